@@ -8,6 +8,7 @@ import (
 	"github.com/ecumenos/ecumenos/accounts/service"
 	"github.com/ecumenos/ecumenos/internal/docs"
 	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
 	gen "github.com/ecumenos/ecumenos/internal/generated/accounts"
 	"github.com/ecumenos/ecumenos/internal/openapi"
 	"go.uber.org/fx"
@@ -53,9 +54,10 @@ func (h *handler) GetInfo(rw http.ResponseWriter, r *http.Request) {
 	deps := h.service.PingServices(ctx)
 	writer := h.responseFactory.NewWriter(rw)
 	_ = writer.WriteSuccess(ctx, gen.GetInfoData{ //nolint:errcheck
-		Name:    string(config.ServiceName),
-		Version: string(config.ServiceVersion),
-		Deps:    deps,
+		Name:            string(config.ServiceName),
+		Version:         string(config.ServiceVersion),
+		ProtocolVersion: int(fxtypes.CurrentProtocolVersion),
+		Deps:            deps,
 	})
 }
 
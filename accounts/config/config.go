@@ -1,15 +1,75 @@
 package config
 
+import (
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/configloader"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
+	"github.com/ecumenos/ecumenos/internal/httputils"
+)
+
 type Config struct {
-	AppAddr    string
-	AppSelfURL string
-	Prod       bool
+	AppAddr                 string        `yaml:"app_addr" env:"APP_ADDR"`
+	AppSelfURL              string        `yaml:"app_self_url" env:"APP_SELF_URL"`
+	Prod                    bool          `yaml:"prod" env:"PROD"`
+	MetricsAddr             string        `yaml:"metrics_addr" env:"METRICS_ADDR"`
+	TracingExporter         string        `yaml:"tracing_exporter" env:"TRACING_EXPORTER"`
+	TracingFile             string        `yaml:"tracing_file" env:"TRACING_FILE"`
+	TracingSampleRatio      float64       `yaml:"tracing_sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	AccessLogSampleRatio    float64       `yaml:"access_log_sample_ratio" env:"ACCESS_LOG_SAMPLE_RATIO"`
+	ReadinessDrainDelay     time.Duration `yaml:"readiness_drain_delay" env:"READINESS_DRAIN_DELAY"`
+	AppDrainTimeout         time.Duration `yaml:"app_drain_timeout" env:"APP_DRAIN_TIMEOUT"`
+	TLSCertFile             string        `yaml:"tls_cert_file" env:"TLS_CERT_FILE"`
+	TLSKeyFile              string        `yaml:"tls_key_file" env:"TLS_KEY_FILE"`
+	AppClientCAFile         string        `yaml:"app_client_ca_file" env:"APP_CLIENT_CA_FILE"`
+	AppCORSAllowedOrigins   []string      `yaml:"app_cors_allowed_origins" env:"APP_CORS_ALLOWED_ORIGINS"`
+	AppCORSAllowCredentials bool          `yaml:"app_cors_allow_credentials" env:"APP_CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge              time.Duration `yaml:"cors_max_age" env:"CORS_MAX_AGE"`
+	TrustedProxies          []string      `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+	ValidateRequests        bool          `yaml:"validate_requests" env:"APP_VALIDATE_REQUESTS"`
 }
 
 func NewDefault() *Config {
 	return &Config{
-		AppAddr:    ":9093",
-		AppSelfURL: "http://localhost:9093",
-		Prod:       false,
+		AppAddr:                 ":9093",
+		AppSelfURL:              "http://localhost:9093",
+		Prod:                    false,
+		MetricsAddr:             ":9193",
+		TracingExporter:         fxtracing.NoneExporter,
+		TracingFile:             "",
+		TracingSampleRatio:      1,
+		AccessLogSampleRatio:    1,
+		ReadinessDrainDelay:     0,
+		AppDrainTimeout:         10 * time.Second,
+		TLSCertFile:             "",
+		TLSKeyFile:              "",
+		AppClientCAFile:         "",
+		AppCORSAllowedOrigins:   nil,
+		AppCORSAllowCredentials: false,
+		CORSMaxAge:              10 * time.Minute,
+		TrustedProxies:          nil,
+		ValidateRequests:        false,
 	}
 }
+
+// StopTimeout is enough to flip readiness and to drain servers.
+func (c *Config) StopTimeout() time.Duration {
+	return c.ReadinessDrainDelay + c.AppDrainTimeout + 5*time.Second
+}
+
+func (c *Config) Validate() error {
+	return configloader.Join(
+		configloader.ValidateAddr("app_addr", c.AppAddr),
+		configloader.ValidateSelfURL("app_self_url", c.AppSelfURL, c.Prod),
+		configloader.ValidateOptionalAddr("metrics_addr", c.MetricsAddr),
+		fxtracing.ValidateExporter(c.TracingExporter, c.TracingFile),
+		configloader.ValidateRatio("tracing_sample_ratio", c.TracingSampleRatio),
+		configloader.ValidateRatio("access_log_sample_ratio", c.AccessLogSampleRatio),
+		configloader.ValidateNonNegative("readiness_drain_delay", c.ReadinessDrainDelay),
+		configloader.ValidateNonNegative("app_drain_timeout", c.AppDrainTimeout),
+		configloader.ValidateTLS(c.TLSCertFile, c.TLSKeyFile),
+		configloader.ValidateClientCA("app_client_ca_file", c.AppClientCAFile, c.TLSCertFile),
+		httputils.ValidateCORS("app_cors", c.AppCORSAllowedOrigins, c.AppCORSAllowCredentials),
+		configloader.ValidateSubnets("trusted_proxies", c.TrustedProxies),
+	)
+}
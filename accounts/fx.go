@@ -1,11 +1,15 @@
 package accounts
 
 import (
+	"github.com/ecumenos/ecumenos/accounts/app"
 	"github.com/ecumenos/ecumenos/accounts/config"
+	"github.com/ecumenos/ecumenos/accounts/service"
 	"go.uber.org/fx"
 )
 
 var Module = fx.Options(
+	service.Module,
+	app.Module,
 	fx.Supply(config.ServiceName),
 	fx.Supply(config.ServiceVersion),
 )
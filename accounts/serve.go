@@ -0,0 +1,32 @@
+package accounts
+
+import (
+	"github.com/ecumenos/ecumenos/accounts/app"
+	"github.com/ecumenos/ecumenos/accounts/config"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
+	"github.com/ecumenos/ecumenos/internal/zerodowntime"
+	"go.uber.org/fx"
+)
+
+// ServerOptions builds fx app serving accounts. Servers are started by
+// ServeApp.
+func ServerOptions(cfg *config.Config) fx.Option {
+	return fx.Options(
+		fx.Provide(func() Configuration {
+			return NewConfiguration(cfg)
+		}),
+		Module,
+		fxlogger.Module,
+		fxmetrics.Module,
+		fxtracing.Module,
+		fxhealth.Module,
+		fx.StopTimeout(cfg.StopTimeout()),
+	)
+}
+
+var ServeApp = fx.Invoke(func(lc fx.Lifecycle, s *app.Server) {
+	zerodowntime.Serve(lc, s)
+})
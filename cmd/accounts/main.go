@@ -1,16 +1,14 @@
 package main
 
 import (
-	"context"
 	"log/slog"
 	"os"
 
 	"github.com/ecumenos/ecumenos/accounts"
-	"github.com/ecumenos/ecumenos/accounts/app"
 	"github.com/ecumenos/ecumenos/accounts/config"
-	"github.com/ecumenos/ecumenos/internal/fxlogger"
 	"github.com/ecumenos/ecumenos/internal/zerodowntime"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 
 	cli "github.com/urfave/cli/v2"
 )
@@ -28,60 +26,42 @@ func run(args []string) error {
 		Usage:   "serving API",
 		Version: string(config.ServiceVersion),
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Usage:   "path to YAML configuration file",
+				EnvVars: []string{"CONFIG_PATH"},
+			},
 			&cli.BoolFlag{
-				Name:    "prod",
-				Value:   false,
-				EnvVars: []string{"PROD"},
+				Name:  "prod",
+				Usage: "overrides prod from configuration",
 			},
 		},
 		Commands: []*cli.Command{
 			runAppCmd,
+			printConfigCmd,
 		},
 	}
 
 	return app.Run(args)
 }
 
-type configuration struct {
-	fx.Out
-
-	Config       *config.Config
-	LoggerConfig *fxlogger.Config
-}
-
 var runAppCmd = &cli.Command{
 	Name:  "run-api-server",
 	Usage: "run API HTTP server",
 	Flags: []cli.Flag{},
 	Action: func(cctx *cli.Context) error {
-		return zerodowntime.HandleApp(fx.New(
-			fx.Options(fx.Provide(func() configuration {
-				cfg := config.NewDefault()
-				cfg.Prod = cctx.Bool("prod")
+		cfg, err := loadConfig(cctx)
+		if err != nil {
+			return err
+		}
+
+		var logger *zap.Logger
+		app := fx.New(
+			accounts.ServerOptions(cfg),
+			accounts.ServeApp,
+			fx.Populate(&logger),
+		)
 
-				return configuration{
-					Config:       cfg,
-					LoggerConfig: &fxlogger.Config{Prod: cctx.Bool("prod")},
-				}
-			})),
-			accounts.Module,
-			fxlogger.Module,
-			fx.Invoke(func(lc fx.Lifecycle, server *app.Server, shutdowner fx.Shutdowner) {
-				lc.Append(fx.Hook{
-					OnStart: func(ctx context.Context) error {
-						go func() {
-							if err := server.Start(ctx); err != nil {
-								slog.Error("zookeeper app server run error", "err", err)
-								return
-							}
-						}()
-						return nil
-					},
-					OnStop: func(ctx context.Context) error {
-						return server.Shutdown(ctx)
-					},
-				})
-			}),
-		))
+		return zerodowntime.HandleApp(logger, app)
 	},
 }
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	cli "github.com/urfave/cli/v2"
+)
+
+var adminsCmd = &cli.Command{
+	Name:  "admins",
+	Usage: "manage admins and their roles",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list admins",
+			Action: func(cctx *cli.Context) error {
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				admins, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) ([]gen.Admin, error) {
+					return c.ListAdmins(ctx)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, admins, adminsTable(admins...))
+			},
+		},
+		{
+			Name:  "create",
+			Usage: "create admin",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "email",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    "password",
+					Usage:   "password of new admin, it is read from stdin if not set",
+					EnvVars: []string{"ECUMCTL_NEW_ADMIN_PASSWORD"},
+				},
+			},
+			Action: func(cctx *cli.Context) error {
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				password := cctx.String("password")
+				if password == "" {
+					if password, err = readPassword(cctx); err != nil {
+						return err
+					}
+				}
+				a, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.Admin, error) {
+					return c.CreateAdmin(ctx, gen.CreateAdminRequest{
+						Email:    openapi_types.Email(cctx.String("email")),
+						Password: password,
+					}, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, a, adminsTable(a))
+			},
+		},
+		{
+			Name:      "grant",
+			Usage:     "assign role to admin",
+			ArgsUsage: "<admin id> <role>",
+			Action: func(cctx *cli.Context) error {
+				id, role, err := adminRoleArgs(cctx)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				a, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.Admin, error) {
+					return c.AssignAdminRole(ctx, id, role)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, a, adminsTable(a))
+			},
+		},
+		{
+			Name:      "revoke",
+			Usage:     "revoke role from admin",
+			ArgsUsage: "<admin id> <role>",
+			Action: func(cctx *cli.Context) error {
+				id, role, err := adminRoleArgs(cctx)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				err = check(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) error {
+					return c.RevokeAdminRole(ctx, id, role)
+				})
+
+				return err
+			},
+		},
+	},
+}
+
+var rolesCmd = &cli.Command{
+	Name:  "roles",
+	Usage: "manage admin roles",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list admin roles",
+			Action: func(cctx *cli.Context) error {
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				roles, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) ([]gen.AdminRole, error) {
+					return c.ListAdminRoles(ctx)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, roles, adminRolesTable(roles...))
+			},
+		},
+		{
+			Name:      "create",
+			Usage:     "create admin role",
+			ArgsUsage: "<name>",
+			Action: func(cctx *cli.Context) error {
+				name, err := argString(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				role, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.AdminRole, error) {
+					return c.CreateAdminRole(ctx, gen.CreateAdminRoleRequest{Name: name}, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, role, adminRolesTable(role))
+			},
+		},
+	},
+}
+
+func adminRoleArgs(cctx *cli.Context) (int64, string, error) {
+	id, err := argID(cctx, 0)
+	if err != nil {
+		return 0, "", err
+	}
+	role, err := argString(cctx, 1)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return id, role, nil
+}
+
+func adminsTable(admins ...gen.Admin) *table {
+	t := &table{headers: []string{"ID", "EMAIL", "ROLES", "CREATED AT"}}
+	for _, a := range admins {
+		a := a
+		t.add(formatID(a.Id), a.Email, strings.Join(a.Roles, ","), formatTime(&a.CreatedAt))
+	}
+
+	return t
+}
+
+func adminRolesTable(roles ...gen.AdminRole) *table {
+	t := &table{headers: []string{"ID", "NAME", "CREATOR ADMIN ID", "CREATED AT"}}
+	for _, r := range roles {
+		r := r
+		t.add(formatID(r.Id), r.Name, formatID(r.CreatorAdminId), formatTime(&r.CreatedAt))
+	}
+
+	return t
+}
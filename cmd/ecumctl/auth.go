@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	cli "github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var profileCmd = &cli.Command{
+	Name:  "profile",
+	Usage: "manage profiles of environments",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list profiles",
+			Action: func(cctx *cli.Context) error {
+				profiles, err := loadProfiles(cctx.String("config"))
+				if err != nil {
+					return err
+				}
+				current := profiles.resolveName("")
+				type item struct {
+					Name     string `json:"name"`
+					URL      string `json:"url"`
+					Email    string `json:"email"`
+					SignedIn bool   `json:"signed_in"`
+					Current  bool   `json:"current"`
+				}
+				items := []item{}
+				t := &table{headers: []string{"NAME", "URL", "EMAIL", "SIGNED IN", "CURRENT"}}
+				for _, name := range profiles.names() {
+					p := profiles.Profiles[name]
+					i := item{Name: name, URL: p.URL, Email: p.Email, SignedIn: p.RefreshToken != "", Current: name == current}
+					items = append(items, i)
+					t.add(i.Name, i.URL, i.Email, fmt.Sprint(i.SignedIn), fmt.Sprint(i.Current))
+				}
+
+				return render(cctx, items, t)
+			},
+		},
+		{
+			Name:      "set",
+			Usage:     "create or update profile",
+			ArgsUsage: "<name>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "url",
+					Usage:    "URL of zookeeper admin API",
+					Required: true,
+				},
+			},
+			Action: func(cctx *cli.Context) error {
+				name, err := argString(cctx, 0)
+				if err != nil {
+					return err
+				}
+				profiles, err := loadProfiles(cctx.String("config"))
+				if err != nil {
+					return err
+				}
+				p, ok := profiles.Profiles[name]
+				if !ok {
+					p = &profile{}
+					profiles.Profiles[name] = p
+				}
+				if p.URL != cctx.String("url") {
+					// Tokens are issued by another environment.
+					*p = profile{URL: cctx.String("url")}
+				}
+				if profiles.Current == "" {
+					profiles.Current = name
+				}
+
+				return profiles.save()
+			},
+		},
+		{
+			Name:      "use",
+			Usage:     "set current profile",
+			ArgsUsage: "<name>",
+			Action: func(cctx *cli.Context) error {
+				name, err := argString(cctx, 0)
+				if err != nil {
+					return err
+				}
+				profiles, err := loadProfiles(cctx.String("config"))
+				if err != nil {
+					return err
+				}
+				if _, err := profiles.get(name); err != nil {
+					return err
+				}
+				profiles.Current = name
+
+				return profiles.save()
+			},
+		},
+		{
+			Name:      "delete",
+			Usage:     "delete profile",
+			ArgsUsage: "<name>",
+			Action: func(cctx *cli.Context) error {
+				name, err := argString(cctx, 0)
+				if err != nil {
+					return err
+				}
+				profiles, err := loadProfiles(cctx.String("config"))
+				if err != nil {
+					return err
+				}
+				delete(profiles.Profiles, name)
+				if profiles.Current == name {
+					profiles.Current = ""
+				}
+
+				return profiles.save()
+			},
+		},
+	},
+}
+
+var loginCmd = &cli.Command{
+	Name:  "login",
+	Usage: "sign in to admin API and cache session tokens in profile",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "email",
+			Usage: "email of admin, defaults to email of the latest sign in",
+		},
+		&cli.StringFlag{
+			Name:    "password",
+			Usage:   "password of admin, it is read from stdin if not set",
+			EnvVars: []string{"ECUMCTL_PASSWORD"},
+		},
+	},
+	Action: func(cctx *cli.Context) error {
+		s, err := newSession(cctx)
+		if err != nil {
+			return err
+		}
+		email := cctx.String("email")
+		if email == "" {
+			email = s.profile.Email
+		}
+		if email == "" {
+			return errors.New("email is required")
+		}
+		password := cctx.String("password")
+		if password == "" {
+			if password, err = readPassword(cctx); err != nil {
+				return err
+			}
+		}
+
+		data, err := s.client.SignIn(cctx.Context, gen.SignInRequest{
+			Email:    openapi_types.Email(email),
+			Password: password,
+		})
+		if err != nil {
+			return err
+		}
+		s.profile.Email = email
+		if err := s.setTokens(data.Token, data.RefreshToken); err != nil {
+			return err
+		}
+		fmt.Fprintf(cctx.App.Writer, "signed in as %v (profile = %v)\n", email, s.name)
+
+		return nil
+	},
+}
+
+var logoutCmd = &cli.Command{
+	Name:  "logout",
+	Usage: "sign out and remove session tokens from profile",
+	Action: func(cctx *cli.Context) error {
+		s, err := newSession(cctx)
+		if err != nil {
+			return err
+		}
+		if s.profile.Token != "" {
+			if err := s.client.SignOutWithBody(cctx.Context, "application/json", nil); err != nil && !isUnauthorized(err) {
+				return err
+			}
+		}
+
+		return s.setTokens("", "")
+	},
+}
+
+// readPassword reads password without echo from terminal. Password piped to
+// stdin is read as the first line.
+func readPassword(cctx *cli.Context) (string, error) {
+	fmt.Fprint(cctx.App.ErrWriter, "password: ")
+	if f, ok := cctx.App.Reader.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		password, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(cctx.App.ErrWriter)
+		if err != nil {
+			return "", fmt.Errorf("can not read password: %w", err)
+		}
+
+		return string(password), nil
+	}
+	line, err := bufio.NewReader(cctx.App.Reader).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("can not read password: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
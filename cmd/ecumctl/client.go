@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	cli "github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// session is admin API client of selected profile.
+type session struct {
+	profiles *profilesFile
+	name     string
+	profile  *profile
+	token    *apiclient.MutableToken
+	client   *gen.TypedClient
+}
+
+func newSession(cctx *cli.Context) (*session, error) {
+	profiles, err := loadProfiles(cctx.String("config"))
+	if err != nil {
+		return nil, err
+	}
+	name := profiles.resolveName(cctx.String("profile"))
+	p, err := profiles.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := zap.NewNop()
+	if cctx.Bool("debug") {
+		if logger, err = zap.NewDevelopment(); err != nil {
+			return nil, err
+		}
+	}
+	token := &apiclient.MutableToken{}
+	token.Set(p.Token)
+	client, err := gen.NewTypedClient(p.URL, gen.WithHTTPClient(apiclient.NewDoer(logger, apiclient.WithToken(token))))
+	if err != nil {
+		return nil, fmt.Errorf("can not create admin API client (url = %v): %w", p.URL, err)
+	}
+
+	return &session{
+		profiles: profiles,
+		name:     name,
+		profile:  p,
+		token:    token,
+		client:   client,
+	}, nil
+}
+
+func (s *session) setTokens(token, refreshToken string) error {
+	s.profile.Token = token
+	s.profile.RefreshToken = refreshToken
+	s.token.Set(token)
+
+	return s.profiles.save()
+}
+
+// refresh replaces expired token with refresh token of profile.
+func (s *session) refresh(ctx context.Context) error {
+	if s.profile.RefreshToken == "" {
+		return fmt.Errorf("not signed in, run `ecumctl login` (profile = %v)", s.name)
+	}
+	data, err := s.client.RefreshSession(ctx, gen.RefreshSessionRequest{
+		RefreshToken: s.profile.RefreshToken,
+	})
+	if err != nil {
+		if isUnauthorized(err) {
+			_ = s.setTokens("", "")
+			return fmt.Errorf("session is expired, run `ecumctl login` (profile = %v)", s.name)
+		}
+		return fmt.Errorf("can not refresh session: %w", err)
+	}
+
+	return s.setTokens(data.Token, data.RefreshToken)
+}
+
+// call sends request to admin API and returns data of response. Request is
+// retried once after refreshing of expired token.
+func call[T any](ctx context.Context, s *session, fn func(ctx context.Context, c *gen.TypedClient) (T, error)) (T, error) {
+	out, err := fn(ctx, s.client)
+	if !isUnauthorized(err) {
+		return out, err
+	}
+	if err := s.refresh(ctx); err != nil {
+		return out, err
+	}
+
+	return fn(ctx, s.client)
+}
+
+// check sends request, which response has no data, to admin API.
+func check(ctx context.Context, s *session, fn func(ctx context.Context, c *gen.TypedClient) error) error {
+	_, err := call(ctx, s, func(ctx context.Context, c *gen.TypedClient) (struct{}, error) {
+		return struct{}{}, fn(ctx, c)
+	})
+
+	return err
+}
+
+func isUnauthorized(err error) bool {
+	var apiErr *apiclient.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
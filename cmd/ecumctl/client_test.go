@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cli "github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+func writeJSend(rw http.ResponseWriter, statusCode int, data interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+	if statusCode >= 400 {
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"status": "error", "message": http.StatusText(statusCode)})
+		return
+	}
+	_ = json.NewEncoder(rw).Encode(map[string]interface{}{"status": "success", "data": data})
+}
+
+func newTestSession(t *testing.T, url string) *session {
+	profiles, err := loadProfiles(filepath.Join(t.TempDir(), "config.yaml"))
+	require.NoError(t, err)
+	p := &profile{URL: url, Token: "expired", RefreshToken: "refresh"}
+	profiles.Profiles[defaultProfileName] = p
+	token := &apiclient.MutableToken{}
+	token.Set(p.Token)
+	client, err := gen.NewTypedClient(url, gen.WithHTTPClient(apiclient.NewDoer(zap.NewNop(), apiclient.WithToken(token))))
+	require.NoError(t, err)
+
+	return &session{profiles: profiles, name: defaultProfileName, profile: p, token: token, client: client}
+}
+
+func TestCallRefreshesExpiredToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/refresh-session":
+			writeJSend(rw, http.StatusOK, gen.RefreshSessionResponseData{Token: "fresh", RefreshToken: "refresh2"})
+		case "/admins":
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				writeJSend(rw, http.StatusUnauthorized, nil)
+				return
+			}
+			writeJSend(rw, http.StatusOK, []gen.Admin{{Email: "admin@example.com"}})
+		default:
+			writeJSend(rw, http.StatusNotFound, nil)
+		}
+	}))
+	defer srv.Close()
+	s := newTestSession(t, srv.URL)
+
+	admins, err := call(context.Background(), s, func(ctx context.Context, c *gen.TypedClient) ([]gen.Admin, error) {
+		return c.ListAdmins(ctx)
+	})
+	require.NoError(t, err)
+	require.Len(t, admins, 1)
+	assert.Equal(t, "fresh", s.profile.Token)
+	assert.Equal(t, "refresh2", s.profile.RefreshToken)
+}
+
+func TestReadPasswordFromPipe(t *testing.T) {
+	var stderr bytes.Buffer
+	app := &cli.App{Reader: strings.NewReader("secret\n"), ErrWriter: &stderr}
+
+	password, err := readPassword(cli.NewContext(app, nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", password)
+	assert.NotContains(t, stderr.String(), "secret")
+}
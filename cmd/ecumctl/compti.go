@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	cli "github.com/urfave/cli/v2"
+)
+
+var comptiCmd = &cli.Command{
+	Name:  "compti",
+	Usage: "manage compti",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list compti, the newest first",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "email",
+					Usage: "filter by email",
+				},
+				&cli.IntFlag{
+					Name:  "limit",
+					Value: 50,
+				},
+				&cli.IntFlag{
+					Name: "offset",
+				},
+			},
+			Action: func(cctx *cli.Context) error {
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				limit, offset := cctx.Int("limit"), cctx.Int("offset")
+				params := &gen.ListComptiParams{Limit: &limit, Offset: &offset}
+				if email := cctx.String("email"); email != "" {
+					params.Email = &email
+				}
+				compti, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) ([]gen.Comptus, error) {
+					return c.ListCompti(ctx, params)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, compti, comptiTable(compti...))
+			},
+		},
+		{
+			Name:      "get",
+			Usage:     "show comptus",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				comptus, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.Comptus, error) {
+					return c.GetComptus(ctx, id)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, comptus, comptiTable(comptus))
+			},
+		},
+		{
+			Name:      "delete",
+			Usage:     "delete comptus and sign out all its sessions",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				err = check(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) error {
+					return c.DeleteComptus(ctx, id)
+				})
+
+				return err
+			},
+		},
+		{
+			Name:      "suspend",
+			Usage:     "suspend comptus and sign out all its sessions",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				comptus, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.Comptus, error) {
+					return c.SuspendComptus(ctx, id, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, comptus, comptiTable(comptus))
+			},
+		},
+		{
+			Name:      "unsuspend",
+			Usage:     "lift suspension of comptus",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				comptus, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.Comptus, error) {
+					return c.UnsuspendComptus(ctx, id, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, comptus, comptiTable(comptus))
+			},
+		},
+	},
+}
+
+func comptiTable(compti ...gen.Comptus) *table {
+	t := &table{headers: []string{"ID", "EMAIL", "COUNTRY", "LANGUAGE", "CREATED AT", "SUSPENDED AT"}}
+	for _, c := range compti {
+		c := c
+		t.add(formatID(c.Id), c.Email, c.Country, c.Language, formatTime(&c.CreatedAt), formatTime(c.SuspendedAt))
+	}
+
+	return t
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	cli "github.com/urfave/cli/v2"
+)
+
+var launchRequestsCmd = &cli.Command{
+	Name:    "launch-requests",
+	Aliases: []string{"lr"},
+	Usage:   "review launch requests of orbes socii",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list launch requests, the newest first",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "status",
+					Usage: "filter by status (pending, viewed, approved or rejected)",
+				},
+			},
+			Action: func(cctx *cli.Context) error {
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				params := &gen.ListOrbisSociusLaunchRequestsParams{}
+				if status := cctx.String("status"); status != "" {
+					st := gen.OrbisSociusLaunchRequestStatus(status)
+					params.Status = &st
+				}
+				requests, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) ([]gen.OrbisSociusLaunchRequest, error) {
+					return c.ListOrbisSociusLaunchRequests(ctx, params)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, requests, launchRequestsTable(requests...))
+			},
+		},
+		{
+			Name:      "get",
+			Usage:     "show launch request, pending request is marked as viewed",
+			ArgsUsage: "<id>",
+			Action: launchRequestAction(func(ctx context.Context, c *gen.TypedClient, id int64) (gen.OrbisSociusLaunchRequest, error) {
+				return c.GetOrbisSociusLaunchRequest(ctx, id)
+			}),
+		},
+		{
+			Name:      "approve",
+			Usage:     "approve launch request and create launch invite",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				data, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.ApproveOrbisSociusLaunchRequestResponseData, error) {
+					return c.ApproveOrbisSociusLaunchRequest(ctx, id, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+				t := launchRequestsTable(data.LaunchRequest)
+				t.headers = append(t.headers, "INVITE CODE", "API KEY", "INVITE EXPIRES AT")
+				t.rows[0] = append(t.rows[0], data.Invite.Code, data.Invite.ApiKey, formatTime(&data.Invite.ExpiredAt))
+
+				return render(cctx, data, t)
+			},
+		},
+		{
+			Name:      "reject",
+			Usage:     "reject launch request",
+			ArgsUsage: "<id>",
+			Action: launchRequestAction(func(ctx context.Context, c *gen.TypedClient, id int64) (gen.OrbisSociusLaunchRequest, error) {
+				return c.RejectOrbisSociusLaunchRequest(ctx, id, apiclient.IdempotencyKey())
+			}),
+		},
+	},
+}
+
+func launchRequestAction(fn func(ctx context.Context, c *gen.TypedClient, id int64) (gen.OrbisSociusLaunchRequest, error)) cli.ActionFunc {
+	return func(cctx *cli.Context) error {
+		id, err := argID(cctx, 0)
+		if err != nil {
+			return err
+		}
+		s, err := newSession(cctx)
+		if err != nil {
+			return err
+		}
+		request, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.OrbisSociusLaunchRequest, error) {
+			return fn(ctx, c, id)
+		})
+		if err != nil {
+			return err
+		}
+
+		return render(cctx, request, launchRequestsTable(request))
+	}
+}
+
+func launchRequestsTable(requests ...gen.OrbisSociusLaunchRequest) *table {
+	t := &table{headers: []string{"ID", "STATUS", "NAME", "URL", "REGION", "COMPTUS ID", "CREATED AT"}}
+	for _, r := range requests {
+		r := r
+		t.add(formatID(r.Id), string(r.Status), r.Name, r.Url, r.Region, formatID(r.ComptusId), formatTime(&r.CreatedAt))
+	}
+
+	return t
+}
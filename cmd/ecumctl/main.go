@@ -0,0 +1,91 @@
+// Command ecumctl is CLI for zookeeper admin API.
+//
+//	ecumctl profile set prod --url https://admin.zookeeper.example.com
+//	ecumctl --profile prod login --email admin@example.com
+//	ecumctl --profile prod launch-requests list --status pending
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+const version = "0.1.0"
+
+func main() {
+	if err := run(os.Args); err != nil {
+		slog.Error("exiting", "err", err)
+		os.Exit(-1)
+	}
+}
+
+func run(args []string) error {
+	app := cli.App{
+		Name:    "ecumctl",
+		Usage:   "managing ecumenos network with zookeeper admin API",
+		Version: version,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Usage:   "path to YAML file with profiles",
+				Value:   defaultProfilesPath(),
+				EnvVars: []string{"ECUMCTL_CONFIG"},
+			},
+			&cli.StringFlag{
+				Name:    "profile",
+				Aliases: []string{"p"},
+				Usage:   "profile of environment, overrides current profile",
+				EnvVars: []string{"ECUMCTL_PROFILE"},
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "output format (table or json)",
+				Value:   outputTable,
+				EnvVars: []string{"ECUMCTL_OUTPUT"},
+			},
+			&cli.BoolFlag{
+				Name:  "debug",
+				Usage: "log HTTP requests",
+			},
+		},
+		Commands: []*cli.Command{
+			profileCmd,
+			loginCmd,
+			logoutCmd,
+			launchRequestsCmd,
+			adminsCmd,
+			rolesCmd,
+			comptiCmd,
+			orbesSociiCmd,
+		},
+	}
+
+	return app.Run(args)
+}
+
+func argID(cctx *cli.Context, i int) (int64, error) {
+	arg := cctx.Args().Get(i)
+	if arg == "" {
+		return 0, fmt.Errorf("missing argument %d, usage: %v", i+1, cctx.Command.ArgsUsage)
+	}
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id (id = %v): %w", arg, err)
+	}
+
+	return id, nil
+}
+
+func argString(cctx *cli.Context, i int) (string, error) {
+	arg := cctx.Args().Get(i)
+	if arg == "" {
+		return "", fmt.Errorf("missing argument %d, usage: %v", i+1, cctx.Command.ArgsUsage)
+	}
+
+	return arg, nil
+}
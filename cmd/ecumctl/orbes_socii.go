@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	cli "github.com/urfave/cli/v2"
+)
+
+var orbesSociiCmd = &cli.Command{
+	Name:    "orbes-socii",
+	Aliases: []string{"os"},
+	Usage:   "inspect orbes socii, rotate their API keys and manage webhooks",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list orbes socii with their health",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "region",
+					Usage: "filter by region code",
+				},
+			},
+			Action: func(cctx *cli.Context) error {
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				params := &gen.ListOrbesSociiParams{}
+				if region := cctx.String("region"); region != "" {
+					params.Region = &region
+				}
+				orbesSocii, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) ([]gen.OrbisSocius, error) {
+					return c.ListOrbesSocii(ctx, params)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, orbesSocii, orbesSociiTable(orbesSocii...))
+			},
+		},
+		{
+			Name:      "health",
+			Usage:     "show health of orbis socius and its latest pings",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				health, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.OrbisSociusHealth, error) {
+					return c.GetOrbisSociusHealth(ctx, id)
+				})
+				if err != nil {
+					return err
+				}
+				t := orbesSociiTable(health.OrbisSocius)
+				t.headers = append(t.headers, "AVAILABILITY", "PINGS", "VERSION", "MEMBERS")
+				var version, members string
+				// stats are the newest first, the latest heartbeat has version.
+				for _, stat := range health.Stats {
+					if stat.Version != nil {
+						version = *stat.Version
+						members = fmt.Sprint(valueOrZero(stat.Members))
+						if capacity := valueOrZero(stat.Capacity); capacity > 0 {
+							members += fmt.Sprintf("/%v", capacity)
+						}
+						break
+					}
+				}
+				t.rows[0] = append(t.rows[0], fmt.Sprintf("%.1f%%", health.Availability*100), fmt.Sprint(len(health.Stats)), version, members)
+
+				return render(cctx, health, t)
+			},
+		},
+		{
+			Name:      "rotate-key",
+			Usage:     "generate new API key of orbis socius, previous key stops working immediately",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				data, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.RotateOrbisSociusAPIKeyResponseData, error) {
+					return c.RotateOrbisSociusAPIKey(ctx, id, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+				t := &table{headers: []string{"ID", "API KEY"}}
+				t.add(formatID(data.Id), data.ApiKey)
+
+				return render(cctx, data, t)
+			},
+		},
+		{
+			Name:      "min-protocol-version",
+			Usage:     "show minimal protocol version of orbes socii or set it, outdated orbes socii are marked as non-compliant",
+			ArgsUsage: "[<version>]",
+			Action: func(cctx *cli.Context) error {
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				var data gen.OrbisSociusMinProtocolVersion
+				if cctx.Args().Present() {
+					v, err := strconv.Atoi(cctx.Args().First())
+					if err != nil {
+						return fmt.Errorf("invalid version (version = %v): %w", cctx.Args().First(), err)
+					}
+					data, err = call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.OrbisSociusMinProtocolVersion, error) {
+						return c.SetOrbisSociusMinProtocolVersion(ctx, gen.SetOrbisSociusMinProtocolVersionJSONRequestBody{MinProtocolVersion: v})
+					})
+					if err != nil {
+						return err
+					}
+				} else {
+					data, err = call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.OrbisSociusMinProtocolVersion, error) {
+						return c.GetOrbisSociusMinProtocolVersion(ctx)
+					})
+					if err != nil {
+						return err
+					}
+				}
+				t := &table{headers: []string{"MIN PROTOCOL VERSION", "CURRENT PROTOCOL VERSION", "NON-COMPLIANT"}}
+				t.add(fmt.Sprint(data.MinProtocolVersion), fmt.Sprint(data.CurrentProtocolVersion), fmt.Sprint(data.NonCompliant))
+
+				return render(cctx, data, t)
+			},
+		},
+		{
+			Name:      "delist",
+			Usage:     "remove orbis socius from listings and notify its operator",
+			ArgsUsage: "<id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				os, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.OrbisSocius, error) {
+					return c.DelistOrbisSocius(ctx, id, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, os, orbesSociiTable(os))
+			},
+		},
+		{
+			Name:      "set-webhook",
+			Usage:     "set webhook URL of orbis socius and print its signing secret",
+			ArgsUsage: "<id> <url>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				webhookURL, err := argString(cctx, 1)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				webhook, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.OrbisSociusWebhook, error) {
+					return c.SetOrbisSociusWebhook(ctx, id, gen.SetOrbisSociusWebhookJSONRequestBody{Url: webhookURL})
+				})
+				if err != nil {
+					return err
+				}
+				t := &table{headers: []string{"ID", "URL", "SECRET"}}
+				t.add(formatID(webhook.OrbisSociusId), webhook.Url, webhook.Secret)
+
+				return render(cctx, webhook, t)
+			},
+		},
+		{
+			Name:      "deliveries",
+			Usage:     "list latest webhook deliveries of orbis socius",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "status",
+					Usage: "filter by status (scheduled, delivered, failed)",
+				},
+			},
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				params := &gen.ListWebhookDeliveriesParams{}
+				if status := cctx.String("status"); status != "" {
+					st := gen.WebhookDeliveryStatus(status)
+					params.Status = &st
+				}
+				deliveries, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) ([]gen.WebhookDelivery, error) {
+					return c.ListWebhookDeliveries(ctx, id, params)
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, deliveries, webhookDeliveriesTable(deliveries...))
+			},
+		},
+		{
+			Name:      "redeliver",
+			Usage:     "send webhook delivery again",
+			ArgsUsage: "<delivery-id>",
+			Action: func(cctx *cli.Context) error {
+				id, err := argID(cctx, 0)
+				if err != nil {
+					return err
+				}
+				s, err := newSession(cctx)
+				if err != nil {
+					return err
+				}
+				d, err := call(cctx.Context, s, func(ctx context.Context, c *gen.TypedClient) (gen.WebhookDelivery, error) {
+					return c.RedeliverWebhook(ctx, id, apiclient.IdempotencyKey())
+				})
+				if err != nil {
+					return err
+				}
+
+				return render(cctx, d, webhookDeliveriesTable(d))
+			},
+		},
+	},
+}
+
+func orbesSociiTable(orbesSocii ...gen.OrbisSocius) *table {
+	t := &table{headers: []string{"ID", "NAME", "URL", "REGION", "ALIVE", "ROBUSTNESS", "PROTOCOL", "COMPLIANT", "LAST PINGED AT"}}
+	for _, o := range orbesSocii {
+		var protocol string
+		if o.ProtocolVersion != nil {
+			protocol = fmt.Sprint(*o.ProtocolVersion)
+		}
+		t.add(formatID(o.Id), o.Name, o.Url, o.Region, fmt.Sprint(o.Alive), string(o.RobustnessStatus), protocol, fmt.Sprint(o.Compliant), formatTime(o.LastPingedAt))
+	}
+
+	return t
+}
+
+func webhookDeliveriesTable(deliveries ...gen.WebhookDelivery) *table {
+	t := &table{headers: []string{"ID", "EVENT", "STATUS", "ATTEMPTS", "LAST STATUS CODE", "NEXT ATTEMPT AT", "DELIVERED AT"}}
+	for _, d := range deliveries {
+		var code string
+		if d.LastStatusCode != nil {
+			code = fmt.Sprint(*d.LastStatusCode)
+		}
+		t.add(formatID(d.Id), string(d.Event), string(d.Status), fmt.Sprint(d.Attempts), code, formatTime(d.NextAttemptAt), formatTime(d.DeliveredAt))
+	}
+
+	return t
+}
+
+func valueOrZero[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// table is tabular view of command result.
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+func (t *table) add(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// render writes v as JSON or t as table depending on --output flag.
+func render(cctx *cli.Context, v interface{}, t *table) error {
+	w := cctx.App.Writer
+	switch format := cctx.String("output"); format {
+	case outputJSON:
+		return writeJSON(w, v)
+	case outputTable, "":
+		return writeTable(w, t)
+	default:
+		return fmt.Errorf("unknown output format (output = %v)", format)
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+func writeTable(w io.Writer, t *table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.headers, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+
+	return t.Local().Format(time.RFC3339)
+}
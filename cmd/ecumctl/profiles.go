@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultProfileName = "default"
+
+// profile is admin API environment with cached session tokens.
+type profile struct {
+	URL          string `yaml:"url"`
+	Email        string `yaml:"email,omitempty"`
+	Token        string `yaml:"token,omitempty"`
+	RefreshToken string `yaml:"refresh_token,omitempty"`
+}
+
+type profilesFile struct {
+	Current  string              `yaml:"current"`
+	Profiles map[string]*profile `yaml:"profiles"`
+
+	path string
+}
+
+func defaultProfilesPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".ecumctl.yaml"
+	}
+
+	return filepath.Join(dir, "ecumctl", "config.yaml")
+}
+
+func loadProfiles(path string) (*profilesFile, error) {
+	f := &profilesFile{Profiles: map[string]*profile{}, path: path}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can not read config file (path = %v): %w", path, err)
+	}
+	if err := yaml.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("can not decode config file (path = %v): %w", path, err)
+	}
+	if f.Profiles == nil {
+		f.Profiles = map[string]*profile{}
+	}
+
+	return f, nil
+}
+
+// save writes config file. It contains session tokens, so it is readable by
+// owner only.
+func (f *profilesFile) save() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("can not create config directory (path = %v): %w", f.path, err)
+	}
+	b, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(f.path, b, 0o600); err != nil {
+		return fmt.Errorf("can not write config file (path = %v): %w", f.path, err)
+	}
+
+	return nil
+}
+
+// resolveName returns name of profile which should be used. Flag has
+// priority over current profile of config file.
+func (f *profilesFile) resolveName(name string) string {
+	if name != "" {
+		return name
+	}
+	if f.Current != "" {
+		return f.Current
+	}
+
+	return defaultProfileName
+}
+
+func (f *profilesFile) get(name string) (*profile, error) {
+	p, ok := f.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile is not found, create it with `ecumctl profile set` (profile = %v)", name)
+	}
+	if p.URL == "" {
+		return nil, fmt.Errorf("profile has no admin API url (profile = %v)", name)
+	}
+
+	return p, nil
+}
+
+func (f *profilesFile) names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
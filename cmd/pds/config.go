@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ecumenos/ecumenos/internal/configloader"
+	"github.com/ecumenos/ecumenos/pds/config"
+	cli "github.com/urfave/cli/v2"
+)
+
+// readConfig builds effective configuration: defaults, then config file,
+// then environment, then command line flags.
+func readConfig(cctx *cli.Context) (*config.Config, error) {
+	cfg := config.NewDefault()
+	if err := configloader.Load(cfg, cctx.String("config")); err != nil {
+		return nil, err
+	}
+	if cctx.IsSet("prod") {
+		cfg.Prod = cctx.Bool("prod")
+	}
+	if cctx.IsSet("pg_url") {
+		cfg.PostgresURL = cctx.String("pg_url")
+	}
+
+	return cfg, nil
+}
+
+func loadConfig(cctx *cli.Context) (*config.Config, error) {
+	cfg, err := readConfig(cctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+var printConfigCmd = &cli.Command{
+	Name:  "print-config",
+	Usage: "print effective configuration with secrets redacted",
+	Flags: []cli.Flag{},
+	Action: func(cctx *cli.Context) error {
+		cfg, err := readConfig(cctx)
+		if err != nil {
+			return err
+		}
+		if err := configloader.Print(os.Stdout, cfg); err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "configuration is invalid:\n%v\n", err)
+		}
+
+		return nil
+	},
+}
@@ -2,11 +2,12 @@ package main
 
 import (
 	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
 	"github.com/ecumenos/ecumenos/internal/zerodowntime"
 	"github.com/ecumenos/ecumenos/zookeeper"
-	"github.com/ecumenos/ecumenos/zookeeper/config"
 	cli "github.com/urfave/cli/v2"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 var migrateUpCmd = &cli.Command{
@@ -14,26 +15,26 @@ var migrateUpCmd = &cli.Command{
 	Usage: "run migrations up",
 	Flags: []cli.Flag{},
 	Action: func(cctx *cli.Context) error {
-		app := fx.New(
-			fx.Options(fx.Provide(func() configuration {
-				cfg := config.NewDefault()
-				cfg.Prod = cctx.Bool("prod")
-				cfg.PostgresURL = cctx.String("pg_url")
-				cfg.JWTSecret = []byte(cctx.String("jwt_secret"))
+		cfg, err := loadConfig(cctx)
+		if err != nil {
+			return err
+		}
 
-				return configuration{
-					Config:       cfg,
-					LoggerConfig: &fxlogger.Config{Prod: cctx.Bool("prod")},
-				}
+		var logger *zap.Logger
+		app := fx.New(
+			fx.Options(fx.Provide(func() zookeeper.Configuration {
+				return zookeeper.NewConfiguration(cfg)
 			})),
 			zookeeper.Module,
 			fxlogger.Module,
+			fxmetrics.Module,
 			fx.Invoke(func(runner *zookeeper.MigrationsRunner) error {
 				return runner.MigrateUp()
 			}),
+			fx.Populate(&logger),
 		)
 
-		return zerodowntime.HandleApp(app)
+		return zerodowntime.HandleApp(logger, app)
 	},
 }
 
@@ -42,25 +43,25 @@ var migrateDownCmd = &cli.Command{
 	Usage: "run migrations down",
 	Flags: []cli.Flag{},
 	Action: func(cctx *cli.Context) error {
-		app := fx.New(
-			fx.Options(fx.Provide(func() configuration {
-				cfg := config.NewDefault()
-				cfg.Prod = cctx.Bool("prod")
-				cfg.PostgresURL = cctx.String("pg_url")
-				cfg.JWTSecret = []byte(cctx.String("jwt_secret"))
+		cfg, err := loadConfig(cctx)
+		if err != nil {
+			return err
+		}
 
-				return configuration{
-					Config:       cfg,
-					LoggerConfig: &fxlogger.Config{Prod: cctx.Bool("prod")},
-				}
+		var logger *zap.Logger
+		app := fx.New(
+			fx.Options(fx.Provide(func() zookeeper.Configuration {
+				return zookeeper.NewConfiguration(cfg)
 			})),
 			zookeeper.Module,
 			fxlogger.Module,
+			fxmetrics.Module,
 			fx.Invoke(func(runner *zookeeper.MigrationsRunner) error {
 				return runner.MigrateDown()
 			}),
+			fx.Populate(&logger),
 		)
 
-		return zerodowntime.HandleApp(app)
+		return zerodowntime.HandleApp(logger, app)
 	},
 }
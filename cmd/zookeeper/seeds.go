@@ -2,126 +2,493 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
+	"io"
+	"os"
+	"reflect"
+	"strings"
 
+	"github.com/ecumenos/ecumenos/internal/fxappsettings"
 	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
 	"github.com/ecumenos/ecumenos/internal/zerodowntime"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
 	"github.com/ecumenos/ecumenos/zookeeper"
-	"github.com/ecumenos/ecumenos/zookeeper/config"
 	"github.com/ecumenos/ecumenos/zookeeper/service"
 	cli "github.com/urfave/cli/v2"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
 )
 
 var runSeedsCmd = &cli.Command{
 	Name:  "run-seeds",
 	Usage: "run seeds for the service",
-	Flags: []cli.Flag{},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "seeds_path",
+			Usage:   "path to seeds file (YAML or JSON)",
+			Value:   "./cmd/zookeeper/configurations/seeds.yaml",
+			EnvVars: []string{"SEEDS_PATH"},
+		},
+		&cli.BoolFlag{
+			Name:    "dry_run",
+			Usage:   "print what would change without writing anything",
+			Value:   false,
+			EnvVars: []string{"SEEDS_DRY_RUN"},
+		},
+	},
 	Action: func(cctx *cli.Context) error {
-		return zerodowntime.HandleApp(fx.New(
-			fx.Options(fx.Provide(func() configuration {
-				cfg := config.NewDefault()
-				cfg.Prod = cctx.Bool("prod")
-				cfg.PostgresURL = cctx.String("pg_url")
-				cfg.JWTSecret = []byte(cctx.String("jwt_secret"))
-
-				return configuration{
-					Config:       cfg,
-					LoggerConfig: &fxlogger.Config{Prod: cctx.Bool("prod")},
-				}
+		cfg, err := loadConfig(cctx)
+		if err != nil {
+			return err
+		}
+
+		file, err := loadSeedsFile(cctx.String("seeds_path"))
+		if err != nil {
+			return err
+		}
+
+		var logger *zap.Logger
+		app := fx.New(
+			fx.Options(fx.Provide(func() zookeeper.Configuration {
+				return zookeeper.NewConfiguration(cfg)
 			})),
 			zookeeper.Module,
 			fxlogger.Module,
+			fxmetrics.Module,
+			fxappsettings.Module,
 			fx.Invoke(func(lc fx.Lifecycle, shutdowner fx.Shutdowner, l *zap.Logger, s *service.Service) {
-				defer func() { _ = shutdowner.Shutdown() }()
+				lc.Append(fx.Hook{
+					OnStart: func(ctx context.Context) error {
+						defer func() { _ = shutdowner.Shutdown() }()
 
-				ctx, cancel := context.WithCancel(context.Background())
-				defer cancel()
+						sd := newSeeder(s, os.Stdout, cctx.Bool("dry_run"))
+						if err := sd.run(ctx, file); err != nil {
+							l.Error("run seeds error", zap.Error(err))
+							return err
+						}
+						l.Info("seeds are applied", zap.Int("changes", sd.changes), zap.Bool("dry_run", sd.dryRun))
 
-				if err := seeds(ctx, l, s); err != nil {
-					slog.Error("run seeds error", "err", err)
-					return
-				}
+						return nil
+					},
+				})
 			}),
-		))
+			fx.Populate(&logger),
+		)
+
+		return zerodowntime.HandleApp(logger, app)
 	},
 }
 
-func seeds(ctx context.Context, l *zap.Logger, s *service.Service) error {
-	var (
-		superAdminEmail = "superadmin@mail.com"
-		admins          = map[string]struct {
-			password string
-			roles    []string
-		}{
-			superAdminEmail: {
-				password: "Password123!",
-				roles:    []string{"superadmin"},
-			},
-		}
-		adminsMap = map[string]*models.Admin{}
-	)
-
-	var (
-		roles = map[string]struct {
-			creator     string
-			permissions models.AdminRolePermissions
-		}{
-			"superadmin": {
-				creator:     superAdminEmail,
-				permissions: models.AdminRolePermissions{},
-			},
-		}
-		rolesMap = map[string]*models.AdminRole{}
-	)
-
-	for email, data := range admins {
-		var err error
-		admin, err := s.CreateAdmin(ctx, email, data.password)
+// seedsFile describes desired state of zookeeper. Passwords and API keys are
+// never stored in the file itself, they are referenced by environment
+// variable name or by path to a secret file.
+type seedsFile struct {
+	Admins     []seedAdmin       `yaml:"admins" json:"admins"`
+	Roles      []seedRole        `yaml:"roles" json:"roles"`
+	Grants     []seedGrant       `yaml:"grants" json:"grants"`
+	Compti     []seedComptus     `yaml:"compti" json:"compti"`
+	OrbesSocii []seedOrbisSocius `yaml:"orbes_socii" json:"orbes_socii"`
+}
+
+type seedSecret struct {
+	Env  string `yaml:"env" json:"env"`
+	File string `yaml:"file" json:"file"`
+}
+
+type seedAdmin struct {
+	Email    string     `yaml:"email" json:"email"`
+	Password seedSecret `yaml:"password" json:"password"`
+}
+
+type seedRole struct {
+	Name        string                      `yaml:"name" json:"name"`
+	Creator     string                      `yaml:"creator" json:"creator"`
+	Permissions models.AdminRolePermissions `yaml:"permissions" json:"permissions"`
+}
+
+type seedGrant struct {
+	Admin   string `yaml:"admin" json:"admin"`
+	Role    string `yaml:"role" json:"role"`
+	Granter string `yaml:"granter" json:"granter"`
+}
+
+type seedComptus struct {
+	Email    string     `yaml:"email" json:"email"`
+	Password seedSecret `yaml:"password" json:"password"`
+	Country  string     `yaml:"country" json:"country"`
+	Language string     `yaml:"language" json:"language"`
+}
+
+type seedOrbisSocius struct {
+	Name        string     `yaml:"name" json:"name"`
+	Description string     `yaml:"description" json:"description"`
+	URL         string     `yaml:"url" json:"url"`
+	Region      string     `yaml:"region" json:"region"`
+	Owner       string     `yaml:"owner" json:"owner"`
+	Approver    string     `yaml:"approver" json:"approver"`
+	APIKey      seedSecret `yaml:"api_key" json:"api_key"`
+}
+
+// loadSeedsFile reads seeds file. JSON is a subset of YAML, so both formats
+// are decoded by YAML decoder.
+func loadSeedsFile(path string) (*seedsFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read seeds file (path = %v): %w", path, err)
+	}
+
+	var f seedsFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("can not decode seeds file (path = %v): %w", path, err)
+	}
+
+	return &f, nil
+}
+
+func resolveSecret(s seedSecret) (string, error) {
+	switch {
+	case s.Env != "" && s.File != "":
+		return "", fmt.Errorf("secret must reference either env or file, not both (env = %v, file = %v)", s.Env, s.File)
+	case s.Env != "":
+		v := os.Getenv(s.Env)
+		if v == "" {
+			return "", fmt.Errorf("environment variable is empty (env = %v)", s.Env)
+		}
+		return v, nil
+	case s.File != "":
+		b, err := os.ReadFile(s.File)
 		if err != nil {
+			return "", fmt.Errorf("can not read secret file (file = %v): %w", s.File, err)
+		}
+		v := strings.TrimSpace(string(b))
+		if v == "" {
+			return "", fmt.Errorf("secret file is empty (file = %v)", s.File)
+		}
+		return v, nil
+	default:
+		return "", errors.New("secret is not referenced, set env or file")
+	}
+}
+
+// seedService is part of zookeeper service used by seeder.
+type seedService interface {
+	GetAdminByEmail(ctx context.Context, email string) (*models.Admin, error)
+	CreateAdmin(ctx context.Context, email, password string) (*models.Admin, error)
+	ValidateAdminCredentials(ctx context.Context, email, password string) error
+	UpdateAdminPassword(ctx context.Context, adminID int64, password string) error
+	GetAdminRoleByName(ctx context.Context, name string) (*models.AdminRole, error)
+	CreateAdminRole(ctx context.Context, name string, permissions models.AdminRolePermissions, creatorID int64) (*models.AdminRole, error)
+	UpdateAdminRolePermissions(ctx context.Context, roleID int64, permissions models.AdminRolePermissions) error
+	IsRoleAssignedToAdmin(ctx context.Context, adminID, roleID int64) (bool, error)
+	AssignRoleForAdmin(ctx context.Context, adminID, roleID int64, granterID *int64) error
+	GetComptusByEmail(ctx context.Context, email string) (*models.Comptus, error)
+	CreateComptus(ctx context.Context, email, password, patria, lingua string) (*models.Comptus, error)
+	ValidateComptusCredentials(ctx context.Context, email, password string) error
+	UpdateComptusPassword(ctx context.Context, comptusID int64, password string) error
+	GetOrbisSociusByURL(ctx context.Context, url string) (*models.OrbisSocius, error)
+	CreateOrbisSocius(ctx context.Context, ownerID int64, approverID *int64, region, name, desc, url, apiKey string) (*models.OrbisSocius, error)
+	UpdateOrbisSocius(ctx context.Context, id, ownerID int64, approverID *int64, region, name, desc, apiKey string) error
+}
+
+var _ seedService = (*service.Service)(nil)
+
+type seeder struct {
+	service seedService
+	out     io.Writer
+	dryRun  bool
+	changes int
+
+	admins map[string]*models.Admin
+	roles  map[string]*models.AdminRole
+	compti map[string]*models.Comptus
+}
+
+func newSeeder(s seedService, out io.Writer, dryRun bool) *seeder {
+	return &seeder{
+		service: s,
+		out:     out,
+		dryRun:  dryRun,
+		admins:  map[string]*models.Admin{},
+		roles:   map[string]*models.AdminRole{},
+		compti:  map[string]*models.Comptus{},
+	}
+}
+
+// change reports the change and returns true if it must be applied.
+func (sd *seeder) change(format string, args ...interface{}) bool {
+	sd.changes++
+	prefix := "apply"
+	if sd.dryRun {
+		prefix = "dry-run"
+	}
+	fmt.Fprintf(sd.out, "[%s] %s\n", prefix, fmt.Sprintf(format, args...))
+
+	return !sd.dryRun
+}
+
+func (sd *seeder) run(ctx context.Context, f *seedsFile) error {
+	for _, a := range f.Admins {
+		if err := sd.seedAdmin(ctx, a); err != nil {
+			return err
+		}
+	}
+	for _, r := range f.Roles {
+		if err := sd.seedRole(ctx, r); err != nil {
+			return err
+		}
+	}
+	for _, g := range f.Grants {
+		if err := sd.seedGrant(ctx, g); err != nil {
+			return err
+		}
+	}
+	for _, c := range f.Compti {
+		if err := sd.seedComptus(ctx, c); err != nil {
 			return err
 		}
-		adminsMap[email] = admin
 	}
+	for _, o := range f.OrbesSocii {
+		if err := sd.seedOrbisSocius(ctx, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-	for roleName, data := range roles {
-		var creatorID int64
-		if data.creator != "" {
-			admin, ok := adminsMap[data.creator]
-			if !ok {
-				return fmt.Errorf("admin is not found (email = %v)", data.creator)
+func (sd *seeder) seedAdmin(ctx context.Context, a seedAdmin) error {
+	password, err := resolveSecret(a.Password)
+	if err != nil {
+		return fmt.Errorf("admin password (email = %v): %w", a.Email, err)
+	}
+	if err := models.VerifyPassword(password); err != nil {
+		return fmt.Errorf("admin password is weak (email = %v): %w", a.Email, err)
+	}
+
+	admin, err := sd.service.GetAdminByEmail(ctx, a.Email)
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		admin = &models.Admin{Email: a.Email}
+		if sd.change("create admin (email = %v)", a.Email) {
+			if admin, err = sd.service.CreateAdmin(ctx, a.Email, password); err != nil {
+				return err
 			}
-			creatorID = admin.ID
 		}
-		role, err := s.CreateAdminRole(ctx, roleName, data.permissions, creatorID)
+		sd.admins[a.Email] = admin
+		return nil
+	}
+
+	if err := sd.service.ValidateAdminCredentials(ctx, a.Email, password); err != nil {
+		if sd.change("update admin password (email = %v)", a.Email) {
+			if err := sd.service.UpdateAdminPassword(ctx, admin.ID, password); err != nil {
+				return err
+			}
+		}
+	}
+	sd.admins[a.Email] = admin
+
+	return nil
+}
+
+func (sd *seeder) getAdmin(ctx context.Context, email string) (*models.Admin, error) {
+	if a, ok := sd.admins[email]; ok {
+		return a, nil
+	}
+	a, err := sd.service.GetAdminByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, fmt.Errorf("admin is not found (email = %v)", email)
+	}
+	sd.admins[email] = a
+
+	return a, nil
+}
+
+func (sd *seeder) seedRole(ctx context.Context, r seedRole) error {
+	creator, err := sd.getAdmin(ctx, r.Creator)
+	if err != nil {
+		return fmt.Errorf("role creator (name = %v): %w", r.Name, err)
+	}
+
+	role, err := sd.service.GetAdminRoleByName(ctx, r.Name)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		role = &models.AdminRole{Name: r.Name, Permissions: r.Permissions, CreatorAdminID: creator.ID}
+		if sd.change("create admin role (name = %v, creator = %v)", r.Name, r.Creator) {
+			if role, err = sd.service.CreateAdminRole(ctx, r.Name, r.Permissions, creator.ID); err != nil {
+				return err
+			}
+		}
+		sd.roles[r.Name] = role
+		return nil
+	}
+
+	if !reflect.DeepEqual(role.Permissions, r.Permissions) {
+		if sd.change("update admin role permissions (name = %v)", r.Name) {
+			if err := sd.service.UpdateAdminRolePermissions(ctx, role.ID, r.Permissions); err != nil {
+				return err
+			}
+		}
+		role.Permissions = r.Permissions
+	}
+	sd.roles[r.Name] = role
+
+	return nil
+}
+
+func (sd *seeder) seedGrant(ctx context.Context, g seedGrant) error {
+	admin, err := sd.getAdmin(ctx, g.Admin)
+	if err != nil {
+		return fmt.Errorf("grant receiver (role = %v): %w", g.Role, err)
+	}
+	role, ok := sd.roles[g.Role]
+	if !ok {
+		if role, err = sd.service.GetAdminRoleByName(ctx, g.Role); err != nil {
+			return err
+		}
+		if role == nil {
+			return fmt.Errorf("role is not found (name = %v)", g.Role)
+		}
+		sd.roles[g.Role] = role
+	}
+	var granterID *int64
+	if g.Granter != "" {
+		granter, err := sd.getAdmin(ctx, g.Granter)
+		if err != nil {
+			return fmt.Errorf("grant granter (role = %v): %w", g.Role, err)
+		}
+		granterID = &granter.ID
+	}
+
+	// admin or role is not created yet (dry run), so grant can not exist either
+	if admin.ID != 0 && role.ID != 0 {
+		assigned, err := sd.service.IsRoleAssignedToAdmin(ctx, admin.ID, role.ID)
 		if err != nil {
 			return err
 		}
-		rolesMap[roleName] = role
+		if assigned {
+			return nil
+		}
+	}
+	if sd.change("grant admin role (email = %v, role = %v)", g.Admin, g.Role) {
+		return sd.service.AssignRoleForAdmin(ctx, admin.ID, role.ID, granterID)
 	}
 
-	superadmin, ok := adminsMap[superAdminEmail]
-	if !ok {
-		return fmt.Errorf("superadmin is not found (email = %v)", superAdminEmail)
+	return nil
+}
+
+func (sd *seeder) seedComptus(ctx context.Context, c seedComptus) error {
+	password, err := resolveSecret(c.Password)
+	if err != nil {
+		return fmt.Errorf("comptus password (email = %v): %w", c.Email, err)
 	}
-	for email, data := range admins {
-		for _, roleName := range data.roles {
-			role, ok := rolesMap[roleName]
-			if !ok {
-				return fmt.Errorf("role is not found (name = %v)", roleName)
-			}
-			admin, ok := adminsMap[email]
-			if !ok {
-				return fmt.Errorf("admin is not found (email = %v)", email)
+	if err := models.VerifyPassword(password); err != nil {
+		return fmt.Errorf("comptus password is weak (email = %v): %w", c.Email, err)
+	}
+
+	comptus, err := sd.service.GetComptusByEmail(ctx, c.Email)
+	if err != nil {
+		return err
+	}
+	if comptus == nil {
+		comptus = &models.Comptus{Email: c.Email, Patria: c.Country, Lingua: c.Language}
+		if sd.change("create comptus (email = %v)", c.Email) {
+			if comptus, err = sd.service.CreateComptus(ctx, c.Email, password, c.Country, c.Language); err != nil {
+				return err
 			}
-			if err := s.AssignRoleForAdmin(ctx, admin.ID, role.ID, &superadmin.ID); err != nil {
+		}
+		sd.compti[c.Email] = comptus
+		return nil
+	}
+
+	if err := sd.service.ValidateComptusCredentials(ctx, c.Email, password); err != nil {
+		if sd.change("update comptus password (email = %v)", c.Email) {
+			if err := sd.service.UpdateComptusPassword(ctx, comptus.ID, password); err != nil {
 				return err
 			}
 		}
 	}
+	sd.compti[c.Email] = comptus
 
 	return nil
 }
+
+func (sd *seeder) seedOrbisSocius(ctx context.Context, o seedOrbisSocius) error {
+	apiKey, err := resolveSecret(o.APIKey)
+	if err != nil {
+		return fmt.Errorf("orbis socius api key (url = %v): %w", o.URL, err)
+	}
+	if err := models.VerifyAPIKey(apiKey); err != nil {
+		return fmt.Errorf("orbis socius api key is weak (url = %v): %w", o.URL, err)
+	}
+	owner, ok := sd.compti[o.Owner]
+	if !ok {
+		if owner, err = sd.service.GetComptusByEmail(ctx, o.Owner); err != nil {
+			return err
+		}
+		if owner == nil {
+			return fmt.Errorf("orbis socius owner is not found (email = %v)", o.Owner)
+		}
+	}
+	var approverID *int64
+	if o.Approver != "" {
+		approver, err := sd.getAdmin(ctx, o.Approver)
+		if err != nil {
+			return fmt.Errorf("orbis socius approver (url = %v): %w", o.URL, err)
+		}
+		approverID = &approver.ID
+	}
+
+	existing, err := sd.service.GetOrbisSociusByURL(ctx, o.URL)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		if sd.change("create orbis socius (name = %v, url = %v)", o.Name, o.URL) {
+			_, err := sd.service.CreateOrbisSocius(ctx, owner.ID, approverID, o.Region, o.Name, o.Description, o.URL, apiKey)
+			return err
+		}
+		return nil
+	}
+
+	var existingApproverID *int64
+	if existing.ApproverAdminID.Valid {
+		existingApproverID = &existing.ApproverAdminID.Int64
+	}
+	attrsChanged := existing.Name != o.Name || existing.Description != o.Description || existing.Region != o.Region ||
+		existing.OwnerComptusID != owner.ID || !equalIDs(existingApproverID, approverID)
+	keyChanged := existing.APIKey != apiKey
+	if !attrsChanged && !keyChanged {
+		return nil
+	}
+	if keyChanged {
+		sd.change("update orbis socius api key (url = %v)", o.URL)
+	} else {
+		apiKey = ""
+	}
+	if attrsChanged {
+		sd.change("update orbis socius (name = %v, url = %v)", o.Name, o.URL)
+	}
+	if sd.dryRun {
+		return nil
+	}
+
+	return sd.service.UpdateOrbisSocius(ctx, existing.ID, owner.ID, approverID, o.Region, o.Name, o.Description, apiKey)
+}
+
+func equalIDs(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"testing"
+
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+)
+
+const (
+	testAdminPassword   = "Admin-Passw0rd"
+	testComptusPassword = "Comptus-Passw0rd"
+	testAPIKey          = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+type fakeSeedService struct {
+	nextID     int64
+	admins     map[string]*models.Admin
+	passwords  map[string]string
+	roles      map[string]*models.AdminRole
+	grants     map[[2]int64]bool
+	compti     map[string]*models.Comptus
+	orbesSocii map[string]*models.OrbisSocius
+	writes     int
+}
+
+func newFakeSeedService() *fakeSeedService {
+	return &fakeSeedService{
+		admins:     map[string]*models.Admin{},
+		passwords:  map[string]string{},
+		roles:      map[string]*models.AdminRole{},
+		grants:     map[[2]int64]bool{},
+		compti:     map[string]*models.Comptus{},
+		orbesSocii: map[string]*models.OrbisSocius{},
+	}
+}
+
+func (f *fakeSeedService) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeSeedService) checkPassword(email, password string) error {
+	if f.passwords[email] != password {
+		return errors.New("invalid credentials")
+	}
+	return nil
+}
+
+func (f *fakeSeedService) GetAdminByEmail(_ context.Context, email string) (*models.Admin, error) {
+	return f.admins[email], nil
+}
+
+func (f *fakeSeedService) CreateAdmin(_ context.Context, email, password string) (*models.Admin, error) {
+	f.writes++
+	a := &models.Admin{ID: f.id(), Email: email}
+	f.admins[email] = a
+	f.passwords[email] = password
+	return a, nil
+}
+
+func (f *fakeSeedService) ValidateAdminCredentials(_ context.Context, email, password string) error {
+	return f.checkPassword(email, password)
+}
+
+func (f *fakeSeedService) UpdateAdminPassword(_ context.Context, adminID int64, password string) error {
+	f.writes++
+	for email, a := range f.admins {
+		if a.ID == adminID {
+			f.passwords[email] = password
+		}
+	}
+	return nil
+}
+
+func (f *fakeSeedService) GetAdminRoleByName(_ context.Context, name string) (*models.AdminRole, error) {
+	return f.roles[name], nil
+}
+
+func (f *fakeSeedService) CreateAdminRole(_ context.Context, name string, permissions models.AdminRolePermissions, creatorID int64) (*models.AdminRole, error) {
+	f.writes++
+	r := &models.AdminRole{ID: f.id(), Name: name, Permissions: permissions, CreatorAdminID: creatorID}
+	f.roles[name] = r
+	return r, nil
+}
+
+func (f *fakeSeedService) UpdateAdminRolePermissions(_ context.Context, roleID int64, permissions models.AdminRolePermissions) error {
+	f.writes++
+	for _, r := range f.roles {
+		if r.ID == roleID {
+			r.Permissions = permissions
+		}
+	}
+	return nil
+}
+
+func (f *fakeSeedService) IsRoleAssignedToAdmin(_ context.Context, adminID, roleID int64) (bool, error) {
+	return f.grants[[2]int64{adminID, roleID}], nil
+}
+
+func (f *fakeSeedService) AssignRoleForAdmin(_ context.Context, adminID, roleID int64, _ *int64) error {
+	f.writes++
+	f.grants[[2]int64{adminID, roleID}] = true
+	return nil
+}
+
+func (f *fakeSeedService) GetComptusByEmail(_ context.Context, email string) (*models.Comptus, error) {
+	return f.compti[email], nil
+}
+
+func (f *fakeSeedService) CreateComptus(_ context.Context, email, password, patria, lingua string) (*models.Comptus, error) {
+	f.writes++
+	c := &models.Comptus{ID: f.id(), Email: email, Patria: patria, Lingua: lingua}
+	f.compti[email] = c
+	f.passwords[email] = password
+	return c, nil
+}
+
+func (f *fakeSeedService) ValidateComptusCredentials(_ context.Context, email, password string) error {
+	return f.checkPassword(email, password)
+}
+
+func (f *fakeSeedService) UpdateComptusPassword(_ context.Context, comptusID int64, password string) error {
+	f.writes++
+	for email, c := range f.compti {
+		if c.ID == comptusID {
+			f.passwords[email] = password
+		}
+	}
+	return nil
+}
+
+func (f *fakeSeedService) GetOrbisSociusByURL(_ context.Context, url string) (*models.OrbisSocius, error) {
+	return f.orbesSocii[url], nil
+}
+
+func (f *fakeSeedService) CreateOrbisSocius(_ context.Context, ownerID int64, approverID *int64, region, name, desc, url, apiKey string) (*models.OrbisSocius, error) {
+	f.writes++
+	os := &models.OrbisSocius{ID: f.id(), OwnerComptusID: ownerID, Region: region, Name: name, Description: desc, URL: url, APIKey: apiKey}
+	if approverID != nil {
+		os.ApproverAdminID = sql.NullInt64{Int64: *approverID, Valid: true}
+	}
+	f.orbesSocii[url] = os
+	return os, nil
+}
+
+func (f *fakeSeedService) UpdateOrbisSocius(_ context.Context, id, ownerID int64, approverID *int64, region, name, desc, apiKey string) error {
+	f.writes++
+	for _, os := range f.orbesSocii {
+		if os.ID != id {
+			continue
+		}
+		os.OwnerComptusID, os.Region, os.Name, os.Description = ownerID, region, name, desc
+		os.ApproverAdminID = sql.NullInt64{}
+		if approverID != nil {
+			os.ApproverAdminID = sql.NullInt64{Int64: *approverID, Valid: true}
+		}
+		if apiKey != "" {
+			os.APIKey = apiKey
+		}
+	}
+	return nil
+}
+
+func testSeedsFile(t *testing.T) *seedsFile {
+	t.Setenv("TEST_ADMIN_PASSWORD", testAdminPassword)
+	t.Setenv("TEST_COMPTUS_PASSWORD", testComptusPassword)
+	t.Setenv("TEST_API_KEY", testAPIKey)
+
+	return &seedsFile{
+		Admins: []seedAdmin{{Email: "admin@mail.com", Password: seedSecret{Env: "TEST_ADMIN_PASSWORD"}}},
+		Roles: []seedRole{{
+			Name:        "superadmin",
+			Creator:     "admin@mail.com",
+			Permissions: models.AdminRolePermissions{},
+		}},
+		Grants: []seedGrant{{Admin: "admin@mail.com", Role: "superadmin", Granter: "admin@mail.com"}},
+		Compti: []seedComptus{{
+			Email:    "comptus@mail.com",
+			Password: seedSecret{Env: "TEST_COMPTUS_PASSWORD"},
+			Country:  "ukr",
+			Language: "ukr",
+		}},
+		OrbesSocii: []seedOrbisSocius{{
+			Name:        "demo",
+			Description: "demo orbis socius",
+			URL:         "http://localhost:9091",
+			Region:      "eu_east",
+			Owner:       "comptus@mail.com",
+			Approver:    "admin@mail.com",
+			APIKey:      seedSecret{Env: "TEST_API_KEY"},
+		}},
+	}
+}
+
+func TestSeederIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	f := testSeedsFile(t)
+	s := newFakeSeedService()
+
+	first := newSeeder(s, io.Discard, false)
+	if err := first.run(ctx, f); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if first.changes != 5 || s.writes != 5 {
+		t.Fatalf("first run: expected 5 changes and writes, got %d changes and %d writes", first.changes, s.writes)
+	}
+
+	second := newSeeder(s, io.Discard, false)
+	if err := second.run(ctx, f); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if second.changes != 0 || s.writes != 5 {
+		t.Fatalf("second run: expected no changes, got %d changes and %d writes", second.changes, s.writes-5)
+	}
+}
+
+func TestSeederUpdatesOrbisSocius(t *testing.T) {
+	ctx := context.Background()
+	f := testSeedsFile(t)
+	s := newFakeSeedService()
+	if err := newSeeder(s, io.Discard, false).run(ctx, f); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+
+	f.OrbesSocii[0].Description = "updated description"
+	f.OrbesSocii[0].Approver = ""
+	rotated := testAPIKey + "-rotated"
+	t.Setenv("TEST_API_KEY", rotated)
+
+	dry := newSeeder(s, io.Discard, true)
+	if err := dry.run(ctx, f); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	os := s.orbesSocii["http://localhost:9091"]
+	if dry.changes != 2 || os.Description != "demo orbis socius" {
+		t.Fatalf("dry run: expected 2 reported changes and nothing written, got %d changes and description %q", dry.changes, os.Description)
+	}
+
+	sd := newSeeder(s, io.Discard, false)
+	if err := sd.run(ctx, f); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if sd.changes != 2 {
+		t.Fatalf("second run: expected 2 changes, got %d", sd.changes)
+	}
+	if os.Description != "updated description" || os.ApproverAdminID.Valid || os.APIKey != rotated {
+		t.Fatalf("orbis socius is not updated: %+v", os)
+	}
+}
+
+func TestSeederRejectsWeakSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("comptus password", func(t *testing.T) {
+		f := testSeedsFile(t)
+		t.Setenv("TEST_COMPTUS_PASSWORD", "weak")
+		s := newFakeSeedService()
+		if err := newSeeder(s, io.Discard, false).run(ctx, f); err == nil {
+			t.Fatal("expected error for weak comptus password")
+		}
+		if _, ok := s.compti["comptus@mail.com"]; ok {
+			t.Fatal("comptus with weak password is created")
+		}
+	})
+
+	t.Run("orbis socius api key", func(t *testing.T) {
+		f := testSeedsFile(t)
+		t.Setenv("TEST_API_KEY", "short")
+		s := newFakeSeedService()
+		if err := newSeeder(s, io.Discard, false).run(ctx, f); err == nil {
+			t.Fatal("expected error for weak api key")
+		}
+		if len(s.orbesSocii) != 0 {
+			t.Fatal("orbis socius with weak api key is created")
+		}
+	})
+}
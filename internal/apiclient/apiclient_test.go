@@ -0,0 +1,89 @@
+package apiclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeper"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "req-1", r.Header.Get("X-Request-Id"))
+		rw.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = rw.Write([]byte(`{"status":"failure","code":"auth.invalid_token","message":"token is invalid","data":null}`))
+			return
+		}
+		if r.URL.Path == "/docs" {
+			rw.Header().Set("Content-Type", "text/html")
+			_, _ = rw.Write([]byte(`<html></html>`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"status":"success","data":{"id":1,"email":"user@example.com","country":"ukr","language":"ukr"}}`))
+	}))
+	defer srv.Close()
+
+	token := &apiclient.MutableToken{}
+	doer := apiclient.NewDoer(zap.NewNop(), apiclient.WithToken(token), apiclient.WithHTTPClient(srv.Client()))
+	c, err := gen.NewTypedClient(srv.URL, gen.WithHTTPClient(doer))
+	require.NoError(t, err)
+	ctx := contextutils.SetRequestID(context.Background(), "req-1")
+
+	_, err = c.GetMe(ctx)
+	var apiErr *apiclient.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.True(t, errors.Is(err, apierrors.New(apierrors.AuthInvalidToken, "")))
+
+	token.Set("token")
+	me, err := c.GetMe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), me.Id)
+	assert.Equal(t, "ukr", me.Country)
+
+	// raw response of underlying client is unwrapped the same way.
+	raw, err := apiclient.Decode[gen.Comptus](c.Client.GetMe(ctx))
+	require.NoError(t, err)
+	assert.Equal(t, me, raw)
+
+	// success response without data is not required to be JSON.
+	require.NoError(t, c.GetDocs(ctx))
+}
+
+func TestIdempotencyKeyIsOptIn(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 2 {
+			// the first attempt of call with key fails and is retried.
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	doer := apiclient.NewDoer(zap.NewNop())
+	c, err := gen.NewTypedClient(srv.URL, gen.WithHTTPClient(doer))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, c.OrbisSociusHeartbeatWithBody(ctx, &gen.OrbisSociusHeartbeatParams{}, "application/json", nil))
+	require.NoError(t, c.SignOutWithBody(ctx, "application/json", nil, apiclient.IdempotencyKey()))
+
+	require.Len(t, keys, 3)
+	assert.Empty(t, keys[0], "key is set without opt-in")
+	assert.NotEmpty(t, keys[1])
+	assert.Equal(t, keys[1], keys[2], "retry has another key")
+}
@@ -0,0 +1,111 @@
+// Package apiclient contains helpers for generated API clients
+// (internal/generated/<service>/client.go): HTTP doer built on
+// RobustHTTPClient and JSend envelope unwrapping. Typed clients
+// (internal/generated/<service>/typed_client.go) are generated by typedgen.
+//
+//	c, err := zookeeper.NewTypedClient(url, zookeeper.WithHTTPClient(apiclient.NewDoer(logger, apiclient.WithToken(token))))
+//	me, err := c.GetMe(ctx)
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	"go.uber.org/zap"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+// TokenSource returns bearer token for request. Empty token means request
+// is sent without Authorization header.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type staticToken string
+
+func (t staticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// StaticToken returns token source which always returns the token.
+func StaticToken(token string) TokenSource {
+	return staticToken(token)
+}
+
+// MutableToken is token source which token can be replaced, e.g. after sign
+// in or session refresh.
+type MutableToken struct {
+	mu    sync.RWMutex
+	token string
+}
+
+func (t *MutableToken) Token(context.Context) (string, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.token, nil
+}
+
+func (t *MutableToken) Set(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = token
+}
+
+// Doer implements HttpRequestDoer of generated clients.
+type Doer struct {
+	client *http.Client
+	token  TokenSource
+}
+
+type DoerOption func(d *Doer)
+
+func WithToken(src TokenSource) DoerOption {
+	return func(d *Doer) {
+		d.token = src
+	}
+}
+
+// WithHTTPClient replaces RobustHTTPClient, e.g. in tests.
+func WithHTTPClient(client *http.Client) DoerOption {
+	return func(d *Doer) {
+		d.client = client
+	}
+}
+
+func NewDoer(logger *zap.Logger, opts ...DoerOption) *Doer {
+	d := &Doer{client: httputils.RobustHTTPClient(logger)}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Do sends request with bearer token of token source and request ID of
+// incoming request from context, so calls between services can be traced by
+// the same request ID.
+func (d *Doer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if req.Header.Get(requestIDHeader) == "" {
+		if requestID := contextutils.GetRequestID(ctx); requestID != "" {
+			req.Header.Set(requestIDHeader, requestID)
+		}
+	}
+	if d.token != nil && req.Header.Get("Authorization") == "" {
+		token, err := d.token.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed get token: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	return d.client.Do(req)
+}
@@ -0,0 +1,26 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKey returns request editor which sets new Idempotency-Key
+// header. Retries of RobustHTTPClient send the same header, so server applies
+// the call only once. It is opt-in for calls which must not be repeated,
+// e.g. creation of resources:
+//
+//	admin, err := c.CreateAdmin(ctx, body, apiclient.IdempotencyKey())
+func IdempotencyKey() func(ctx context.Context, req *http.Request) error {
+	key := uuid.NewString()
+	return func(_ context.Context, req *http.Request) error {
+		if req.Header.Get(idempotencyKeyHeader) == "" {
+			req.Header.Set(idempotencyKeyHeader, key)
+		}
+		return nil
+	}
+}
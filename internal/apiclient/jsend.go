@@ -0,0 +1,131 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+)
+
+// Error is fail or error response of API.
+type Error struct {
+	StatusCode int
+	RequestID  string
+	Code       apierrors.Code
+	Message    string
+	Fields     []apierrors.FieldError
+	// Data is raw data of fail response.
+	Data json.RawMessage
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("api error: %s (status code = %v, code = %v, request id = %v)", msg, e.StatusCode, e.Code, e.RequestID)
+}
+
+// Is allows to match errors with errors of apierrors catalogue, e.g.
+// errors.Is(err, apierrors.New(apierrors.AuthInvalidCredentials, "")).
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*apierrors.Error) //nolint:errorlint
+	return ok && t.Code == e.Code
+}
+
+type envelope struct {
+	Status  string                 `json:"status"`
+	Data    json.RawMessage        `json:"data"`
+	Code    apierrors.Code         `json:"code"`
+	Message string                 `json:"message"`
+	Errors  []apierrors.FieldError `json:"errors"`
+
+	// fields of problem details
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// Decode unwraps JSend envelope of response. It returns data of success
+// response or *Error. It is used by TypedClient of generated clients and
+// with methods of Client which return raw response.
+func Decode[T any](resp *http.Response, err error) (T, error) {
+	var out T
+	data, err := unwrap(resp, err, true)
+	if err != nil {
+		return out, err
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return out, nil
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return out, fmt.Errorf("failed decode response data: %w", err)
+	}
+
+	return out, nil
+}
+
+// Check is Decode for responses without data. Success response is not
+// required to be JSON, e.g. HTML docs.
+func Check(resp *http.Response, err error) error {
+	_, err = unwrap(resp, err, false)
+	return err
+}
+
+func unwrap(resp *http.Response, err error, requireJSON bool) (json.RawMessage, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed read response body: %w", err)
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	var env envelope
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &env); err != nil {
+			if resp.StatusCode >= http.StatusBadRequest {
+				return nil, newError(resp, envelope{Message: strings.TrimSpace(string(body))})
+			}
+			if !requireJSON {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed decode response body (status code = %v): %w", resp.StatusCode, err)
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest || (env.Status != "" && env.Status != "success") {
+		return nil, newError(resp, env)
+	}
+
+	return env.Data, nil
+}
+
+func newError(resp *http.Response, env envelope) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Code:       env.Code,
+		Message:    env.Message,
+		Fields:     env.Errors,
+		Data:       env.Data,
+	}
+	if e.Message == "" {
+		e.Message = env.Detail
+	}
+	if e.Message == "" {
+		e.Message = env.Title
+	}
+	if e.Code == "" {
+		e.Code = apierrors.CodeForStatus(resp.StatusCode)
+	}
+
+	return e
+}
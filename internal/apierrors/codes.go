@@ -0,0 +1,153 @@
+package apierrors
+
+import "net/http"
+
+// Code is stable machine-readable error code. Codes are part of public API,
+// so existing codes must never be renamed.
+type Code string
+
+const (
+	BadRequest       Code = "common.bad_request"
+	InvalidBody      Code = "common.invalid_body"
+	ValidationFailed Code = "common.validation_failed"
+	Unauthorized     Code = "common.unauthorized"
+	Forbidden        Code = "common.forbidden"
+	NotFound         Code = "common.not_found"
+	Conflict         Code = "common.conflict"
+	TooManyRequests  Code = "common.too_many_requests"
+	Internal         Code = "common.internal"
+	NotImplemented   Code = "common.not_implemented"
+	Unavailable      Code = "common.unavailable"
+
+	FieldRequired Code = "field.required"
+	FieldInvalid  Code = "field.invalid"
+	FieldDisabled Code = "field.disabled"
+	FieldUnknown  Code = "field.unknown"
+
+	AuthMissingToken       Code = "auth.missing_token"
+	AuthInvalidToken       Code = "auth.invalid_token"
+	AuthSessionNotFound    Code = "auth.session_not_found"
+	AuthInvalidCredentials Code = "auth.invalid_credentials"
+	AuthPermissionDenied   Code = "auth.permission_denied"
+	AuthMissingClientCert  Code = "auth.missing_client_certificate"
+
+	IdempotencyKeyInvalid    Code = "idempotency_key.invalid"
+	IdempotencyKeyMismatch   Code = "idempotency_key.mismatch"
+	IdempotencyKeyInProgress Code = "idempotency_key.in_progress"
+
+	ComptusNotFound        Code = "comptus.not_found"
+	ComptusAlreadyExists   Code = "comptus.already_exists"
+	ComptusSuspended       Code = "comptus.suspended"
+	AdminNotFound          Code = "admin.not_found"
+	AdminAlreadyExists     Code = "admin.already_exists"
+	AdminRoleNotFound      Code = "admin_role.not_found"
+	AdminRoleAlreadyExists Code = "admin_role.already_exists"
+
+	OrbisSociusNotFound              Code = "orbis_socius.not_found"
+	OrbisSociusInviteExpired         Code = "orbis_socius.invite_expired"
+	OrbisSociusLaunchRequestNotFound Code = "orbis_socius.launch_request_not_found"
+	OrbisSociusLaunchRequestReviewed Code = "orbis_socius.launch_request_reviewed"
+	OrbisSociusInvalidAPIKey         Code = "orbis_socius.invalid_api_key"
+	OrbisSociusInvalidSignature      Code = "orbis_socius.invalid_signature"
+	WebhookDeliveryNotFound          Code = "webhook_delivery.not_found"
+
+	AppSettingsReadOnly   Code = "app_settings.read_only"
+	AppSettingsInvalid    Code = "app_settings.invalid"
+	RegionNotFound        Code = "region.not_found"
+	RegionAlreadyExists   Code = "region.already_exists"
+	RegionInUse           Code = "region.in_use"
+	RegionDisabled        Code = "region.disabled"
+	CountryNotFound       Code = "country.not_found"
+	CountryAlreadyExists  Code = "country.already_exists"
+	CountryInUse          Code = "country.in_use"
+	CountryDisabled       Code = "country.disabled"
+	LanguageNotFound      Code = "language.not_found"
+	LanguageAlreadyExists Code = "language.already_exists"
+	LanguageInUse         Code = "language.in_use"
+	LanguageDisabled      Code = "language.disabled"
+)
+
+type catalogueEntry struct {
+	status int
+	title  string
+}
+
+var catalogue = map[Code]catalogueEntry{
+	BadRequest:       {http.StatusBadRequest, "Bad request"},
+	InvalidBody:      {http.StatusBadRequest, "Request body is invalid"},
+	ValidationFailed: {http.StatusUnprocessableEntity, "Validation failed"},
+	Unauthorized:     {http.StatusUnauthorized, "Unauthorized"},
+	Forbidden:        {http.StatusForbidden, "Forbidden"},
+	NotFound:         {http.StatusNotFound, "Not found"},
+	Conflict:         {http.StatusConflict, "Conflict"},
+	TooManyRequests:  {http.StatusTooManyRequests, "Too many requests"},
+	Internal:         {http.StatusInternalServerError, "Internal error"},
+	NotImplemented:   {http.StatusNotImplemented, "Not implemented"},
+	Unavailable:      {http.StatusServiceUnavailable, "Service unavailable"},
+
+	FieldRequired: {http.StatusUnprocessableEntity, "Field is required"},
+	FieldInvalid:  {http.StatusUnprocessableEntity, "Field is invalid"},
+	FieldDisabled: {http.StatusUnprocessableEntity, "Field value is disabled"},
+	FieldUnknown:  {http.StatusUnprocessableEntity, "Field is unknown"},
+
+	AuthMissingToken:       {http.StatusUnauthorized, "Token is missing"},
+	AuthInvalidToken:       {http.StatusUnauthorized, "Token is invalid"},
+	AuthSessionNotFound:    {http.StatusUnauthorized, "Session is not found"},
+	AuthInvalidCredentials: {http.StatusUnauthorized, "Invalid credentials"},
+	AuthPermissionDenied:   {http.StatusForbidden, "Permission denied"},
+	AuthMissingClientCert:  {http.StatusUnauthorized, "Client certificate is missing"},
+
+	IdempotencyKeyInvalid:    {http.StatusBadRequest, "Idempotency key is invalid"},
+	IdempotencyKeyMismatch:   {http.StatusUnprocessableEntity, "Idempotency key is used with another request"},
+	IdempotencyKeyInProgress: {http.StatusConflict, "Request with idempotency key is in progress"},
+
+	ComptusNotFound:        {http.StatusNotFound, "Comptus is not found"},
+	ComptusAlreadyExists:   {http.StatusConflict, "Comptus already exists"},
+	ComptusSuspended:       {http.StatusForbidden, "Comptus is suspended"},
+	AdminNotFound:          {http.StatusNotFound, "Admin is not found"},
+	AdminAlreadyExists:     {http.StatusConflict, "Admin already exists"},
+	AdminRoleNotFound:      {http.StatusNotFound, "Admin role is not found"},
+	AdminRoleAlreadyExists: {http.StatusConflict, "Admin role already exists"},
+
+	OrbisSociusNotFound:              {http.StatusNotFound, "Orbis socius is not found"},
+	OrbisSociusInviteExpired:         {http.StatusGone, "Orbis socius invite is expired"},
+	OrbisSociusLaunchRequestNotFound: {http.StatusNotFound, "Orbis socius launch request is not found"},
+	OrbisSociusLaunchRequestReviewed: {http.StatusConflict, "Orbis socius launch request is already reviewed"},
+	OrbisSociusInvalidAPIKey:         {http.StatusUnauthorized, "Orbis socius API key is invalid"},
+	OrbisSociusInvalidSignature:      {http.StatusUnauthorized, "Orbis socius signature is invalid"},
+	WebhookDeliveryNotFound:          {http.StatusNotFound, "Webhook delivery is not found"},
+
+	AppSettingsReadOnly:   {http.StatusConflict, "App settings are read-only"},
+	AppSettingsInvalid:    {http.StatusUnprocessableEntity, "App settings are invalid"},
+	RegionNotFound:        {http.StatusNotFound, "Region is not found"},
+	RegionAlreadyExists:   {http.StatusConflict, "Region already exists"},
+	RegionInUse:           {http.StatusConflict, "Region is in use"},
+	RegionDisabled:        {http.StatusUnprocessableEntity, "Region is disabled"},
+	CountryNotFound:       {http.StatusNotFound, "Country is not found"},
+	CountryAlreadyExists:  {http.StatusConflict, "Country already exists"},
+	CountryInUse:          {http.StatusConflict, "Country is in use"},
+	CountryDisabled:       {http.StatusUnprocessableEntity, "Country is disabled"},
+	LanguageNotFound:      {http.StatusNotFound, "Language is not found"},
+	LanguageAlreadyExists: {http.StatusConflict, "Language already exists"},
+	LanguageInUse:         {http.StatusConflict, "Language is in use"},
+	LanguageDisabled:      {http.StatusUnprocessableEntity, "Language is disabled"},
+}
+
+// HTTPStatusCode returns HTTP status code of the code. Unknown codes are
+// treated as internal errors.
+func (c Code) HTTPStatusCode() int {
+	if e, ok := catalogue[c]; ok {
+		return e.status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Title returns short human-readable summary of the code.
+func (c Code) Title() string {
+	if e, ok := catalogue[c]; ok {
+		return e.title
+	}
+
+	return http.StatusText(c.HTTPStatusCode())
+}
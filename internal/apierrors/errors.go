@@ -0,0 +1,117 @@
+// Package apierrors contains catalogue of stable machine-readable error codes
+// and typed error which carries code through service layers up to HTTP
+// response writer.
+package apierrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// FieldError describes validation problem of single request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+}
+
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// New returns error with code. Message should be end-user-readable.
+func New(code Code, msg string) *Error {
+	return &Error{Code: code, Message: msg}
+}
+
+// Newf is New with formatted message.
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return New(code, fmt.Sprintf(format, args...))
+}
+
+// Wrap returns error with code which keeps err as a cause.
+func Wrap(code Code, err error, msg string) *Error {
+	return &Error{Code: code, Message: msg, Err: err}
+}
+
+// Validation returns validation error with field-level details.
+func Validation(fields ...FieldError) *Error {
+	return &Error{Code: ValidationFailed, Message: "request is invalid", Fields: fields}
+}
+
+// Field returns field-level validation detail.
+func Field(field string, code Code, msg string) FieldError {
+	return FieldError{Field: field, Code: code, Message: msg}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports errors with the same code as equal, so that errors created with
+// New can be matched against sentinel errors with errors.Is.
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+
+	return t.Code == e.Code
+}
+
+// HTTPStatusCode returns HTTP status code for the error code.
+func (e *Error) HTTPStatusCode() int {
+	return e.Code.HTTPStatusCode()
+}
+
+// As returns the first *Error in err's chain.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	return nil, false
+}
+
+// CodeForStatus returns generic code for HTTP status code. It is used when
+// response is written without explicit code.
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return BadRequest
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusUnprocessableEntity:
+		return ValidationFailed
+	case http.StatusTooManyRequests:
+		return TooManyRequests
+	case http.StatusNotImplemented:
+		return NotImplemented
+	case http.StatusServiceUnavailable:
+		return Unavailable
+	}
+	if status >= http.StatusInternalServerError {
+		return Internal
+	}
+
+	return BadRequest
+}
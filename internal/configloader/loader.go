@@ -0,0 +1,197 @@
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load fills cfg from the configuration sources. cfg must be a pointer to a
+// struct which is already filled with defaults. Sources are applied in
+// order, every next one overrides the previous:
+//
+//  1. YAML file (skipped if path is empty);
+//  2. environment variables named by `env` field tag;
+//  3. secret files referenced by `<env>_FILE` environment variables.
+//
+// Fields are matched with YAML keys by `yaml` field tag.
+func Load(cfg interface{}, path string) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to struct (type = %T)", cfg)
+	}
+	v = v.Elem()
+
+	if path != "" {
+		if err := loadFile(v, path); err != nil {
+			return err
+		}
+	}
+	if err := loadEnv(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func loadFile(v reflect.Value, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("can not read config file (path = %v): %w", path, err)
+	}
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("can not decode config file (path = %v): %w", path, err)
+	}
+
+	fields := map[string]reflect.Value{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if key := yamlKey(t.Field(i)); key != "" {
+			fields[key] = v.Field(i)
+		}
+	}
+	for key, raw := range values {
+		field, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown config key (key = %v, path = %v)", key, path)
+		}
+		if err := setFromYAML(field, raw); err != nil {
+			return fmt.Errorf("invalid config value (key = %v, path = %v): %w", key, path, err)
+		}
+	}
+
+	return nil
+}
+
+func loadEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		value, hasValue := os.LookupEnv(name)
+		secretPath, hasSecret := os.LookupEnv(name + "_FILE")
+		if hasValue && hasSecret {
+			return fmt.Errorf("both %v and %v_FILE are set, only one of them is allowed", name, name)
+		}
+		if hasSecret {
+			b, err := os.ReadFile(secretPath)
+			if err != nil {
+				return fmt.Errorf("can not read secret file (env = %v_FILE, path = %v): %w", name, secretPath, err)
+			}
+			value, hasValue = strings.TrimRight(string(b), "\r\n"), true
+		}
+		if !hasValue {
+			continue
+		}
+		if err := setFromString(v.Field(i), value); err != nil {
+			return fmt.Errorf("invalid environment variable (env = %v): %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func yamlKey(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	key, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if key == "-" {
+		return ""
+	}
+
+	return key
+}
+
+// setFromYAML sets scalar or list of scalars decoded from YAML. Mapping is
+// never allowed, list is allowed only for list of strings.
+func setFromYAML(field reflect.Value, raw interface{}) error {
+	if isYAMLMapping(raw) {
+		return fmt.Errorf("mapping is not allowed for %v", field.Type())
+	}
+	if items, ok := raw.([]interface{}); ok {
+		if field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("list is not allowed for %v", field.Type())
+		}
+		out := make([]string, 0, len(items))
+		for i, item := range items {
+			if _, ok := item.([]interface{}); ok || isYAMLMapping(item) {
+				return fmt.Errorf("list item must be scalar (index = %v)", i)
+			}
+			out = append(out, fmt.Sprint(item))
+		}
+		field.Set(reflect.ValueOf(out))
+		return nil
+	}
+	if raw == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	return setFromString(field, fmt.Sprint(raw))
+}
+
+func isYAMLMapping(raw interface{}) bool {
+	switch raw.(type) {
+	case map[string]interface{}, map[interface{}]interface{}:
+		return true
+	}
+
+	return false
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setFromString(field reflect.Value, s string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(s)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Int || field.Kind() == reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case field.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		field.SetBytes([]byte(s))
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		var out []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+		field.Set(reflect.ValueOf(out))
+	default:
+		return errors.New("unsupported config field type " + field.Type().String())
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package configloader_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/configloader"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Addr    string        `yaml:"addr" env:"TEST_CFG_ADDR"`
+	Secret  []byte        `yaml:"secret" env:"TEST_CFG_SECRET" secret:"true"`
+	Timeout time.Duration `yaml:"timeout" env:"TEST_CFG_TIMEOUT"`
+	Origins []string      `yaml:"origins" env:"TEST_CFG_ORIGINS"`
+	DBURL   string        `yaml:"db_url" env:"TEST_CFG_DB_URL"`
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("addr: \":8080\"\ntimeout: 5s\norigins: [a, b]\n"), 0o600))
+	secretPath := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secretPath, []byte("from-file\n"), 0o600))
+	t.Setenv("TEST_CFG_ADDR", ":9090")
+	t.Setenv("TEST_CFG_SECRET_FILE", secretPath)
+
+	cfg := &testConfig{Addr: ":80", Timeout: time.Second, DBURL: "postgresql://u:p@localhost/db"}
+	require.NoError(t, configloader.Load(cfg, path))
+	require.Equal(t, ":9090", cfg.Addr)
+	require.Equal(t, []byte("from-file"), cfg.Secret)
+	require.Equal(t, 5*time.Second, cfg.Timeout)
+	require.Equal(t, []string{"a", "b"}, cfg.Origins)
+
+	var buf bytes.Buffer
+	require.NoError(t, configloader.Print(&buf, cfg))
+	require.False(t, strings.Contains(buf.String(), "from-file"))
+	require.False(t, strings.Contains(buf.String(), ":p@"))
+}
+
+func TestLoadRejectsUnknownKeyAndDoubleSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("unknown: 1\n"), 0o600))
+	require.Error(t, configloader.Load(&testConfig{}, path))
+
+	t.Setenv("TEST_CFG_SECRET", "value")
+	t.Setenv("TEST_CFG_SECRET_FILE", path)
+	require.Error(t, configloader.Load(&testConfig{}, ""))
+}
+
+func TestLoadRejectsValueOfWrongKind(t *testing.T) {
+	for name, content := range map[string]string{
+		"mapping for string":   "addr:\n  host: localhost\n",
+		"list for string":      "addr: [localhost]\n",
+		"mapping for list":     "origins:\n  a: b\n",
+		"mapping in list":      "origins:\n  - a: b\n",
+		"list in list":         "origins: [[a]]\n",
+		"mapping for duration": "timeout: {seconds: 5}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			err := configloader.Load(&testConfig{}, path)
+			require.Error(t, err)
+			key, _, _ := strings.Cut(content, ":")
+			require.Contains(t, err.Error(), "key = "+key)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package configloader
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "<redacted>"
+
+// Print writes effective config as YAML. Fields tagged `secret:"true"` are
+// redacted, passwords inside URLs are masked.
+func Print(w io.Writer, cfg interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(cfg))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a struct (type = %T)", cfg)
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := yamlKey(f)
+		if key == "" {
+			continue
+		}
+		value := &yaml.Node{}
+		if err := value.Encode(printable(v.Field(i), f.Tag.Get("secret") == "true")); err != nil {
+			return err
+		}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func printable(field reflect.Value, secret bool) interface{} {
+	if secret {
+		if field.Len() == 0 {
+			return ""
+		}
+		return redacted
+	}
+	switch {
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		return string(field.Bytes())
+	case field.Kind() == reflect.String:
+		return maskURLPassword(field.String())
+	case field.Type() == durationType:
+		return fmt.Sprint(field.Interface())
+	}
+
+	return field.Interface()
+}
+
+func maskURLPassword(s string) string {
+	if !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+	u.User = url.UserPassword(u.User.Username(), "xxxxx")
+
+	return u.String()
+}
@@ -0,0 +1,152 @@
+package configloader
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+)
+
+// MinProdSecretLength is the minimal length of secret allowed in production.
+const MinProdSecretLength = 32
+
+var placeholders = []string{"changeme", "change_me", "secret", "password"}
+
+// IsPlaceholder returns true if value looks like a development placeholder.
+func IsPlaceholder(v string) bool {
+	lower := strings.ToLower(v)
+	if strings.Contains(lower, "placeholder") {
+		return true
+	}
+	for _, p := range placeholders {
+		if lower == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ValidateRequired(name, v string) error {
+	if strings.TrimSpace(v) == "" {
+		return fmt.Errorf("%v is required", name)
+	}
+
+	return nil
+}
+
+func ValidateAddr(name, v string) error {
+	if _, _, err := netutils.SplitHostPort(v); err != nil {
+		return fmt.Errorf("%v is invalid listen address (value = %v): %w", name, v, err)
+	}
+
+	return nil
+}
+
+// ValidateOptionalAddr is ValidateAddr which accepts empty value.
+func ValidateOptionalAddr(name, v string) error {
+	if v == "" {
+		return nil
+	}
+
+	return ValidateAddr(name, v)
+}
+
+// ValidateRatio checks that value is in range 0..1.
+func ValidateRatio(name string, v float64) error {
+	if v < 0 || v > 1 {
+		return fmt.Errorf("%v must be in range 0..1 (value = %v)", name, v)
+	}
+
+	return nil
+}
+
+// ValidateNonNegative checks that duration is not negative.
+func ValidateNonNegative(name string, v time.Duration) error {
+	if v < 0 {
+		return fmt.Errorf("%v must not be negative (value = %v)", name, v)
+	}
+
+	return nil
+}
+
+// ValidatePositive checks that duration is greater than zero.
+func ValidatePositive(name string, v time.Duration) error {
+	if v <= 0 {
+		return fmt.Errorf("%v must be positive (value = %v)", name, v)
+	}
+
+	return nil
+}
+
+// ValidateTLS checks that certificate and key are configured together.
+func ValidateTLS(certFile, keyFile string) error {
+	if (certFile == "") != (keyFile == "") {
+		return errors.New("tls_cert_file and tls_key_file must be set together")
+	}
+
+	return nil
+}
+
+// ValidateClientCA checks that client certificates are verified only on TLS
+// listener.
+func ValidateClientCA(name, caFile, certFile string) error {
+	if caFile != "" && certFile == "" {
+		return fmt.Errorf("%v requires tls_cert_file", name)
+	}
+
+	return nil
+}
+
+// ValidateSubnets checks that every value is IP or CIDR.
+func ValidateSubnets(name string, v []string) error {
+	if _, err := netutils.ParseSubnets(v...); err != nil {
+		return fmt.Errorf("%v must contain IPs or CIDRs: %w", name, err)
+	}
+
+	return nil
+}
+
+// ValidateSelfURL checks that URL is absolute. In production only https is
+// allowed.
+func ValidateSelfURL(name, v string, prod bool) error {
+	u, err := url.Parse(v)
+	if err != nil {
+		return fmt.Errorf("%v is invalid URL (value = %v): %w", name, v, err)
+	}
+	if u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%v must be absolute http(s) URL (value = %v)", name, v)
+	}
+	if prod && u.Scheme != "https" {
+		return fmt.Errorf("%v must use https in production (value = %v)", name, v)
+	}
+
+	return nil
+}
+
+// ValidateSecret checks that secret is set. In production placeholders and
+// short secrets are rejected.
+func ValidateSecret(name string, v []byte, prod bool) error {
+	if len(v) == 0 {
+		return fmt.Errorf("%v is required", name)
+	}
+	if !prod {
+		return nil
+	}
+	if IsPlaceholder(string(v)) {
+		return fmt.Errorf("%v must not be a placeholder in production", name)
+	}
+	if len(v) < MinProdSecretLength {
+		return fmt.Errorf("%v must be at least %d bytes long in production", name, MinProdSecretLength)
+	}
+
+	return nil
+}
+
+// Join collects validation errors. It returns nil if all of them are nil.
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
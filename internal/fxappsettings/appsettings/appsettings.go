@@ -1,21 +1,165 @@
 package appsettings
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 type Country struct {
 	CountryCode string   `yaml:"country_code"`
 	Enabled     bool     `yaml:"enabled"`
 	Regions     []string `yaml:"regions"`
 }
 
+type Region struct {
+	RegionCode         string `yaml:"region_code"`
+	Enabled            bool   `yaml:"enabled"`
+	DisplayName        string `yaml:"display_name"`
+	DefaultTimezone    string `yaml:"default_timezone"`
+	DataResidencyNotes string `yaml:"data_residency_notes"`
+}
+
 type Language struct {
 	LangaugeCode string `yaml:"language_code"`
 	Enabled      bool   `yaml:"enabled"`
 }
 
-type configurations struct {
+// Settings is a complete set of countries, regions and languages. Regions
+// metadata is optional, regions which are referenced by countries but have
+// no metadata are considered enabled.
+type Settings struct {
 	Countries []*Country  `yaml:"countries"`
+	Regions   []*Region   `yaml:"regions"`
 	Languages []*Language `yaml:"languages"`
 }
 
-func New() *configurations {
-	return &configurations{}
+// Source loads settings from the storage (files, database).
+type Source interface {
+	Load(ctx context.Context) (*Settings, error)
+	String() string
+}
+
+// Status describes the version of settings which is currently served.
+type Status struct {
+	Version  int64
+	LoadedAt time.Time
+	Source   string
+	// LastError is set if the latest reload attempt was rejected. In that
+	// case previous version is kept.
+	LastError   string
+	LastErrorAt time.Time
+}
+
+type configurations struct {
+	source Source
+
+	mu      sync.Mutex
+	current atomic.Pointer[Settings]
+	status  atomic.Pointer[Status]
+}
+
+func New(source Source) *configurations {
+	m := &configurations{source: source}
+	m.current.Store(&Settings{})
+	m.status.Store(&Status{Source: source.String()})
+
+	return m
+}
+
+// Reload loads and validates settings from the source and atomically swaps
+// in-memory settings. If loaded settings are invalid, previous settings are
+// kept. Version is incremented only if settings are changed.
+func (m *configurations) Reload(ctx context.Context) (*Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev := m.status.Load()
+	next, err := m.source.Load(ctx)
+	if err == nil {
+		err = next.Validate()
+	}
+	if err != nil {
+		failed := *prev
+		failed.LastError = err.Error()
+		failed.LastErrorAt = time.Now()
+		m.status.Store(&failed)
+
+		return &failed, err
+	}
+
+	status := &Status{
+		Version:  prev.Version,
+		LoadedAt: prev.LoadedAt,
+		Source:   m.source.String(),
+	}
+	if prev.Version == 0 || !reflect.DeepEqual(next, m.current.Load()) {
+		m.current.Store(next)
+		status.Version++
+		status.LoadedAt = time.Now()
+	}
+	m.status.Store(status)
+
+	return status, nil
+}
+
+func (m *configurations) GetStatus() *Status {
+	status := *m.status.Load()
+	return &status
+}
+
+func (s *Settings) Validate() error {
+	if len(s.Countries) == 0 {
+		return errors.New("no countries in settings")
+	}
+	if len(s.Languages) == 0 {
+		return errors.New("no languages in settings")
+	}
+	regions := make(map[string]struct{}, len(s.Regions))
+	for _, r := range s.Regions {
+		if r == nil || r.RegionCode == "" {
+			return errors.New("region code is empty")
+		}
+		if _, ok := regions[r.RegionCode]; ok {
+			return fmt.Errorf("duplicated region (region code = %v)", r.RegionCode)
+		}
+		regions[r.RegionCode] = struct{}{}
+	}
+	countries := make(map[string]struct{}, len(s.Countries))
+	for _, c := range s.Countries {
+		if c == nil || c.CountryCode == "" {
+			return errors.New("country code is empty")
+		}
+		if _, ok := countries[c.CountryCode]; ok {
+			return fmt.Errorf("duplicated country (country code = %v)", c.CountryCode)
+		}
+		countries[c.CountryCode] = struct{}{}
+		if len(c.Regions) == 0 {
+			return fmt.Errorf("country has no regions (country code = %v)", c.CountryCode)
+		}
+		for _, r := range c.Regions {
+			if r == "" {
+				return fmt.Errorf("region is empty (country code = %v)", c.CountryCode)
+			}
+			if _, ok := regions[r]; len(s.Regions) > 0 && !ok {
+				return fmt.Errorf("country refers to unknown region (country code = %v, region code = %v)", c.CountryCode, r)
+			}
+		}
+	}
+	languages := make(map[string]struct{}, len(s.Languages))
+	for _, l := range s.Languages {
+		if l == nil || l.LangaugeCode == "" {
+			return errors.New("language code is empty")
+		}
+		if _, ok := languages[l.LangaugeCode]; ok {
+			return fmt.Errorf("duplicated language (language code = %v)", l.LangaugeCode)
+		}
+		languages[l.LangaugeCode] = struct{}{}
+	}
+
+	return nil
 }
@@ -0,0 +1,72 @@
+package appsettings_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadKeepsPreviousVersionOnInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	locales := filepath.Join(dir, "locales.yaml")
+	regions := filepath.Join(dir, "regions.yaml")
+	require.NoError(t, os.WriteFile(locales, []byte("languages:\n  - language_code: eng\n    enabled: true\n"), 0o600))
+	require.NoError(t, os.WriteFile(regions, []byte("countries:\n  - country_code: ukr\n    enabled: true\n    regions: [eu_east]\n"), 0o600))
+
+	m := appsettings.New(&appsettings.FileSource{LocalesPath: locales, RegionsPath: regions})
+	status, err := m.Reload(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), status.Version)
+	require.Equal(t, []string{"ukr"}, m.GetCountries(true))
+
+	require.NoError(t, os.WriteFile(regions, []byte("countries:\n  - country_code: ukr\n    enabled: true\n    regions: [eu_east]\n  - country_code: ukr\n    enabled: true\n    regions: [eu_east]\n"), 0o600))
+	status, err = m.Reload(context.Background())
+	require.Error(t, err)
+	require.Equal(t, int64(1), status.Version)
+	require.NotEmpty(t, status.LastError)
+	require.Equal(t, []string{"ukr"}, m.GetCountries(true))
+
+	require.NoError(t, os.WriteFile(regions, []byte("countries:\n  - country_code: pol\n    enabled: true\n    regions: [eu_cent]\n"), 0o600))
+	status, err = m.Reload(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), status.Version)
+	require.Empty(t, status.LastError)
+	require.NoError(t, m.ValidateRegionCode("eu_cent"))
+
+	status, err = m.Reload(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), status.Version)
+}
+
+func TestDisabledRegion(t *testing.T) {
+	dir := t.TempDir()
+	locales := filepath.Join(dir, "locales.yaml")
+	regions := filepath.Join(dir, "regions.yaml")
+	require.NoError(t, os.WriteFile(locales, []byte("languages:\n  - language_code: eng\n    enabled: true\n"), 0o600))
+	require.NoError(t, os.WriteFile(regions, []byte(`countries:
+  - country_code: pol
+    enabled: true
+    regions: [eu_cent, eu_east]
+regions:
+  - region_code: eu_cent
+    enabled: false
+    display_name: Central Europe
+    default_timezone: Europe/Berlin
+  - region_code: eu_east
+    enabled: true
+    display_name: Eastern Europe
+    default_timezone: Europe/Kyiv
+`), 0o600))
+
+	m := appsettings.New(&appsettings.FileSource{LocalesPath: locales, RegionsPath: regions})
+	_, err := m.Reload(context.Background())
+	require.NoError(t, err)
+	require.Error(t, m.ValidateRegionCode("eu_cent"))
+	require.NoError(t, m.ValidateRegionCode("eu_east"))
+	require.Equal(t, []string{"eu_east"}, m.GetRegionsByCountryCode("pol"))
+	require.Equal(t, "Europe/Kyiv", m.GetRegion("eu_east").DefaultTimezone)
+}
@@ -0,0 +1,49 @@
+package appsettings
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileSource reads languages from locales file and countries with regions
+// from regions file.
+type FileSource struct {
+	LocalesPath string
+	RegionsPath string
+}
+
+func (s *FileSource) Load(ctx context.Context) (*Settings, error) {
+	var locales Settings
+	if err := readYAML(s.LocalesPath, &locales); err != nil {
+		return nil, err
+	}
+	var regions Settings
+	if err := readYAML(s.RegionsPath, &regions); err != nil {
+		return nil, err
+	}
+
+	return &Settings{
+		Countries: regions.Countries,
+		Regions:   regions.Regions,
+		Languages: locales.Languages,
+	}, nil
+}
+
+func (s *FileSource) String() string {
+	return fmt.Sprintf("files (locales = %v, regions = %v)", s.LocalesPath, s.RegionsPath)
+}
+
+func readYAML(path string, out interface{}) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("can not decode settings file (path = %v): %w", path, err)
+	}
+
+	return nil
+}
@@ -1,29 +1,11 @@
 package appsettings
 
-import (
-	"context"
-	"fmt"
-	"os"
-
-	"gopkg.in/yaml.v3"
-)
-
-func (m *configurations) LoadLocales(ctx context.Context, path string) error {
-	yamlFile, err := os.ReadFile(path)
-	if err != nil {
-		return err
-	}
-
-	if err := yaml.Unmarshal(yamlFile, m); err != nil {
-		return err
-	}
-
-	return nil
-}
+import "fmt"
 
 func (m *configurations) GetLanguages(onlyEnabled bool) []string {
-	languages := make([]string, 0, len(m.Languages))
-	for _, lang := range m.Languages {
+	s := m.current.Load()
+	languages := make([]string, 0, len(s.Languages))
+	for _, lang := range s.Languages {
 		if onlyEnabled && !lang.Enabled {
 			continue
 		}
@@ -34,7 +16,8 @@ func (m *configurations) GetLanguages(onlyEnabled bool) []string {
 }
 
 func (m *configurations) ValidateLanguageCode(code string) error {
-	for _, c := range m.Languages {
+	s := m.current.Load()
+	for _, c := range s.Languages {
 		if code == c.LangaugeCode {
 			if !c.Enabled {
 				return fmt.Errorf(`not allowed to take "%s" language the language is disabled`, code)
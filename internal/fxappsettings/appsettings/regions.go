@@ -1,29 +1,11 @@
 package appsettings
 
-import (
-	"context"
-	"fmt"
-	"os"
-
-	"gopkg.in/yaml.v3"
-)
-
-func (m *configurations) LoadRegions(ctx context.Context, path string) error {
-	yamlFile, err := os.ReadFile(path)
-	if err != nil {
-		return err
-	}
-
-	if err := yaml.Unmarshal(yamlFile, m); err != nil {
-		return err
-	}
-
-	return nil
-}
+import "fmt"
 
 func (m *configurations) GetCountries(onlyEnabled bool) []string {
-	countries := make([]string, 0, len(m.Countries))
-	for _, c := range m.Countries {
+	s := m.current.Load()
+	countries := make([]string, 0, len(s.Countries))
+	for _, c := range s.Countries {
 		if onlyEnabled && !c.Enabled {
 			continue
 		}
@@ -33,18 +15,41 @@ func (m *configurations) GetCountries(onlyEnabled bool) []string {
 	return countries
 }
 
+// GetRegionsByCountryCode returns enabled regions of the country.
 func (m *configurations) GetRegionsByCountryCode(code string) []string {
-	for _, c := range m.Countries {
-		if c.CountryCode == code {
-			return c.Regions
+	s := m.current.Load()
+	for _, c := range s.Countries {
+		if c.CountryCode != code {
+			continue
 		}
+		regions := make([]string, 0, len(c.Regions))
+		for _, r := range c.Regions {
+			if region := s.region(r); region == nil || region.Enabled {
+				regions = append(regions, r)
+			}
+		}
+
+		return regions
 	}
 
 	return nil
 }
 
+// GetRegion returns region metadata. It returns nil if region has no
+// metadata.
+func (m *configurations) GetRegion(code string) *Region {
+	r := m.current.Load().region(code)
+	if r == nil {
+		return nil
+	}
+	region := *r
+
+	return &region
+}
+
 func (m *configurations) ValidateCountryCode(code string) error {
-	for _, c := range m.Countries {
+	s := m.current.Load()
+	for _, c := range s.Countries {
 		if code == c.CountryCode {
 			if !c.Enabled {
 				return fmt.Errorf(`not allowed to take "%s" country the country is disabled`, code)
@@ -58,17 +63,35 @@ func (m *configurations) ValidateCountryCode(code string) error {
 }
 
 func (m *configurations) ValidateRegionCode(code string) error {
-	for _, c := range m.Countries {
+	s := m.current.Load()
+	if r := s.region(code); r != nil && !r.Enabled {
+		return fmt.Errorf(`not allowed to take "%s" region the region is disabled`, code)
+	}
+	var disabledCountry string
+	for _, c := range s.Countries {
 		for _, r := range c.Regions {
-			if code == r {
-				if !c.Enabled {
-					return fmt.Errorf(`not allowed to take "%s" region because "%s" is disabled`, code, c.CountryCode)
-				}
-
+			if code != r {
+				continue
+			}
+			if c.Enabled {
 				return nil
 			}
+			disabledCountry = c.CountryCode
 		}
 	}
+	if disabledCountry != "" {
+		return fmt.Errorf(`not allowed to take "%s" region because "%s" is disabled`, code, disabledCountry)
+	}
 
 	return fmt.Errorf("not found region (region=%s)", code)
 }
+
+func (s *Settings) region(code string) *Region {
+	for _, r := range s.Regions {
+		if r.RegionCode == code {
+			return r
+		}
+	}
+
+	return nil
+}
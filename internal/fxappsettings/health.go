@@ -0,0 +1,31 @@
+package fxappsettings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+)
+
+func newCheck(m AppSettings, w *watcher, watch bool) fxhealth.Check {
+	return fxhealth.Check{
+		Name:     "app_settings",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			status := m.GetStatus()
+			if status == nil || status.Version == 0 {
+				return errors.New("app settings are not loaded")
+			}
+			if status.LastError != "" {
+				return fxhealth.Degraded(fmt.Errorf("app settings reload is rejected (version = %v, at = %v): %v",
+					status.Version, status.LastErrorAt, status.LastError))
+			}
+			if watch && !w.running.Load() {
+				return fxhealth.Degraded(errors.New("app settings watcher is not running"))
+			}
+
+			return nil
+		},
+	}
+}
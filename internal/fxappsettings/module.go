@@ -3,42 +3,84 @@ package fxappsettings
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 type Config struct {
 	RegionsPath string `json:"regionsPath"`
 	LocalesPath string `json:"localesPath"`
+	// Watch enables reloading of settings on SIGHUP and on files change.
+	Watch bool `json:"watch"`
+	// RefreshInterval enables periodical reloading of settings. It is useful
+	// when settings are stored in database and changed by other process.
+	RefreshInterval time.Duration `json:"refreshInterval"`
+}
+
+type params struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *Config
+	Logger    *zap.Logger
+	// Source overrides default file source.
+	Source appsettings.Source `optional:"true"`
+}
+
+type result struct {
+	fx.Out
+
+	Settings AppSettings
+	Check    fxhealth.Check `group:"health_checks"`
 }
 
 var Module = fx.Options(
-	fx.Provide(func(lc fx.Lifecycle, cfg *Config) (AppSettings, error) {
+	fx.Provide(func(p params) (result, error) {
+		cfg := p.Config
 		if cfg.LocalesPath == "" || cfg.RegionsPath == "" {
-			return nil, errors.New("locales path or regions path is empty")
+			return result{}, errors.New("locales path or regions path is empty")
 		}
 
-		m := appsettings.New()
-		lc.Append(fx.Hook{
+		source := p.Source
+		var files []string
+		if source == nil {
+			source = &appsettings.FileSource{LocalesPath: cfg.LocalesPath, RegionsPath: cfg.RegionsPath}
+			files = []string{cfg.LocalesPath, cfg.RegionsPath}
+		}
+		m := appsettings.New(source)
+		w := newWatcher(m, p.Logger, cfg.RefreshInterval, files...)
+		p.Lifecycle.Append(fx.Hook{
 			OnStart: func(ctx context.Context) error {
-				if err := m.LoadLocales(ctx, cfg.LocalesPath); err != nil {
+				if _, err := m.Reload(ctx); err != nil {
 					return err
 				}
+				if !cfg.Watch {
+					return nil
+				}
 
-				return m.LoadRegions(ctx, cfg.RegionsPath)
+				return w.start()
+			},
+			OnStop: func(ctx context.Context) error {
+				return w.stop()
 			},
 		})
 
-		return m, nil
+		return result{Settings: m, Check: newCheck(m, w, cfg.Watch)}, nil
 	}),
 )
 
 type AppSettings interface {
 	GetCountries(onlyEnabled bool) []string
 	GetRegionsByCountryCode(code string) []string
+	GetRegion(code string) *appsettings.Region
 	ValidateRegionCode(code string) error
 	ValidateCountryCode(code string) error
 	GetLanguages(onlyEnabled bool) []string
 	ValidateLanguageCode(code string) error
+	Reload(ctx context.Context) (*appsettings.Status, error)
+	GetStatus() *appsettings.Status
 }
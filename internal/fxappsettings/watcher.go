@@ -0,0 +1,166 @@
+package fxappsettings
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// debounceInterval groups bursts of file events (editors usually write file
+// in several steps) into a single reload.
+const debounceInterval = 500 * time.Millisecond
+
+type reloader interface {
+	Reload(ctx context.Context) (*appsettings.Status, error)
+}
+
+type watcher struct {
+	settings reloader
+	logger   *zap.Logger
+	interval time.Duration
+	paths    map[string]struct{}
+	// targets are paths with resolved symlinks. Kubernetes updates config
+	// map by swapping `..data` symlink, so watched files are not changed
+	// themselves, but they point to other files.
+	targets map[string]string
+	version int64
+	running atomic.Bool
+
+	fs   *fsnotify.Watcher
+	sigs chan os.Signal
+	done chan struct{}
+}
+
+func newWatcher(settings reloader, logger *zap.Logger, interval time.Duration, paths ...string) *watcher {
+	w := &watcher{
+		settings: settings,
+		logger:   logger,
+		interval: interval,
+		paths:    make(map[string]struct{}, len(paths)),
+		targets:  make(map[string]string, len(paths)),
+	}
+	for _, p := range paths {
+		w.paths[filepath.Clean(p)] = struct{}{}
+	}
+	w.resolveTargets()
+
+	return w
+}
+
+func (w *watcher) start() error {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// directories are watched instead of files, so files replaced by rename
+	// (atomic writes) and swapped symlinks (k8s config maps) are still
+	// noticed.
+	dirs := map[string]struct{}{}
+	for p := range w.paths {
+		dirs[filepath.Dir(p)] = struct{}{}
+	}
+	for d := range dirs {
+		if err := fs.Add(d); err != nil {
+			_ = fs.Close()
+			return err
+		}
+	}
+
+	w.fs = fs
+	w.sigs = make(chan os.Signal, 1)
+	w.done = make(chan struct{})
+	signal.Notify(w.sigs, syscall.SIGHUP)
+	go w.loop()
+
+	return nil
+}
+
+func (w *watcher) stop() error {
+	if w.fs == nil {
+		return nil
+	}
+	signal.Stop(w.sigs)
+	close(w.done)
+
+	return w.fs.Close()
+}
+
+func (w *watcher) loop() {
+	w.running.Store(true)
+	defer w.running.Store(false)
+
+	var debounce <-chan time.Time
+	var refresh <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		refresh = ticker.C
+	}
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.sigs:
+			w.reload("signal")
+		case <-refresh:
+			w.reload("refresh")
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if _, watched := w.paths[filepath.Clean(event.Name)]; watched || w.resolveTargets() {
+				debounce = time.After(debounceInterval)
+			}
+		case <-debounce:
+			debounce = nil
+			w.reload("file change")
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Error("app settings watcher error", zap.Error(err))
+		}
+	}
+}
+
+// resolveTargets resolves symlinks of watched paths and returns true if any
+// of them points to other file than before.
+func (w *watcher) resolveTargets() bool {
+	var changed bool
+	for p := range w.paths {
+		target, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			// file is being replaced, it is resolved on the next event.
+			continue
+		}
+		if w.targets[p] != target {
+			w.targets[p] = target
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (w *watcher) reload(reason string) {
+	status, err := w.settings.Reload(context.Background())
+	if err != nil {
+		w.logger.Error("app settings reload is rejected, previous version is kept",
+			zap.String("reason", reason), zap.Int64("version", status.Version), zap.Error(err))
+		return
+	}
+	if status.Version == w.version {
+		return
+	}
+	w.version = status.Version
+	w.logger.Info("app settings are reloaded",
+		zap.String("reason", reason), zap.Int64("version", status.Version))
+}
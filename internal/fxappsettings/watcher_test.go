@@ -0,0 +1,74 @@
+package fxappsettings
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"go.uber.org/zap"
+)
+
+type reloaderFunc func(ctx context.Context) (*appsettings.Status, error)
+
+func (f reloaderFunc) Reload(ctx context.Context) (*appsettings.Status, error) {
+	return f(ctx)
+}
+
+// writeConfigMapVersion writes files the way kubelet does it: into new
+// timestamped directory which `..data` symlink is swapped to.
+func writeConfigMapVersion(t *testing.T, dir, version string) {
+	t.Helper()
+	versionDir := filepath.Join(dir, version)
+	if err := os.Mkdir(versionDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(versionDir, "regions.yaml"), []byte(version), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tmp := filepath.Join(dir, "..data_tmp")
+	if err := os.Symlink(version, tmp); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcherReloadsOnConfigMapSymlinkSwap(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigMapVersion(t, dir, "..2024_01_01_00_00_00.1")
+	path := filepath.Join(dir, "regions.yaml")
+	if err := os.Symlink(filepath.Join("..data", "regions.yaml"), path); err != nil {
+		t.Fatal(err)
+	}
+
+	reloads := make(chan struct{}, 10)
+	var version int64
+	w := newWatcher(reloaderFunc(func(context.Context) (*appsettings.Status, error) {
+		version++
+		reloads <- struct{}{}
+		return &appsettings.Status{Version: version}, nil
+	}), zap.NewNop(), 0, path)
+	if err := w.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = w.stop() }()
+
+	writeConfigMapVersion(t, dir, "..2024_01_01_00_00_01.2")
+
+	select {
+	case <-reloads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("settings are not reloaded after config map update")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "..2024_01_01_00_00_01.2" {
+		t.Fatalf("unexpected content of watched file: %q", b)
+	}
+}
@@ -0,0 +1,219 @@
+package fxhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type Status string
+
+const (
+	OKStatus       Status = "ok"
+	DegradedStatus Status = "degraded"
+	FailedStatus   Status = "failed"
+)
+
+const defaultCheckTimeout = 2 * time.Second
+
+// Check is a dependency check of readiness probe. Failure of critical check
+// makes service not ready, failure of non critical check only degrades it.
+// Check can report degradation itself by returning error wrapped by Degraded.
+type Check struct {
+	Name     string
+	Critical bool
+	// Timeout defaults to 2 seconds.
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+type degradedError struct {
+	err error
+}
+
+func (e *degradedError) Error() string { return e.err.Error() }
+func (e *degradedError) Unwrap() error { return e.err }
+
+// Degraded marks error of check as degradation, so it doesn't fail
+// readiness even if check is critical.
+func Degraded(err error) error {
+	return &degradedError{err: err}
+}
+
+type Config struct {
+	// DrainDelay is time between readiness is flipped to false on shutdown
+	// and server stops accepting connections, so load balancers have time
+	// to notice.
+	DrainDelay time.Duration
+}
+
+type CheckResult struct {
+	Status    Status  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status       Status                 `json:"status"`
+	ShuttingDown bool                   `json:"shutting_down,omitempty"`
+	Checks       map[string]CheckResult `json:"checks"`
+}
+
+type Health struct {
+	checks       []Check
+	drainDelay   time.Duration
+	logger       *zap.Logger
+	shuttingDown atomic.Bool
+}
+
+type params struct {
+	fx.In
+
+	Config *Config `optional:"true"`
+	Checks []Check `group:"health_checks"`
+	Logger *zap.Logger
+}
+
+var Module = fx.Options(
+	fx.Provide(New),
+	fx.Provide(NewJobs),
+	fx.Provide(AsCheck(newJobsCheck)),
+)
+
+func New(p params) *Health {
+	checks := append([]Check{}, p.Checks...)
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	h := &Health{checks: checks, logger: p.Logger}
+	if p.Config != nil {
+		h.drainDelay = p.Config.DrainDelay
+	}
+
+	return h
+}
+
+// AsCheck annotates constructor of check, so it is collected by Module.
+func AsCheck(f interface{}) interface{} {
+	return fx.Annotate(f, fx.ResultTags(`group:"health_checks"`))
+}
+
+// Drain flips readiness to false and waits for drain delay. It must be
+// called by server before it stops accepting connections.
+func (h *Health) Drain(ctx context.Context) {
+	if h.shuttingDown.Swap(true) || h.drainDelay <= 0 {
+		return
+	}
+	h.logger.Info("readiness is flipped to false, waiting before shutdown", zap.Duration("delay", h.drainDelay))
+	select {
+	case <-ctx.Done():
+	case <-time.After(h.drainDelay):
+	}
+}
+
+// Ready runs all checks concurrently.
+func (h *Health) Ready(ctx context.Context) *Report {
+	report := &Report{
+		Status:       OKStatus,
+		ShuttingDown: h.shuttingDown.Load(),
+		Checks:       make(map[string]CheckResult, len(h.checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func(c Check) {
+			defer wg.Done()
+			res := run(ctx, c)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name] = res
+			switch {
+			case res.Status == FailedStatus && c.Critical:
+				report.Status = FailedStatus
+			case res.Status != OKStatus && report.Status == OKStatus:
+				report.Status = DegradedStatus
+			}
+		}(c)
+	}
+	wg.Wait()
+	if report.ShuttingDown {
+		report.Status = FailedStatus
+	}
+
+	return report
+}
+
+func run(ctx context.Context, c Check) CheckResult {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.Run(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	res := CheckResult{Status: OKStatus, LatencyMS: float64(time.Since(start).Microseconds()) / 1000}
+	if err == nil {
+		return res
+	}
+	res.Error = err.Error()
+	var degraded *degradedError
+	if errors.As(err, &degraded) {
+		res.Status = DegradedStatus
+	} else {
+		res.Status = FailedStatus
+	}
+
+	return res
+}
+
+// LivezHandler reports that process is alive. It doesn't run checks, so
+// broken dependency doesn't make orchestrator restart service.
+func (h *Health) LivezHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		writeJSON(rw, http.StatusOK, map[string]Status{"status": OKStatus})
+	})
+}
+
+// ReadyzHandler responds 503 if critical check failed or service is shutting
+// down. Degraded service is still ready.
+func (h *Health) ReadyzHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		report := h.Ready(r.Context())
+		status := http.StatusOK
+		if report.Status == FailedStatus {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(rw, status, report)
+	})
+}
+
+// Mount mounts /livez and /readyz on mux.
+func (h *Health) Mount(mux *http.ServeMux) {
+	mux.Handle("/livez", h.LivezHandler())
+	mux.Handle("/readyz", h.ReadyzHandler())
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(v)
+}
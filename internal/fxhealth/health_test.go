@@ -0,0 +1,97 @@
+package fxhealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestReadyz(t *testing.T) {
+	failing := func(ctx context.Context) error { return errors.New("boom") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+	tests := []struct {
+		name   string
+		checks []Check
+		status Status
+		code   int
+	}{
+		{
+			name:   "ok",
+			checks: []Check{{Name: "a", Critical: true, Run: func(ctx context.Context) error { return nil }}},
+			status: OKStatus,
+			code:   http.StatusOK,
+		},
+		{
+			name: "degraded by non critical check",
+			checks: []Check{
+				{Name: "a", Critical: true, Run: func(ctx context.Context) error { return nil }},
+				{Name: "b", Run: failing},
+			},
+			status: DegradedStatus,
+			code:   http.StatusOK,
+		},
+		{
+			name: "degraded by critical check",
+			checks: []Check{{Name: "a", Critical: true, Run: func(ctx context.Context) error {
+				return Degraded(errors.New("slow"))
+			}}},
+			status: DegradedStatus,
+			code:   http.StatusOK,
+		},
+		{
+			name:   "failed",
+			checks: []Check{{Name: "a", Critical: true, Run: failing}},
+			status: FailedStatus,
+			code:   http.StatusServiceUnavailable,
+		},
+		{
+			name:   "timed out",
+			checks: []Check{{Name: "a", Critical: true, Timeout: 10 * time.Millisecond, Run: slow}},
+			status: FailedStatus,
+			code:   http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(params{Checks: tt.checks, Logger: zap.NewNop()})
+			if report := h.Ready(context.Background()); report.Status != tt.status {
+				t.Fatalf("unexpected status (expected = %v, got = %v, checks = %v)", tt.status, report.Status, report.Checks)
+			}
+			rec := httptest.NewRecorder()
+			h.ReadyzHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.code {
+				t.Fatalf("unexpected code (expected = %v, got = %v)", tt.code, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDrainFlipsReadiness(t *testing.T) {
+	h := New(params{Config: &Config{DrainDelay: 20 * time.Millisecond}, Logger: zap.NewNop()})
+	mux := http.NewServeMux()
+	h.Mount(mux)
+
+	start := time.Now()
+	h.Drain(context.Background())
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("drain returned before delay (elapsed = %v)", elapsed)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected not ready after drain (code = %v)", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected alive after drain (code = %v)", rec.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package fxhealth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// job is overdue if it doesn't tick during jobOverdueFactor intervals,
+	// but not earlier than after jobOverdueMin, so slow run of frequent job
+	// is not reported.
+	jobOverdueFactor = 3
+	jobOverdueMin    = time.Minute
+)
+
+// Jobs records ticks of background jobs of the process. Its check reports
+// jobs which stopped ticking, so stuck or crashed worker is visible in
+// readiness report.
+type Jobs struct {
+	now func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// Job is background job registered in Jobs.
+type Job struct {
+	jobs *Jobs
+	name string
+
+	mu       sync.Mutex
+	lastTick time.Time
+	next     time.Duration
+}
+
+func NewJobs() *Jobs {
+	return &Jobs{now: time.Now, jobs: map[string]*Job{}}
+}
+
+// Register adds job which is expected to tick every interval. Registration
+// counts as the first tick.
+func (j *Jobs) Register(name string, interval time.Duration) *Job {
+	job := &Job{jobs: j, name: name, lastTick: j.now(), next: interval}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jobs[name] = job
+
+	return job
+}
+
+// Tick records that job ran and expects the next tick within next.
+func (j *Job) Tick(next time.Duration) {
+	now := j.jobs.now()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.lastTick = now
+	j.next = next
+}
+
+func (j *Job) overdue(now time.Time) (time.Duration, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	limit := jobOverdueFactor * j.next
+	if limit < jobOverdueMin {
+		limit = jobOverdueMin
+	}
+	since := now.Sub(j.lastTick)
+
+	return since, since > limit
+}
+
+func newJobsCheck(j *Jobs) Check {
+	return Check{
+		Name: "jobs",
+		Run: func(ctx context.Context) error {
+			now := j.now()
+			j.mu.Lock()
+			defer j.mu.Unlock()
+			var overdue []string
+			for name, job := range j.jobs {
+				if since, ok := job.overdue(now); ok {
+					overdue = append(overdue, fmt.Sprintf("%v (last tick = %v ago)", name, since.Round(time.Second)))
+				}
+			}
+			if len(overdue) > 0 {
+				sort.Strings(overdue)
+				return fmt.Errorf("background jobs are overdue: %v", strings.Join(overdue, ", "))
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package fxhealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobsCheck(t *testing.T) {
+	now := time.Now()
+	jobs := NewJobs()
+	jobs.now = func() time.Time { return now }
+	check := newJobsCheck(jobs)
+	require.NoError(t, check.Run(context.Background()), "no jobs")
+
+	relay := jobs.Register("event_relay", 10*time.Second)
+	cleanup := jobs.Register("cleanup", time.Hour)
+	now = now.Add(time.Minute)
+	require.NoError(t, check.Run(context.Background()), "frequent job is overdue before minimum")
+
+	now = now.Add(time.Second)
+	err := check.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "event_relay")
+	assert.NotContains(t, err.Error(), "cleanup")
+
+	relay.Tick(10 * time.Second)
+	require.NoError(t, check.Run(context.Background()))
+
+	cleanup.Tick(time.Minute)
+	now = now.Add(3*time.Minute + time.Second)
+	relay.Tick(10 * time.Second)
+	err = check.Run(context.Background())
+	require.Error(t, err, "interval of the next tick is not used")
+	assert.Contains(t, err.Error(), "cleanup")
+}
@@ -0,0 +1,47 @@
+package fxlogger
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// scope is request scoped logger. It is shared by pointer, so fields added
+// deeper in handler chain are visible to middlewares which created it.
+type scope struct {
+	mu sync.RWMutex
+	l  *zap.Logger
+}
+
+// WithContext puts request scoped logger into context.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &scope{l: l})
+}
+
+// FromContext returns request scoped logger of context or fallback if
+// context has no logger.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	s, ok := ctx.Value(ctxKey{}).(*scope)
+	if !ok {
+		return fallback
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.l
+}
+
+// AddFields adds fields to request scoped logger of context, e.g. ID of
+// authorized comptus. It does nothing if context has no logger.
+func AddFields(ctx context.Context, fields ...zap.Field) {
+	s, ok := ctx.Value(ctxKey{}).(*scope)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.l = s.l.With(fields...)
+}
@@ -0,0 +1,49 @@
+package fxlogger
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAddFieldsAreVisibleToParentContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := WithContext(context.Background(), zap.New(core).With(zap.String("request_id", "req-1")))
+	// Handler authorizes request in derived context.
+	derived, cancel := context.WithCancel(ctx)
+	defer cancel()
+	AddFields(derived, zap.Int64("comptus_id", 42))
+
+	FromContext(ctx, zap.NewNop()).Info("access")
+
+	entries := logs.All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]interface{}{"request_id": "req-1", "comptus_id": int64(42)}, entries[0].ContextMap())
+}
+
+func TestFromContextFallback(t *testing.T) {
+	fallback := zap.NewNop()
+	assert.Same(t, fallback, FromContext(context.Background(), fallback))
+	AddFields(context.Background(), zap.String("k", "v"))
+}
+
+func TestRedact(t *testing.T) {
+	type signIn struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+		Email        string `json:"email"`
+	}
+	assert.Equal(t, map[string]interface{}{
+		"token":         "REDACTED",
+		"refresh_token": "REDACTED",
+		"email":         "a@b.c",
+	}, Redact(signIn{Token: "t", RefreshToken: "r", Email: "a@b.c"}))
+	assert.Equal(t, "ok", Redact("ok"))
+
+	u, _ := url.Parse("/invites?code=abc&region=ua")
+	assert.Equal(t, "/invites?code=REDACTED&region=ua", RedactQuery(u))
+}
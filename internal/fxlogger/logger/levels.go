@@ -0,0 +1,134 @@
+package logger
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels holds levels of logger which can be changed at runtime. Level of
+// named logger applies to its children too, e.g. level of "access" applies
+// to "access.slow". Loggers without own level use root level.
+//
+// Named levels are immutable map which is replaced on change, so levels are
+// read by every log entry without locking.
+type Levels struct {
+	root  zap.AtomicLevel
+	mu    sync.Mutex
+	named atomic.Pointer[map[string]zapcore.Level]
+}
+
+func NewLevels(root zapcore.Level) *Levels {
+	l := &Levels{root: zap.NewAtomicLevelAt(root)}
+	l.named.Store(&map[string]zapcore.Level{})
+
+	return l
+}
+
+func (l *Levels) Root() zapcore.Level {
+	return l.root.Level()
+}
+
+func (l *Levels) SetRoot(lvl zapcore.Level) {
+	l.root.SetLevel(lvl)
+}
+
+// Named returns names of loggers with own level sorted by name.
+func (l *Levels) Named() []string {
+	named := *l.named.Load()
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (l *Levels) SetNamed(name string, lvl zapcore.Level) {
+	l.updateNamed(func(named map[string]zapcore.Level) {
+		named[name] = lvl
+	})
+}
+
+// ResetNamed makes logger use level of its parent again.
+func (l *Levels) ResetNamed(name string) {
+	l.updateNamed(func(named map[string]zapcore.Level) {
+		delete(named, name)
+	})
+}
+
+// updateNamed applies update to copy of named levels and swaps them.
+func (l *Levels) updateNamed(update func(named map[string]zapcore.Level)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev := *l.named.Load()
+	next := make(map[string]zapcore.Level, len(prev)+1)
+	for name, lvl := range prev {
+		next[name] = lvl
+	}
+	update(next)
+	l.named.Store(&next)
+}
+
+// LevelFor returns effective level of named logger.
+func (l *Levels) LevelFor(name string) zapcore.Level {
+	named := *l.named.Load()
+	if len(named) == 0 {
+		return l.root.Level()
+	}
+	for name != "" {
+		if lvl, ok := named[name]; ok {
+			return lvl
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	return l.root.Level()
+}
+
+func (l *Levels) minLevel() zapcore.Level {
+	lvl := l.root.Level()
+	for _, named := range *l.named.Load() {
+		if named < lvl {
+			lvl = named
+		}
+	}
+
+	return lvl
+}
+
+// Core wraps core, so entries are filtered by levels. Wrapped core must
+// enable all levels.
+func (l *Levels) Core(core zapcore.Core) zapcore.Core {
+	return &levelsCore{Core: core, levels: l}
+}
+
+type levelsCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelsCore) Enabled(lvl zapcore.Level) bool {
+	return lvl >= c.levels.minLevel()
+}
+
+func (c *levelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelsCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.levels.LevelFor(ent.LoggerName) {
+		return ce
+	}
+
+	return c.Core.Check(ent, ce)
+}
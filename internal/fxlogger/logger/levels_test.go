@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevels(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	levels := NewLevels(zap.InfoLevel)
+	l := zap.New(levels.Core(core))
+
+	l.Debug("dropped")
+	l.Named("access").Info("kept")
+	levels.SetNamed("access", zap.WarnLevel)
+	l.Named("access").Named("slow").Info("dropped")
+	l.Info("kept")
+	levels.SetRoot(zap.DebugLevel)
+	l.Debug("kept")
+	levels.ResetNamed("access")
+	l.Named("access").Debug("kept")
+
+	assert.Equal(t, 4, logs.FilterMessage("kept").Len())
+	assert.Equal(t, 0, logs.FilterMessage("dropped").Len())
+}
+
+func TestLevelsAreChangedWhileLogging(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	levels := NewLevels(zap.InfoLevel)
+	l := zap.New(levels.Core(core)).Named("access")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			levels.SetNamed("access", zap.ErrorLevel)
+			levels.ResetNamed("access")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		l.Warn("maybe")
+	}
+	wg.Wait()
+	l.Warn("kept")
+
+	assert.Equal(t, 1, logs.FilterMessage("kept").Len())
+	assert.Empty(t, levels.Named())
+}
@@ -8,16 +8,10 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewZapLogger(serviceName fxtypes.ServiceName, prod bool, lc fx.Lifecycle) (*zap.Logger, error) {
-	var logger *zap.Logger
-	var err error
-	if prod {
-		logger, err = NewProductionLogger(string(serviceName))
-	} else {
-		logger, err = NewDevelopmentLogger(string(serviceName))
-	}
+func NewZapLogger(serviceName fxtypes.ServiceName, prod bool, lc fx.Lifecycle) (*zap.Logger, *Levels, error) {
+	logger, levels, err := NewLoggerWithLevels(string(serviceName), prod)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	zap.ReplaceGlobals(logger)
 
@@ -29,7 +23,7 @@ func NewZapLogger(serviceName fxtypes.ServiceName, prod bool, lc fx.Lifecycle) (
 		},
 	})
 
-	return logger, nil
+	return logger, levels, nil
 }
 
 func ZapSugared(log *zap.Logger) *zap.SugaredLogger {
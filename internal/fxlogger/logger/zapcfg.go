@@ -9,13 +9,13 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-func newLogger(serviceName string, zapConfig zap.Config) (*zap.Logger, error) {
+func newLogger(serviceName string, zapConfig zap.Config, opts ...zap.Option) (*zap.Logger, error) {
 	zapConfig.EncoderConfig.TimeKey = "time"
 	zapConfig.EncoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
 		enc.AppendString(t.UTC().Format(timeutils.DefaultTimeFormat))
 	}
 
-	logger, err := zapConfig.Build()
+	logger, err := zapConfig.Build(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -35,3 +35,21 @@ func NewDevelopmentLogger(serviceName string) (*zap.Logger, error) {
 	zapConfig.Sampling = nil
 	return newLogger(serviceName, zapConfig)
 }
+
+// NewLoggerWithLevels returns logger with levels which can be changed at
+// runtime. Initial root level is Debug in development and Info in production.
+func NewLoggerWithLevels(serviceName string, prod bool) (*zap.Logger, *Levels, error) {
+	zapConfig := zap.NewProductionConfig()
+	levels := NewLevels(zap.InfoLevel)
+	if !prod {
+		zapConfig.Sampling = nil
+		levels.SetRoot(zap.DebugLevel)
+	}
+	zapConfig.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	logger, err := newLogger(serviceName, zapConfig, zap.WrapCore(levels.Core))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return logger, levels, nil
+}
@@ -16,13 +16,18 @@ type loggerParams struct {
 	Config      *Config
 }
 
+// Levels are runtime adjustable levels of logger provided by Module.
+type Levels = logger.Levels
+
+var NewLevels = logger.NewLevels
+
 type Config struct {
 	Prod bool
 }
 
 var Module = fx.Options(
 	fx.Provide(
-		func(params loggerParams) (*zap.Logger, error) {
+		func(params loggerParams) (*zap.Logger, *logger.Levels, error) {
 			return logger.NewZapLogger(params.ServiceName, params.Config.Prod, params.Lifecycle)
 		},
 		logger.ZapSugared,
@@ -0,0 +1,76 @@
+package fxlogger
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+var sensitiveKeys = map[string]struct{}{
+	"password":      {},
+	"token":         {},
+	"refresh_token": {},
+	"api_key":       {},
+	"secret":        {},
+	"code":          {},
+	"authorization": {},
+}
+
+func isSensitive(key string) bool {
+	_, ok := sensitiveKeys[strings.ToLower(key)]
+	return ok
+}
+
+// Redact returns copy of value as generic JSON with values of sensitive keys
+// replaced. Value which can't be represented as JSON is returned as is.
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return v
+	}
+
+	return redactValue(generic)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, item := range t {
+			if isSensitive(k) {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range t {
+			t[i] = redactValue(item)
+		}
+	}
+
+	return v
+}
+
+// RedactQuery replaces values of sensitive query parameters of URL.
+func RedactQuery(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.Path
+	}
+	q := u.Query()
+	for k := range q {
+		if isSensitive(k) {
+			q[k] = []string{redacted}
+		}
+	}
+
+	return u.Path + "?" + q.Encode()
+}
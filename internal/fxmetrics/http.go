@@ -0,0 +1,40 @@
+package fxmetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTPMetrics are metrics of HTTP servers. They are labeled by server (app
+// or admin), method, route template and status code. Route template is used
+// instead of path to keep cardinality bounded.
+type HTTPMetrics struct {
+	Requests *prometheus.CounterVec
+	Duration *prometheus.HistogramVec
+}
+
+func NewHTTPMetrics(reg prometheus.Registerer) (*HTTPMetrics, error) {
+	labels := []string{"server", "method", "route", "status"}
+	m := &HTTPMetrics{
+		Requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of handled HTTP requests.",
+		}, labels),
+		Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of handled HTTP requests.",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, labels),
+	}
+	if err := reg.Register(m.Requests); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.Duration); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,106 @@
+package fxmetrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
+	"github.com/ecumenos/ecumenos/internal/zerodowntime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Namespace is prefix of all metrics of ecumenos services.
+const Namespace = "ecumenos"
+
+type Config struct {
+	// Addr is address of standalone metrics listener. It is needed by
+	// processes without admin listener only, admin servers serve /metrics
+	// themselves.
+	Addr string
+}
+
+var Module = fx.Options(
+	fx.Provide(
+		NewRegistry,
+		func(reg *prometheus.Registry) prometheus.Registerer { return reg },
+		NewHTTPMetrics,
+	),
+	fx.Invoke(runServer),
+)
+
+// NewRegistry returns registry with runtime, process and build info
+// collectors. Default prometheus registry is not used, so metrics of
+// libraries don't leak in.
+func NewRegistry(name fxtypes.ServiceName, version fxtypes.Version) (*prometheus.Registry, error) {
+	reg := prometheus.NewRegistry()
+	buildInfo := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   Namespace,
+		Name:        "build_info",
+		Help:        "Build information of service, value is always 1.",
+		ConstLabels: prometheus.Labels{"service": string(name), "version": string(version)},
+	})
+	buildInfo.Set(1)
+	for _, c := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		buildInfo,
+	} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return reg, nil
+}
+
+// Handler serves metrics of registry in prometheus exposition format.
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})
+}
+
+type serverParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    *Config `optional:"true"`
+	Registry  *prometheus.Registry
+	Logger    *zap.Logger
+}
+
+func runServer(params serverParams) {
+	if params.Config == nil || params.Config.Addr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(params.Registry))
+	server := &http.Server{
+		Addr:              params.Config.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			ln, err := zerodowntime.Listen(server.Addr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					params.Logger.Error("metrics server run error", zap.Error(err))
+				}
+			}()
+			params.Logger.Info("metrics server is started", zap.String("addr", server.Addr))
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+}
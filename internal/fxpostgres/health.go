@@ -0,0 +1,102 @@
+package fxpostgres
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/migrations"
+	"github.com/jackc/pgx/v4"
+)
+
+// SlowPingLatency is latency of ping after which postgres is reported as
+// degraded.
+const SlowPingLatency = 500 * time.Millisecond
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type RowQuerier interface {
+	QueryRow(ctx context.Context, query string, args ...interface{}) (pgx.Row, error)
+}
+
+func NewPingCheck(p Pinger) fxhealth.Check {
+	return fxhealth.Check{
+		Name:     "postgres",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			start := time.Now()
+			if err := p.Ping(ctx); err != nil {
+				return err
+			}
+			if latency := time.Since(start); latency > SlowPingLatency {
+				return fxhealth.Degraded(fmt.Errorf("postgres ping is slow (latency = %v)", latency))
+			}
+
+			return nil
+		},
+	}
+}
+
+// NewMigrationsCheck compares version of database schema with the latest
+// migration of source (see migrations.Open). Schema behind migrations or
+// dirty schema fails readiness, schema ahead of migrations (e.g. during
+// rolling deploy) only degrades it.
+func NewMigrationsCheck(q RowQuerier, sourceURL string, embedded fs.FS) fxhealth.Check {
+	latest, latestErr := migrations.LatestVersion(sourceURL, embedded)
+
+	return fxhealth.Check{
+		Name:     "migrations",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			if latestErr != nil {
+				return fxhealth.Degraded(fmt.Errorf("can not read migrations (source = %v): %w", sourceURL, latestErr))
+			}
+			version, dirty, err := schemaVersion(ctx, q)
+			if err != nil {
+				return err
+			}
+			switch {
+			case dirty:
+				return fmt.Errorf("schema is dirty (version = %v)", version)
+			case version < latest:
+				return fmt.Errorf("schema is behind migrations (version = %v, latest = %v)", version, latest)
+			case version > latest:
+				return fxhealth.Degraded(fmt.Errorf("schema is ahead of migrations (version = %v, latest = %v)", version, latest))
+			}
+
+			return nil
+		},
+	}
+}
+
+func schemaVersion(ctx context.Context, q RowQuerier) (uint, bool, error) {
+	// table is resolved when query is planned, so its existence is checked by
+	// separate query.
+	row, err := q.QueryRow(ctx, `select to_regclass('schema_migrations') is not null;`)
+	if err != nil {
+		return 0, false, err
+	}
+	var exists bool
+	if err := row.Scan(&exists); err != nil {
+		return 0, false, err
+	}
+	if !exists {
+		return 0, false, nil
+	}
+
+	row, err = q.QueryRow(ctx, `select coalesce(max(version), 0), coalesce(bool_or(dirty), false) from schema_migrations;`)
+	if err != nil {
+		return 0, false, err
+	}
+	var version int64
+	var dirty bool
+	if err := row.Scan(&version, &dirty); err != nil {
+		return 0, false, err
+	}
+
+	return uint(version), dirty, nil
+}
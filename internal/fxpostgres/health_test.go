@@ -0,0 +1,68 @@
+package fxpostgres_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ecumenos/ecumenos/internal/fxpostgres"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = fstest.MapFS{
+	"000001_init.up.sql":   {Data: []byte("create table a (id bigint);")},
+	"000001_init.down.sql": {Data: []byte("drop table a;")},
+}
+
+func schemaDriver(exists bool, version int64, dirty bool) *pgxtest.Driver {
+	return &pgxtest.Driver{
+		OnQueryRow: func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+			if strings.Contains(query, "to_regclass") {
+				return pgxtest.Row(exists), nil
+			}
+			if !exists {
+				return nil, errors.New(`relation "schema_migrations" does not exist`)
+			}
+			return pgxtest.Row(version, dirty), nil
+		},
+	}
+}
+
+func TestMigrationsCheck(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		driver   *pgxtest.Driver
+		fails    bool
+		degraded bool
+	}{
+		{name: "up to date", driver: schemaDriver(true, 1, false)},
+		{name: "not migrated", driver: schemaDriver(false, 0, false), fails: true},
+		{name: "behind", driver: schemaDriver(true, 0, false), fails: true},
+		{name: "dirty", driver: schemaDriver(true, 1, true), fails: true},
+		{name: "ahead", driver: schemaDriver(true, 2, false), degraded: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := fxpostgres.NewMigrationsCheck(tc.driver, "", testMigrations).Run(context.Background())
+			switch {
+			case tc.fails:
+				require.Error(t, err)
+				assert.NotContains(t, err.Error(), "does not exist")
+			case tc.degraded:
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "ahead")
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMigrationsCheckWithoutMigrations(t *testing.T) {
+	err := fxpostgres.NewMigrationsCheck(schemaDriver(false, 0, false), "", nil).Run(context.Background())
+	require.NoError(t, err)
+}
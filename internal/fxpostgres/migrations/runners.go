@@ -2,6 +2,7 @@ package migrations
 
 import (
 	"errors"
+	"io/fs"
 
 	"github.com/golang-migrate/migrate/v4"
 
@@ -13,14 +14,14 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewMigrateUpFunc() func(sourceURL, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
-	return func(sourceURL, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
+func NewMigrateUpFunc() func(sourceURL string, embedded fs.FS, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
+	return func(sourceURL string, embedded fs.FS, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
 		log.Info("command starting...")
 		defer func(l *zap.Logger) {
 			l.Info("command finished")
 		}(log)
 
-		if err := migrateUp(sourceURL, dbURL); err != nil {
+		if err := MigrateUp(sourceURL, embedded, dbURL); err != nil {
 			return err
 		}
 
@@ -29,11 +30,14 @@ func NewMigrateUpFunc() func(sourceURL, dbURL string, log *zap.Logger, shutdowne
 	}
 }
 
-func migrateUp(sourceURL, dbURL string) error {
-	m, err := migrate.New(sourceURL, dbURL)
-	if err != nil {
+// MigrateUp applies all up migrations of source (see Open) which are not
+// applied yet.
+func MigrateUp(sourceURL string, embedded fs.FS, dbURL string) error {
+	m, err := newMigrate(sourceURL, embedded, dbURL)
+	if err != nil || m == nil {
 		return err
 	}
+	defer m.Close()
 	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return err
 	}
@@ -41,14 +45,14 @@ func migrateUp(sourceURL, dbURL string) error {
 	return nil
 }
 
-func NewMigrateDownFunc() func(sourceURL, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
-	return func(sourceURL, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
+func NewMigrateDownFunc() func(sourceURL string, embedded fs.FS, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
+	return func(sourceURL string, embedded fs.FS, dbURL string, log *zap.Logger, shutdowner fx.Shutdowner) error {
 		log.Info("command starting...")
 		defer func(l *zap.Logger) {
 			l.Info("command finished")
 		}(log)
 
-		if err := migrateDown(sourceURL, dbURL); err != nil {
+		if err := migrateDown(sourceURL, embedded, dbURL); err != nil {
 			return err
 		}
 
@@ -57,14 +61,25 @@ func NewMigrateDownFunc() func(sourceURL, dbURL string, log *zap.Logger, shutdow
 	}
 }
 
-func migrateDown(sourceURL, dbURL string) error {
-	m, err := migrate.New(sourceURL, dbURL)
-	if err != nil {
+func migrateDown(sourceURL string, embedded fs.FS, dbURL string) error {
+	m, err := newMigrate(sourceURL, embedded, dbURL)
+	if err != nil || m == nil {
 		return err
 	}
+	defer m.Close()
 	if err = m.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return err
 	}
 
 	return nil
 }
+
+// newMigrate returns nil if service has no migrations.
+func newMigrate(sourceURL string, embedded fs.FS, dbURL string) (*migrate.Migrate, error) {
+	src, err := Open(sourceURL, embedded)
+	if err != nil || src == nil {
+		return nil, err
+	}
+
+	return migrate.NewWithSourceInstance("migrations", src, dbURL)
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Open returns source of migrations. Migrations embedded in binary are used
+// if sourceURL is empty, file:// source is useful for development. It
+// returns nil if service has no migrations.
+func Open(sourceURL string, embedded fs.FS) (source.Driver, error) {
+	if sourceURL != "" {
+		return source.Open(sourceURL)
+	}
+	if embedded == nil {
+		return nil, nil
+	}
+
+	return iofs.New(embedded, ".")
+}
+
+// LatestVersion returns version of the newest migration of source, 0 if
+// service has no migrations.
+func LatestVersion(sourceURL string, embedded fs.FS) (uint, error) {
+	src, err := Open(sourceURL, embedded)
+	if err != nil || src == nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	latest, err := src.First()
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := src.Next(latest)
+		if errors.Is(err, fs.ErrNotExist) {
+			return latest, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		latest = next
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestVersion(t *testing.T) {
+	embedded := fstest.MapFS{
+		"000001_init.up.sql":       {Data: []byte("create table a (id bigint);")},
+		"000001_init.down.sql":     {Data: []byte("drop table a;")},
+		"000002_more.up.sql":       {Data: []byte("create table b (id bigint);")},
+		"000002_more.down.sql":     {Data: []byte("drop table b;")},
+		"000010_the_last.up.sql":   {Data: []byte("create table c (id bigint);")},
+		"000010_the_last.down.sql": {Data: []byte("drop table c;")},
+	}
+	latest, err := LatestVersion("", embedded)
+	require.NoError(t, err)
+	assert.Equal(t, uint(10), latest)
+
+	// service without migrations.
+	latest, err = LatestVersion("", nil)
+	require.NoError(t, err)
+	assert.Zero(t, latest)
+
+	// misconfigured source is reported instead of being treated as empty.
+	_, err = LatestVersion("file://"+t.TempDir()+"/missing", embedded)
+	require.Error(t, err)
+}
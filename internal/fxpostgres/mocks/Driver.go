@@ -181,6 +181,101 @@ func (_c *MockDriver_ExecuteQuery_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// InTx provides a mock function with given fields: ctx, fn
+func (_m *MockDriver) InTx(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDriver_InTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTx'
+type MockDriver_InTx_Call struct {
+	*mock.Call
+}
+
+// InTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(context.Context) error
+func (_e *MockDriver_Expecter) InTx(ctx interface{}, fn interface{}) *MockDriver_InTx_Call {
+	return &MockDriver_InTx_Call{Call: _e.mock.On("InTx", ctx, fn)}
+}
+
+func (_c *MockDriver_InTx_Call) Run(run func(ctx context.Context, fn func(context.Context) error)) *MockDriver_InTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockDriver_InTx_Call) Return(_a0 error) *MockDriver_InTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDriver_InTx_Call) RunAndReturn(run func(context.Context, func(context.Context) error) error) *MockDriver_InTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Listen provides a mock function with given fields: ctx, channel, handle
+func (_m *MockDriver) Listen(ctx context.Context, channel string, handle func(string)) error {
+	ret := _m.Called(ctx, channel, handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Listen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(string)) error); ok {
+		r0 = rf(ctx, channel, handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDriver_Listen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Listen'
+type MockDriver_Listen_Call struct {
+	*mock.Call
+}
+
+// Listen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - handle func(string)
+func (_e *MockDriver_Expecter) Listen(ctx interface{}, channel interface{}, handle interface{}) *MockDriver_Listen_Call {
+	return &MockDriver_Listen_Call{Call: _e.mock.On("Listen", ctx, channel, handle)}
+}
+
+func (_c *MockDriver_Listen_Call) Run(run func(ctx context.Context, channel string, handle func(string))) *MockDriver_Listen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(string)))
+	})
+	return _c
+}
+
+func (_c *MockDriver_Listen_Call) Return(_a0 error) *MockDriver_Listen_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDriver_Listen_Call) RunAndReturn(run func(context.Context, string, func(string)) error) *MockDriver_Listen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ping provides a mock function with given fields: ctx
 func (_m *MockDriver) Ping(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -40,4 +40,6 @@ type Driver interface {
 	ExecuteQuery(ctx context.Context, query string, args ...interface{}) error
 	QueryRow(ctx context.Context, query string, args ...interface{}) (pgx.Row, error)
 	QueryRows(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error)
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Listen(ctx context.Context, channel string, handle func(payload string)) error
 }
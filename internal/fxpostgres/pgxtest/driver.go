@@ -0,0 +1,150 @@
+package pgxtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ecumenos/ecumenos/internal/fxpostgres"
+	"github.com/jackc/pgx/v4"
+)
+
+// Query is statement executed by Driver.
+type Query struct {
+	SQL  string
+	Args []interface{}
+	// Tx is number of transaction query is executed in, 0 if query is
+	// executed outside of transaction.
+	Tx int
+}
+
+// Driver records executed statements and answers queries by handler
+// functions. Queries without handler return no rows.
+type Driver struct {
+	OnExec      func(ctx context.Context, query string, args ...interface{}) error
+	OnQueryRow  func(ctx context.Context, query string, args ...interface{}) (pgx.Row, error)
+	OnQueryRows func(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error)
+	OnCountRows func(ctx context.Context, query string, args ...interface{}) (int, error)
+
+	mu         sync.Mutex
+	queries    []Query
+	txs        int
+	rolledBack map[int]bool
+}
+
+type txKey struct{}
+
+// TxOf returns number of transaction of ctx, 0 if ctx has no transaction.
+func TxOf(ctx context.Context) int {
+	tx, _ := ctx.Value(txKey{}).(int)
+	return tx
+}
+
+func (d *Driver) record(ctx context.Context, query string, args []interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, Query{SQL: query, Args: args, Tx: TxOf(ctx)})
+}
+
+// Queries returns statements executed so far.
+func (d *Driver) Queries() []Query {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]Query(nil), d.queries...)
+}
+
+// Committed returns statements which are not rolled back.
+func (d *Driver) Committed() []Query {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var out []Query
+	for _, q := range d.queries {
+		if !d.rolledBack[q.Tx] {
+			out = append(out, q)
+		}
+	}
+
+	return out
+}
+
+// RolledBack returns number of rolled back transactions.
+func (d *Driver) RolledBack() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.rolledBack)
+}
+
+func (d *Driver) Ping(context.Context) error {
+	return nil
+}
+
+func (d *Driver) Close() {}
+
+func (d *Driver) ExecuteQuery(ctx context.Context, query string, args ...interface{}) error {
+	d.record(ctx, query, args)
+	if d.OnExec == nil {
+		return nil
+	}
+
+	return d.OnExec(ctx, query, args...)
+}
+
+func (d *Driver) QueryRow(ctx context.Context, query string, args ...interface{}) (pgx.Row, error) {
+	d.record(ctx, query, args)
+	if d.OnQueryRow == nil {
+		return NoRows(), nil
+	}
+
+	return d.OnQueryRow(ctx, query, args...)
+}
+
+func (d *Driver) QueryRows(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	d.record(ctx, query, args)
+	if d.OnQueryRows == nil {
+		return Rows(), nil
+	}
+
+	return d.OnQueryRows(ctx, query, args...)
+}
+
+func (d *Driver) CountRows(ctx context.Context, query string, args ...interface{}) (int, error) {
+	d.record(ctx, query, args)
+	if d.OnCountRows == nil {
+		return 0, nil
+	}
+
+	return d.OnCountRows(ctx, query, args...)
+}
+
+// InTx calls fn with context of new transaction, nested call joins
+// transaction of outer call. Transaction is rolled back if fn fails.
+func (d *Driver) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if TxOf(ctx) != 0 {
+		return fn(ctx)
+	}
+	d.mu.Lock()
+	d.txs++
+	tx := d.txs
+	d.mu.Unlock()
+
+	err := fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		d.mu.Lock()
+		if d.rolledBack == nil {
+			d.rolledBack = map[int]bool{}
+		}
+		d.rolledBack[tx] = true
+		d.mu.Unlock()
+	}
+
+	return err
+}
+
+// Listen blocks until ctx is done.
+func (d *Driver) Listen(ctx context.Context, _ string, _ func(payload string)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+var _ fxpostgres.Driver = (*Driver)(nil)
@@ -0,0 +1,111 @@
+// Package pgxtest provides in-memory rows, which are returned by mocked
+// driver in tests of repositories.
+package pgxtest
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+)
+
+// Row returns row with values. Values are scanned into destinations of the
+// same type or of sql.Scanner type.
+func Row(values ...interface{}) pgx.Row {
+	return &rows{values: [][]interface{}{values}, pos: 0}
+}
+
+// NoRows returns row which scan fails with pgx.ErrNoRows.
+func NoRows() pgx.Row {
+	return &rows{err: pgx.ErrNoRows, pos: 0}
+}
+
+// Rows returns rows, every row is slice of values.
+func Rows(values ...[]interface{}) pgx.Rows {
+	return &rows{values: values, pos: -1}
+}
+
+type rows struct {
+	values [][]interface{}
+	pos    int
+	err    error
+}
+
+func (r *rows) Close() {}
+
+func (r *rows) Err() error {
+	return nil
+}
+
+func (r *rows) CommandTag() pgconn.CommandTag {
+	return nil
+}
+
+func (r *rows) FieldDescriptions() []pgproto3.FieldDescription {
+	return nil
+}
+
+func (r *rows) Next() bool {
+	r.pos++
+	return r.pos < len(r.values)
+}
+
+func (r *rows) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.pos < 0 || r.pos >= len(r.values) {
+		return pgx.ErrNoRows
+	}
+	values := r.values[r.pos]
+	if len(values) != len(dest) {
+		return fmt.Errorf("number of values (%d) and destinations (%d) differ", len(values), len(dest))
+	}
+	for i, v := range values {
+		if err := assign(dest[i], v); err != nil {
+			return fmt.Errorf("can not scan value %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *rows) Values() ([]interface{}, error) {
+	if r.pos < 0 || r.pos >= len(r.values) {
+		return nil, pgx.ErrNoRows
+	}
+
+	return r.values[r.pos], nil
+}
+
+func (r *rows) RawValues() [][]byte {
+	return nil
+}
+
+func assign(dest, v interface{}) error {
+	if s, ok := dest.(sql.Scanner); ok {
+		return s.Scan(v)
+	}
+	d := reflect.ValueOf(dest)
+	if d.Kind() != reflect.Pointer || d.IsNil() {
+		return fmt.Errorf("destination must be non-nil pointer, got %T", dest)
+	}
+	if v == nil {
+		d.Elem().Set(reflect.Zero(d.Elem().Type()))
+		return nil
+	}
+	value := reflect.ValueOf(v)
+	switch {
+	case value.Type().AssignableTo(d.Elem().Type()):
+		d.Elem().Set(value)
+	case value.Type().ConvertibleTo(d.Elem().Type()):
+		d.Elem().Set(value.Convert(d.Elem().Type()))
+	default:
+		return fmt.Errorf("can not assign %T to %T", v, dest)
+	}
+
+	return nil
+}
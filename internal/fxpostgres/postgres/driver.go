@@ -2,11 +2,18 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/ecumenos/ecumenos/internal/fxpostgres/postgres")
+
 type Driver struct {
 	pool *pgxpool.Pool
 }
@@ -41,18 +48,72 @@ func (c *Driver) acquireConn(ctx context.Context) (*pgxpool.Conn, error) {
 	return conn, nil
 }
 
-func (c *Driver) QueryRow(ctx context.Context, query string, args ...interface{}) (pgx.Row, error) {
-	conn, err := c.acquireConn(ctx)
+type txKey struct{}
+
+// InTx calls fn in transaction. Queries of driver with context passed to fn
+// are executed in this transaction, which is committed if fn returns nil and
+// rolled back otherwise. Nested call joins transaction of outer call.
+func (c *Driver) InTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	ctx, span := startSpan(ctx, "InTx", "begin")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := c.pool.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx) //nolint:errcheck
+		}
+	}()
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
 	}
 
-	defer conn.Release()
+	return tx.Commit(ctx)
+}
 
-	return conn.QueryRow(ctx, query, args...), nil
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// Listen calls handle for every notification of channel until ctx is done or
+// connection fails. Listening connection is taken out of pool.
+func (c *Driver) Listen(ctx context.Context, channel string, handle func(payload string)) error {
+	pooled, err := c.acquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "listen "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(n.Payload)
+	}
 }
 
-func (c *Driver) QueryRows(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+// QueryRow returns row which must be scanned, in transaction connection is
+// busy until row is read.
+func (c *Driver) QueryRow(ctx context.Context, query string, args ...interface{}) (_ pgx.Row, err error) {
+	ctx, span := startSpan(ctx, "QueryRow", query)
+	defer func() { endSpan(span, err) }()
+
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.QueryRow(ctx, query, args...), nil
+	}
+
 	conn, err := c.acquireConn(ctx)
 	if err != nil {
 		return nil, err
@@ -60,7 +121,21 @@ func (c *Driver) QueryRows(ctx context.Context, query string, args ...interface{
 
 	defer conn.Release()
 
-	rows, err := conn.Query(ctx, query, args...)
+	return conn.QueryRow(ctx, query, args...), nil
+}
+
+// QueryRows keeps connection acquired until rows are closed, so caller must
+// always close them.
+func (c *Driver) QueryRows(ctx context.Context, query string, args ...interface{}) (_ pgx.Rows, err error) {
+	ctx, span := startSpan(ctx, "QueryRows", query)
+	defer func() { endSpan(span, err) }()
+
+	var rows pgx.Rows
+	if tx, ok := txFromContext(ctx); ok {
+		rows, err = tx.Query(ctx, query, args...)
+	} else {
+		rows, err = c.pool.Query(ctx, query, args...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +143,19 @@ func (c *Driver) QueryRows(ctx context.Context, query string, args ...interface{
 	return rows, nil
 }
 
-func (c *Driver) CountRows(ctx context.Context, query string, args ...interface{}) (int, error) {
+func (c *Driver) CountRows(ctx context.Context, query string, args ...interface{}) (_ int, err error) {
+	ctx, span := startSpan(ctx, "CountRows", query)
+	defer func() { endSpan(span, err) }()
+
 	var count int
 
+	if tx, ok := txFromContext(ctx); ok {
+		if err = tx.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+			return 0, err
+		}
+		return count, nil
+	}
+
 	conn, err := c.acquireConn(ctx)
 	if err != nil {
 		return count, err
@@ -85,7 +170,15 @@ func (c *Driver) CountRows(ctx context.Context, query string, args ...interface{
 	return count, nil
 }
 
-func (c *Driver) ExecuteQuery(ctx context.Context, query string, args ...interface{}) error {
+func (c *Driver) ExecuteQuery(ctx context.Context, query string, args ...interface{}) (err error) {
+	ctx, span := startSpan(ctx, "ExecuteQuery", query)
+	defer func() { endSpan(span, err) }()
+
+	if tx, ok := txFromContext(ctx); ok {
+		_, err = tx.Exec(ctx, query, args...)
+		return err
+	}
+
 	conn, err := c.acquireConn(ctx)
 	if err != nil {
 		return err
@@ -97,3 +190,21 @@ func (c *Driver) ExecuteQuery(ctx context.Context, query string, args ...interfa
 	}
 	return nil
 }
+
+func (c *Driver) Stat() *pgxpool.Stat {
+	return c.pool.Stat()
+}
+
+func startSpan(ctx context.Context, op, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "postgres."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBStatement(query)))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
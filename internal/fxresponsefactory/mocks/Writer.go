@@ -57,6 +57,68 @@ func (_c *MockWriter_SetLogger_Call) RunAndReturn(run func(*zap.Logger)) *MockWr
 	return _c
 }
 
+// WriteAPIError provides a mock function with given fields: ctx, err, opts
+func (_m *MockWriter) WriteAPIError(ctx context.Context, err error, opts ...fxresponsefactory.ResponseBuildOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, err)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteAPIError")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, error, ...fxresponsefactory.ResponseBuildOption) error); ok {
+		r0 = rf(ctx, err, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWriter_WriteAPIError_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteAPIError'
+type MockWriter_WriteAPIError_Call struct {
+	*mock.Call
+}
+
+// WriteAPIError is a helper method to define mock.On call
+//   - ctx context.Context
+//   - err error
+//   - opts ...fxresponsefactory.ResponseBuildOption
+func (_e *MockWriter_Expecter) WriteAPIError(ctx interface{}, err interface{}, opts ...interface{}) *MockWriter_WriteAPIError_Call {
+	return &MockWriter_WriteAPIError_Call{Call: _e.mock.On("WriteAPIError",
+		append([]interface{}{ctx, err}, opts...)...)}
+}
+
+func (_c *MockWriter_WriteAPIError_Call) Run(run func(ctx context.Context, err error, opts ...fxresponsefactory.ResponseBuildOption)) *MockWriter_WriteAPIError_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]fxresponsefactory.ResponseBuildOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(fxresponsefactory.ResponseBuildOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(error), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockWriter_WriteAPIError_Call) Return(_a0 error) *MockWriter_WriteAPIError_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWriter_WriteAPIError_Call) RunAndReturn(run func(context.Context, error, ...fxresponsefactory.ResponseBuildOption) error) *MockWriter_WriteAPIError_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WriteError provides a mock function with given fields: ctx, msg, cause, opts
 func (_m *MockWriter) WriteError(ctx context.Context, msg string, cause error, opts ...fxresponsefactory.ResponseBuildOption) error {
 	_va := make([]interface{}, len(opts))
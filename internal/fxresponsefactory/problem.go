@@ -0,0 +1,73 @@
+package fxresponsefactory
+
+import (
+	"context"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:ecumenos:problem:"
+)
+
+// ProblemResp is RFC 7807 problem details object. It is written instead of
+// JSend fail and error responses if client prefers application/problem+json.
+type ProblemResp struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Code      apierrors.Code         `json:"code"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Errors    []apierrors.FieldError `json:"errors,omitempty"`
+}
+
+func (w *writer) writeProblem(ctx context.Context, headers map[string]string, rb *responseBuilder, detail string) error {
+	headers["Content-Type"] = problemContentType
+	w.writeHeaders(headers, rb.httpStatusCode)
+
+	return w.write(&ProblemResp{
+		Type:      problemTypePrefix + string(rb.code),
+		Title:     rb.code.Title(),
+		Status:    rb.httpStatusCode,
+		Detail:    detail,
+		Code:      rb.code,
+		RequestID: contextutils.GetRequestID(ctx),
+		Errors:    rb.fields,
+	})
+}
+
+// acceptsProblem returns true if Accept header prefers problem details over
+// plain JSON. Wildcards don't select problem details, so clients which don't
+// know about them keep getting JSend responses.
+func acceptsProblem(accept string) bool {
+	if accept == "" {
+		return false
+	}
+	problemQ, jsonQ := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		switch mediaType {
+		case problemContentType:
+			problemQ = max(problemQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+
+	return problemQ > 0 && problemQ >= jsonQ
+}
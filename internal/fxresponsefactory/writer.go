@@ -7,10 +7,13 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
 	"github.com/ecumenos/ecumenos/internal/fxtypes"
 	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
 	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
 	"github.com/ecumenos/ecumenos/internal/toolkit/timeutils"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -19,6 +22,9 @@ type Writer interface {
 	WriteSuccess(ctx context.Context, payload interface{}, opts ...ResponseBuildOption) error
 	WriteFail(ctx context.Context, data interface{}, opts ...ResponseBuildOption) error
 	WriteError(ctx context.Context, msg string, cause error, opts ...ResponseBuildOption) error
+	// WriteAPIError writes fail or error response depending on error code of
+	// err. Errors without code are written as internal errors.
+	WriteAPIError(ctx context.Context, err error, opts ...ResponseBuildOption) error
 }
 
 type writer struct {
@@ -51,7 +57,6 @@ func (w *writer) write(payload interface{}) error {
 }
 
 func (w *writer) writeHeaders(headers map[string]string, statusCode int) {
-	w.rw.Header().Set("Content-Type", "application/json")
 	for key, value := range headers {
 		w.rw.Header().Set(key, value)
 	}
@@ -92,7 +97,7 @@ func (w *writer) WriteSuccess(ctx context.Context, payload interface{}, opts ...
 		return fmt.Errorf("success response must have status code in range 200..299 (status code = %v)", rb.httpStatusCode)
 	}
 	if w.writeLogs {
-		w.l.Info("responding success response", zap.Any("data", payload), zap.Int("status_code", rb.httpStatusCode))
+		fxlogger.FromContext(ctx, w.l).Info("responding success response", zap.Any("data", fxlogger.Redact(payload)), zap.Int("status_code", rb.httpStatusCode))
 	}
 
 	w.writeHeaders(headers, rb.httpStatusCode)
@@ -103,9 +108,11 @@ func (w *writer) WriteSuccess(ctx context.Context, payload interface{}, opts ...
 }
 
 type FailureResp[T interface{}] struct {
-	Status  Status `json:"status"`
-	Data    T      `json:"data"`
-	Message string `json:"message"`
+	Status  Status                 `json:"status"`
+	Code    apierrors.Code         `json:"code"`
+	Data    T                      `json:"data"`
+	Message string                 `json:"message"`
+	Errors  []apierrors.FieldError `json:"errors,omitempty"`
 }
 
 func (w *writer) WriteFail(ctx context.Context, data interface{}, opts ...ResponseBuildOption) error {
@@ -124,22 +131,35 @@ func (w *writer) WriteFail(ctx context.Context, data interface{}, opts ...Respon
 	if rb.httpStatusCode < http.StatusBadRequest || rb.httpStatusCode > 499 {
 		return fmt.Errorf("fail response must have status code in range 400..499 (status code = %v)", rb.httpStatusCode)
 	}
+	if rb.code == "" {
+		rb.code = apierrors.CodeForStatus(rb.httpStatusCode)
+	}
 	if w.writeLogs {
-		w.l.Info("responding fail response", zap.Any("data", rb.data), zap.Error(rb.cause),
-			zap.Int("status_code", rb.httpStatusCode), zap.String("msg", rb.message))
+		fxlogger.FromContext(ctx, w.l).Info("responding fail response", zap.Any("data", fxlogger.Redact(rb.data)), zap.Error(rb.cause),
+			zap.Int("status_code", rb.httpStatusCode), zap.String("msg", rb.message), zap.String("code", string(rb.code)))
 	}
 
-	w.writeHeaders(headers, http.StatusBadRequest)
+	if acceptsProblem(contextutils.GetAccept(ctx)) {
+		detail := rb.message
+		if msg, ok := rb.data.(string); ok && detail == "" {
+			detail = msg
+		}
+		return w.writeProblem(ctx, headers, rb, detail)
+	}
+	w.writeHeaders(headers, rb.httpStatusCode)
 	return w.write(&FailureResp[interface{}]{
 		Data:    rb.data,
 		Message: rb.message,
 		Status:  FailureStatus,
+		Code:    rb.code,
+		Errors:  rb.fields,
 	})
 }
 
 type ErrorResp struct {
-	Status  Status `json:"status"`
-	Message string `json:"message"`
+	Status  Status         `json:"status"`
+	Code    apierrors.Code `json:"code"`
+	Message string         `json:"message"`
 }
 
 func (w *writer) WriteError(ctx context.Context, msg string, cause error, opts ...ResponseBuildOption) error {
@@ -159,20 +179,53 @@ func (w *writer) WriteError(ctx context.Context, msg string, cause error, opts .
 	if rb.httpStatusCode < http.StatusInternalServerError || rb.httpStatusCode > 599 {
 		return fmt.Errorf("error response must have status code in range 500..599 (status code = %v)", rb.httpStatusCode)
 	}
+	if rb.code == "" {
+		rb.code = apierrors.CodeForStatus(rb.httpStatusCode)
+	}
+	if rb.cause != nil {
+		trace.SpanFromContext(ctx).RecordError(rb.cause)
+	}
 	if w.writeLogs {
-		w.l.Info("responding error response", zap.Error(rb.cause), zap.String("msg", rb.message), zap.Int("status_code", rb.httpStatusCode))
+		fxlogger.FromContext(ctx, w.l).Info("responding error response", zap.Error(rb.cause), zap.String("msg", rb.message),
+			zap.Int("status_code", rb.httpStatusCode), zap.String("code", string(rb.code)))
 	}
 
+	if acceptsProblem(contextutils.GetAccept(ctx)) {
+		return w.writeProblem(ctx, headers, rb, rb.message)
+	}
 	w.writeHeaders(headers, rb.httpStatusCode)
 	return w.write(&ErrorResp{
 		Message: rb.message,
 		Status:  ErrorStatus,
+		Code:    rb.code,
 	})
 }
 
+func (w *writer) WriteAPIError(ctx context.Context, err error, opts ...ResponseBuildOption) error {
+	apiErr, ok := apierrors.As(err)
+	if !ok {
+		return w.WriteError(ctx, "something went wrong", err, opts...)
+	}
+
+	status := apiErr.HTTPStatusCode()
+	base := []ResponseBuildOption{
+		WithHTTPStatusCode(status),
+		WithCode(apiErr.Code),
+		WithCause(err),
+	}
+	if status >= http.StatusInternalServerError {
+		return w.WriteError(ctx, apiErr.Message, err, append(base, opts...)...)
+	}
+	base = append(base, WithMessage(apiErr.Message), WithFieldErrors(apiErr.Fields...))
+
+	return w.WriteFail(ctx, nil, append(base, opts...)...)
+}
+
 type responseBuilder struct {
 	httpStatusCode int
 	message        string
+	code           apierrors.Code
+	fields         []apierrors.FieldError
 	cause          error
 	data           interface{}
 	l              *zap.Logger
@@ -198,6 +251,20 @@ func WithCause(err error) ResponseBuildOption {
 	}
 }
 
+// WithCode sets machine-readable error code of fail and error responses. If
+// it is not set, generic code of HTTP status code is used.
+func WithCode(code apierrors.Code) ResponseBuildOption {
+	return func(b *responseBuilder) {
+		b.code = code
+	}
+}
+
+func WithFieldErrors(fields ...apierrors.FieldError) ResponseBuildOption {
+	return func(b *responseBuilder) {
+		b.fields = fields
+	}
+}
+
 func WithData(data interface{}) ResponseBuildOption {
 	return func(b *responseBuilder) {
 		b.data = data
@@ -0,0 +1,72 @@
+package fxresponsefactory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestContext(accept string) context.Context {
+	ctx := contextutils.SetStartRequestTimestamp(context.Background(), time.Now())
+	ctx = contextutils.SetRequestID(ctx, "req-1")
+	return contextutils.SetAccept(ctx, accept)
+}
+
+func TestWriteFailKeepsStatusCode(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := NewWriter(zap.NewNop(), rec, "v0.0.1", false)
+
+	require.NoError(t, w.WriteFail(newTestContext(""), nil, WithHTTPStatusCode(http.StatusUnauthorized)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var resp FailureResp[interface{}]
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, apierrors.Unauthorized, resp.Code)
+}
+
+func TestWriteAPIError(t *testing.T) {
+	err := apierrors.Validation(apierrors.Field("email", apierrors.FieldInvalid, "email is invalid"))
+
+	rec := httptest.NewRecorder()
+	w := NewWriter(zap.NewNop(), rec, "v0.0.1", false)
+	require.NoError(t, w.WriteAPIError(newTestContext("application/problem+json"), err))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+	var problem ProblemResp
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, apierrors.ValidationFailed, problem.Code)
+	assert.Equal(t, "req-1", problem.RequestID)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "email", problem.Errors[0].Field)
+
+	rec = httptest.NewRecorder()
+	w = NewWriter(zap.NewNop(), rec, "v0.0.1", false)
+	require.NoError(t, w.WriteAPIError(newTestContext(""), errors.New("boom")))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
+
+func TestAcceptsProblem(t *testing.T) {
+	for accept, expected := range map[string]bool{
+		"":                         false,
+		"*/*":                      false,
+		"application/json":         false,
+		"application/problem+json": true,
+		"application/json, application/problem+json":       true,
+		"application/json, application/problem+json;q=0.5": false,
+		"application/problem+json;q=0":                     false,
+	} {
+		assert.Equal(t, expected, acceptsProblem(accept), accept)
+	}
+}
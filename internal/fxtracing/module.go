@@ -0,0 +1,127 @@
+package fxtracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+)
+
+const (
+	// NoneExporter doesn't record spans. Trace context is still propagated,
+	// so services behind keep the trace of their caller.
+	NoneExporter = "none"
+	// StdoutExporter writes finished spans to stdout as JSON.
+	StdoutExporter = "stdout"
+	// FileExporter appends finished spans to file as JSON.
+	FileExporter = "file"
+)
+
+type Config struct {
+	Exporter string
+	FilePath string
+	// SampleRatio is share of new traces which are recorded. Incoming
+	// traces follow sampling decision of caller.
+	SampleRatio float64
+}
+
+var Module = fx.Options(
+	fx.Provide(NewTracerProvider),
+	fx.Invoke(func(tp trace.TracerProvider) {
+		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	}),
+)
+
+type providerParams struct {
+	fx.In
+
+	Lifecycle   fx.Lifecycle
+	Config      *Config `optional:"true"`
+	ServiceName fxtypes.ServiceName
+	Version     fxtypes.Version
+}
+
+func NewTracerProvider(params providerParams) (trace.TracerProvider, error) {
+	cfg := params.Config
+	if cfg == nil || cfg.Exporter == "" || cfg.Exporter == NoneExporter {
+		return noop.NewTracerProvider(), nil
+	}
+
+	exporter, closer, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(string(params.ServiceName)),
+		semconv.ServiceVersion(string(params.Version)),
+	))
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	params.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			if err := tp.Shutdown(ctx); err != nil {
+				return err
+			}
+			if closer != nil {
+				return closer.Close()
+			}
+			return nil
+		},
+	})
+
+	return tp, nil
+}
+
+func newExporter(cfg *Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case StdoutExporter:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, nil, err
+	case FileExporter:
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can not open tracing file (path = %v): %w", cfg.FilePath, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+		return exporter, f, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown tracing exporter (exporter = %v)", cfg.Exporter)
+	}
+}
+
+// ValidateExporter is used by service configurations.
+func ValidateExporter(exporter, filePath string) error {
+	switch exporter {
+	case NoneExporter, StdoutExporter:
+		return nil
+	case FileExporter:
+		if filePath == "" {
+			return fmt.Errorf("tracing_file is required for %q tracing exporter", FileExporter)
+		}
+		return nil
+	default:
+		return fmt.Errorf("tracing_exporter must be %q, %q or %q (value = %v)", NoneExporter, StdoutExporter, FileExporter, exporter)
+	}
+}
@@ -3,3 +3,11 @@ package fxtypes
 type ServiceName string
 
 type Version string
+
+// ProtocolVersion is version of protocol spoken between zookeeper, orbes
+// socii and PDS instances. It is increased on breaking changes of the
+// protocol, unlike Version which is version of service build.
+type ProtocolVersion int
+
+// CurrentProtocolVersion is protocol version spoken by this build.
+const CurrentProtocolVersion ProtocolVersion = 1
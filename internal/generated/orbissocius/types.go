@@ -29,8 +29,14 @@ const (
 	SuccessResponseStatusSuccess SuccessResponseStatus = "success"
 )
 
+// ErrorCode Stable machine-readable error code, e.g. auth.invalid_credentials.
+type ErrorCode = string
+
 // ErrorResponseBody defines model for ErrorResponseBody.
 type ErrorResponseBody struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code *ErrorCode `json:"code,omitempty"`
+
 	// Message A meaningful, end-user-readable message, explaining what went wrong.
 	Message string              `json:"message"`
 	Status  ErrorResponseStatus `json:"status"`
@@ -44,13 +50,24 @@ type FailResponseStatus string
 
 // FailureResponseBody defines model for FailureResponseBody.
 type FailureResponseBody struct {
-	Data *map[string]interface{} `json:"data"`
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code   *ErrorCode              `json:"code,omitempty"`
+	Data   *map[string]interface{} `json:"data"`
+	Errors *[]FieldError           `json:"errors,omitempty"`
 
 	// Message A meaningful, end-user-readable message, explaining what went wrong.
 	Message *string            `json:"message,omitempty"`
 	Status  FailResponseStatus `json:"status"`
 }
 
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code    ErrorCode `json:"code"`
+	Field   string    `json:"field"`
+	Message string    `json:"message"`
+}
+
 // GetHealthData defines model for GetHealthData.
 type GetHealthData struct {
 	// Ok The OK is true if all is okay.
@@ -64,6 +81,9 @@ type GetInfoData struct {
 	// Name The Name is the service name.
 	Name string `json:"name"`
 
+	// ProtocolVersion The ProtocolVersion is version of federation protocol spoken by the service.
+	ProtocolVersion int `json:"protocol_version"`
+
 	// Version The Version is the service semver version.
 	Version string `json:"version"`
 }
@@ -80,6 +100,18 @@ type JSendResponseObject struct {
 	Status SuccessResponseStatus   `json:"status"`
 }
 
+// ProblemDetails RFC 7807 problem details. It is returned instead of fail and error responses if request has "Accept application/problem+json" header.
+type ProblemDetails struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code      ErrorCode     `json:"code"`
+	Detail    *string       `json:"detail,omitempty"`
+	Errors    *[]FieldError `json:"errors,omitempty"`
+	RequestId *string       `json:"request_id,omitempty"`
+	Status    int           `json:"status"`
+	Title     string        `json:"title"`
+	Type      string        `json:"type"`
+}
+
 // RequestDuration defines model for RequestDuration.
 type RequestDuration = int64
 
@@ -31,10 +31,10 @@ type ServerInterface interface {
 	SignUp(w http.ResponseWriter, r *http.Request)
 	// Get Countries
 	// (GET /countries)
-	GetCountries(w http.ResponseWriter, r *http.Request)
+	GetCountries(w http.ResponseWriter, r *http.Request, params GetCountriesParams)
 	// Get Country Regions
 	// (GET /countries/{countryCode}/regions)
-	GetCountryRegions(w http.ResponseWriter, r *http.Request, countryCode string)
+	GetCountryRegions(w http.ResponseWriter, r *http.Request, countryCode string, params GetCountryRegionsParams)
 	// Returns HTML docs.
 	// (GET /docs)
 	GetDocs(w http.ResponseWriter, r *http.Request)
@@ -46,10 +46,16 @@ type ServerInterface interface {
 	GetInfo(w http.ResponseWriter, r *http.Request)
 	// Get Languages
 	// (GET /languages)
-	GetLanguages(w http.ResponseWriter, r *http.Request)
+	GetLanguages(w http.ResponseWriter, r *http.Request, params GetLanguagesParams)
 	// Activation Orbis Socius
 	// (POST /orbes_socii/activate)
 	ActivateOrbisSocius(w http.ResponseWriter, r *http.Request)
+	// Orbis Socius Heartbeat
+	// (POST /orbes_socii/heartbeat)
+	OrbisSociusHeartbeat(w http.ResponseWriter, r *http.Request, params OrbisSociusHeartbeatParams)
+	// Register Orbis Socius
+	// (POST /orbes_socii/register)
+	RegisterOrbisSocius(w http.ResponseWriter, r *http.Request)
 	// Request Orbis Socius
 	// (POST /orbes_socii/request)
 	RequestOrbisSocius(w http.ResponseWriter, r *http.Request)
@@ -156,10 +162,36 @@ func (siw *ServerInterfaceWrapper) SignUp(w http.ResponseWriter, r *http.Request
 func (siw *ServerInterfaceWrapper) GetCountries(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCountriesParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Accept-Language", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Accept-Language", Err: err})
+			return
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCountries(w, r)
+		siw.Handler.GetCountries(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -186,8 +218,32 @@ func (siw *ServerInterfaceWrapper) GetCountryRegions(w http.ResponseWriter, r *h
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCountryRegionsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Accept-Language", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Accept-Language", Err: err})
+			return
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCountryRegions(w, r, countryCode)
+		siw.Handler.GetCountryRegions(w, r, countryCode, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -252,10 +308,36 @@ func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Reques
 func (siw *ServerInterfaceWrapper) GetLanguages(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLanguagesParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Accept-Language", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Accept-Language", Err: err})
+			return
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLanguages(w, r)
+		siw.Handler.GetLanguages(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -282,6 +364,91 @@ func (siw *ServerInterfaceWrapper) ActivateOrbisSocius(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// OrbisSociusHeartbeat operation middleware
+func (siw *ServerInterfaceWrapper) OrbisSociusHeartbeat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params OrbisSociusHeartbeatParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Ecumenos-Orbis-Socius" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Ecumenos-Orbis-Socius")]; found {
+		var EcumenosOrbisSocius int64
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Ecumenos-Orbis-Socius", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Ecumenos-Orbis-Socius", runtime.ParamLocationHeader, valueList[0], &EcumenosOrbisSocius)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Ecumenos-Orbis-Socius", Err: err})
+			return
+		}
+
+		params.EcumenosOrbisSocius = EcumenosOrbisSocius
+
+	} else {
+		err = fmt.Errorf("Header parameter Ecumenos-Orbis-Socius is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Ecumenos-Orbis-Socius", Err: err})
+		return
+	}
+
+	// ------------- Required header parameter "Ecumenos-Signature" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Ecumenos-Signature")]; found {
+		var EcumenosSignature string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Ecumenos-Signature", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Ecumenos-Signature", runtime.ParamLocationHeader, valueList[0], &EcumenosSignature)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Ecumenos-Signature", Err: err})
+			return
+		}
+
+		params.EcumenosSignature = EcumenosSignature
+
+	} else {
+		err = fmt.Errorf("Header parameter Ecumenos-Signature is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Ecumenos-Signature", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.OrbisSociusHeartbeat(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RegisterOrbisSocius operation middleware
+func (siw *ServerInterfaceWrapper) RegisterOrbisSocius(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterOrbisSocius(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // RequestOrbisSocius operation middleware
 func (siw *ServerInterfaceWrapper) RequestOrbisSocius(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -453,6 +620,10 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/orbes_socii/activate", wrapper.ActivateOrbisSocius).Methods("POST")
 
+	r.HandleFunc(options.BaseURL+"/orbes_socii/heartbeat", wrapper.OrbisSociusHeartbeat).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/orbes_socii/register", wrapper.RegisterOrbisSocius).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/orbes_socii/request", wrapper.RequestOrbisSocius).Methods("POST")
 
 	r.HandleFunc(options.BaseURL+"/spec", wrapper.GetSpecs).Methods("GET")
@@ -59,13 +59,19 @@ type Comptus struct {
 }
 
 // CountriesResponseData defines model for CountriesResponseData.
-type CountriesResponseData = []string
+type CountriesResponseData = []LocalizedName
 
 // CountryRegionsResponseData defines model for CountryRegionsResponseData.
-type CountryRegionsResponseData = []string
+type CountryRegionsResponseData = []LocalizedName
+
+// ErrorCode Stable machine-readable error code, e.g. auth.invalid_credentials.
+type ErrorCode = string
 
 // ErrorResponseBody defines model for ErrorResponseBody.
 type ErrorResponseBody struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code *ErrorCode `json:"code,omitempty"`
+
 	// Message A meaningful, end-user-readable message, explaining what went wrong.
 	Message string              `json:"message"`
 	Status  ErrorResponseStatus `json:"status"`
@@ -79,13 +85,24 @@ type FailResponseStatus string
 
 // FailureResponseBody defines model for FailureResponseBody.
 type FailureResponseBody struct {
-	Data *map[string]interface{} `json:"data"`
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code   *ErrorCode              `json:"code,omitempty"`
+	Data   *map[string]interface{} `json:"data"`
+	Errors *[]FieldError           `json:"errors,omitempty"`
 
 	// Message A meaningful, end-user-readable message, explaining what went wrong.
 	Message *string            `json:"message,omitempty"`
 	Status  FailResponseStatus `json:"status"`
 }
 
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code    ErrorCode `json:"code"`
+	Field   string    `json:"field"`
+	Message string    `json:"message"`
+}
+
 // GetHealthData defines model for GetHealthData.
 type GetHealthData struct {
 	// Ok The OK is true if all is okay.
@@ -99,6 +116,9 @@ type GetInfoData struct {
 	// Name The Name is the service name.
 	Name string `json:"name"`
 
+	// ProtocolVersion The ProtocolVersion is version of federation protocol spoken by the service.
+	ProtocolVersion int `json:"protocol_version"`
+
 	// Version The Version is the service semver version.
 	Version string `json:"version"`
 }
@@ -116,11 +136,60 @@ type JSendResponseObject struct {
 }
 
 // LanguagesResponseData defines model for LanguagesResponseData.
-type LanguagesResponseData = []string
+type LanguagesResponseData = []LocalizedName
+
+// LocalizedName defines model for LocalizedName.
+type LocalizedName struct {
+	Code string `json:"code"`
+
+	// Name name in the preferred language.
+	Name string `json:"name"`
+
+	// NativeName name in its own language. For regions it is display name configured by administrators.
+	NativeName string `json:"native_name"`
+}
+
+// OrbisSociusHeartbeatRequest defines model for OrbisSociusHeartbeatRequest.
+type OrbisSociusHeartbeatRequest struct {
+	// Capacity maximal number of members, 0 means unlimited.
+	Capacity int64 `json:"capacity"`
+	Members  int64 `json:"members"`
+
+	// ProtocolVersion protocol version spoken by orbis socius. Instances which don't report it are considered outdated.
+	ProtocolVersion *int `json:"protocol_version,omitempty"`
+
+	// Version software version of orbis socius.
+	Version string `json:"version"`
+}
+
+// OrbisSociusRegistration defines model for OrbisSociusRegistration.
+type OrbisSociusRegistration struct {
+	// HeartbeatInterval interval between heartbeats in seconds.
+	HeartbeatInterval int   `json:"heartbeat_interval"`
+	Id                int64 `json:"id"`
+
+	// MinProtocolVersion minimal protocol version which orbis socius must speak to be compliant.
+	MinProtocolVersion int    `json:"min_protocol_version"`
+	Name               string `json:"name"`
+	Region             string `json:"region"`
+	Url                string `json:"url"`
+}
 
 // Password defines model for Password.
 type Password = string
 
+// ProblemDetails RFC 7807 problem details. It is returned instead of fail and error responses if request has "Accept application/problem+json" header.
+type ProblemDetails struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code      ErrorCode     `json:"code"`
+	Detail    *string       `json:"detail,omitempty"`
+	Errors    *[]FieldError `json:"errors,omitempty"`
+	RequestId *string       `json:"request_id,omitempty"`
+	Status    int           `json:"status"`
+	Title     string        `json:"title"`
+	Type      string        `json:"type"`
+}
+
 // RefreshSessionRequest defines model for RefreshSessionRequest.
 type RefreshSessionRequest struct {
 	RefreshToken string `json:"refresh_token"`
@@ -218,6 +287,33 @@ type NotFound = FailureResponseBody
 // Success defines model for Success.
 type Success = JSendResponseObject
 
+// GetCountriesParams defines parameters for GetCountries.
+type GetCountriesParams struct {
+	// AcceptLanguage Preferred languages of names. Falls back to comptus lingua and then to English.
+	AcceptLanguage *string `json:"Accept-Language,omitempty"`
+}
+
+// GetCountryRegionsParams defines parameters for GetCountryRegions.
+type GetCountryRegionsParams struct {
+	// AcceptLanguage Preferred languages of names. Falls back to comptus lingua and then to English.
+	AcceptLanguage *string `json:"Accept-Language,omitempty"`
+}
+
+// GetLanguagesParams defines parameters for GetLanguages.
+type GetLanguagesParams struct {
+	// AcceptLanguage Preferred languages of names. Falls back to comptus lingua and then to English.
+	AcceptLanguage *string `json:"Accept-Language,omitempty"`
+}
+
+// OrbisSociusHeartbeatParams defines parameters for OrbisSociusHeartbeat.
+type OrbisSociusHeartbeatParams struct {
+	// EcumenosOrbisSocius identifier of orbis socius.
+	EcumenosOrbisSocius int64 `json:"Ecumenos-Orbis-Socius"`
+
+	// EcumenosSignature t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<body>" by API key>.
+	EcumenosSignature string `json:"Ecumenos-Signature"`
+}
+
 // RefreshSessionJSONRequestBody defines body for RefreshSession for application/json ContentType.
 type RefreshSessionJSONRequestBody = RefreshSessionRequest
 
@@ -230,6 +326,9 @@ type SignUpJSONRequestBody = SignUpRequest
 // ActivateOrbisSociusJSONRequestBody defines body for ActivateOrbisSocius for application/json ContentType.
 type ActivateOrbisSociusJSONRequestBody = ActivateOrbisSociusRequest
 
+// OrbisSociusHeartbeatJSONRequestBody defines body for OrbisSociusHeartbeat for application/json ContentType.
+type OrbisSociusHeartbeatJSONRequestBody = OrbisSociusHeartbeatRequest
+
 // RequestOrbisSociusJSONRequestBody defines body for RequestOrbisSocius for application/json ContentType.
 type RequestOrbisSociusJSONRequestBody = RequestOrbisSociusRequest
 
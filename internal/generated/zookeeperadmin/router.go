@@ -9,10 +9,68 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/oapi-codegen/runtime"
 )
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List Admin Roles
+	// (GET /admin-roles)
+	ListAdminRoles(w http.ResponseWriter, r *http.Request)
+	// Create Admin Role
+	// (POST /admin-roles)
+	CreateAdminRole(w http.ResponseWriter, r *http.Request)
+	// List Admins
+	// (GET /admins)
+	ListAdmins(w http.ResponseWriter, r *http.Request)
+	// Create Admin
+	// (POST /admins)
+	CreateAdmin(w http.ResponseWriter, r *http.Request)
+	// Revoke Admin Role
+	// (DELETE /admins/{id}/roles/{role})
+	RevokeAdminRole(w http.ResponseWriter, r *http.Request, id int64, role string)
+	// Assign Admin Role
+	// (PUT /admins/{id}/roles/{role})
+	AssignAdminRole(w http.ResponseWriter, r *http.Request, id int64, role string)
+	// Get App Settings Status
+	// (GET /app-settings)
+	GetAppSettingsStatus(w http.ResponseWriter, r *http.Request)
+	// Reload App Settings
+	// (POST /app-settings/reload)
+	ReloadAppSettings(w http.ResponseWriter, r *http.Request)
+	// List Compti
+	// (GET /compti)
+	ListCompti(w http.ResponseWriter, r *http.Request, params ListComptiParams)
+	// Delete Comptus
+	// (DELETE /compti/{id})
+	DeleteComptus(w http.ResponseWriter, r *http.Request, id int64)
+	// Get Comptus
+	// (GET /compti/{id})
+	GetComptus(w http.ResponseWriter, r *http.Request, id int64)
+	// Suspend Comptus
+	// (POST /compti/{id}/suspend)
+	SuspendComptus(w http.ResponseWriter, r *http.Request, id int64)
+	// Unsuspend Comptus
+	// (POST /compti/{id}/unsuspend)
+	UnsuspendComptus(w http.ResponseWriter, r *http.Request, id int64)
+	// List Countries
+	// (GET /countries)
+	ListCountries(w http.ResponseWriter, r *http.Request)
+	// Create Country
+	// (POST /countries)
+	CreateCountry(w http.ResponseWriter, r *http.Request)
+	// Delete Country
+	// (DELETE /countries/{code})
+	DeleteCountry(w http.ResponseWriter, r *http.Request, code string)
+	// Update Country
+	// (PUT /countries/{code})
+	UpdateCountry(w http.ResponseWriter, r *http.Request, code string)
+	// Disable Country
+	// (POST /countries/{code}/disable)
+	DisableCountry(w http.ResponseWriter, r *http.Request, code string)
+	// Enable Country
+	// (POST /countries/{code}/enable)
+	EnableCountry(w http.ResponseWriter, r *http.Request, code string)
 	// Returns HTML docs.
 	// (GET /docs)
 	GetDocs(w http.ResponseWriter, r *http.Request)
@@ -22,9 +80,87 @@ type ServerInterface interface {
 	// Service Info
 	// (GET /info)
 	GetInfo(w http.ResponseWriter, r *http.Request)
+	// List Languages
+	// (GET /languages)
+	ListLanguages(w http.ResponseWriter, r *http.Request)
+	// Create Language
+	// (POST /languages)
+	CreateLanguage(w http.ResponseWriter, r *http.Request)
+	// Delete Language
+	// (DELETE /languages/{code})
+	DeleteLanguage(w http.ResponseWriter, r *http.Request, code string)
+	// Disable Language
+	// (POST /languages/{code}/disable)
+	DisableLanguage(w http.ResponseWriter, r *http.Request, code string)
+	// Enable Language
+	// (POST /languages/{code}/enable)
+	EnableLanguage(w http.ResponseWriter, r *http.Request, code string)
+	// List Orbes Socii
+	// (GET /orbes-socii)
+	ListOrbesSocii(w http.ResponseWriter, r *http.Request, params ListOrbesSociiParams)
+	// List Orbis Socius Launch Requests
+	// (GET /orbes-socii/launch-requests)
+	ListOrbisSociusLaunchRequests(w http.ResponseWriter, r *http.Request, params ListOrbisSociusLaunchRequestsParams)
+	// Get Orbis Socius Launch Request
+	// (GET /orbes-socii/launch-requests/{id})
+	GetOrbisSociusLaunchRequest(w http.ResponseWriter, r *http.Request, id int64)
+	// Approve Orbis Socius Launch Request
+	// (POST /orbes-socii/launch-requests/{id}/approve)
+	ApproveOrbisSociusLaunchRequest(w http.ResponseWriter, r *http.Request, id int64)
+	// Reject Orbis Socius Launch Request
+	// (POST /orbes-socii/launch-requests/{id}/reject)
+	RejectOrbisSociusLaunchRequest(w http.ResponseWriter, r *http.Request, id int64)
+	// Get Orbis Socius Min Protocol Version
+	// (GET /orbes-socii/min-protocol-version)
+	GetOrbisSociusMinProtocolVersion(w http.ResponseWriter, r *http.Request)
+	// Set Orbis Socius Min Protocol Version
+	// (PUT /orbes-socii/min-protocol-version)
+	SetOrbisSociusMinProtocolVersion(w http.ResponseWriter, r *http.Request)
+	// Redeliver Webhook
+	// (POST /orbes-socii/webhook-deliveries/{id}/redeliver)
+	RedeliverWebhook(w http.ResponseWriter, r *http.Request, id int64)
+	// Delist Orbis Socius
+	// (POST /orbes-socii/{id}/delist)
+	DelistOrbisSocius(w http.ResponseWriter, r *http.Request, id int64)
+	// Get Orbis Socius Health
+	// (GET /orbes-socii/{id}/health)
+	GetOrbisSociusHealth(w http.ResponseWriter, r *http.Request, id int64)
+	// Rotate Orbis Socius API Key
+	// (POST /orbes-socii/{id}/rotate-api-key)
+	RotateOrbisSociusAPIKey(w http.ResponseWriter, r *http.Request, id int64)
+	// Delete Orbis Socius Webhook
+	// (DELETE /orbes-socii/{id}/webhook)
+	DeleteOrbisSociusWebhook(w http.ResponseWriter, r *http.Request, id int64)
+	// Set Orbis Socius Webhook
+	// (PUT /orbes-socii/{id}/webhook)
+	SetOrbisSociusWebhook(w http.ResponseWriter, r *http.Request, id int64)
+	// List Webhook Deliveries
+	// (GET /orbes-socii/{id}/webhook-deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, id int64, params ListWebhookDeliveriesParams)
 	// Refresh Session
 	// (POST /refresh-session)
 	RefreshSession(w http.ResponseWriter, r *http.Request)
+	// List Regions
+	// (GET /regions)
+	ListRegions(w http.ResponseWriter, r *http.Request)
+	// Create Region
+	// (POST /regions)
+	CreateRegion(w http.ResponseWriter, r *http.Request)
+	// Delete Region
+	// (DELETE /regions/{code})
+	DeleteRegion(w http.ResponseWriter, r *http.Request, code string)
+	// Get Region
+	// (GET /regions/{code})
+	GetRegion(w http.ResponseWriter, r *http.Request, code string)
+	// Update Region
+	// (PUT /regions/{code})
+	UpdateRegion(w http.ResponseWriter, r *http.Request, code string)
+	// Disable Region
+	// (POST /regions/{code}/disable)
+	DisableRegion(w http.ResponseWriter, r *http.Request, code string)
+	// Enable Region
+	// (POST /regions/{code}/enable)
+	EnableRegion(w http.ResponseWriter, r *http.Request, code string)
 	// Sign In
 	// (POST /sign-in)
 	SignIn(w http.ResponseWriter, r *http.Request)
@@ -45,6 +181,486 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ListAdminRoles operation middleware
+func (siw *ServerInterfaceWrapper) ListAdminRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAdminRoles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateAdminRole operation middleware
+func (siw *ServerInterfaceWrapper) CreateAdminRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAdminRole(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListAdmins operation middleware
+func (siw *ServerInterfaceWrapper) ListAdmins(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAdmins(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateAdmin operation middleware
+func (siw *ServerInterfaceWrapper) CreateAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAdmin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RevokeAdminRole operation middleware
+func (siw *ServerInterfaceWrapper) RevokeAdminRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameter("simple", false, "role", mux.Vars(r)["role"], &role)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeAdminRole(w, r, id, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AssignAdminRole operation middleware
+func (siw *ServerInterfaceWrapper) AssignAdminRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameter("simple", false, "role", mux.Vars(r)["role"], &role)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AssignAdminRole(w, r, id, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAppSettingsStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetAppSettingsStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAppSettingsStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReloadAppSettings operation middleware
+func (siw *ServerInterfaceWrapper) ReloadAppSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadAppSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCompti operation middleware
+func (siw *ServerInterfaceWrapper) ListCompti(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListComptiParams
+
+	// ------------- Optional query parameter "email" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "email", r.URL.Query(), &params.Email)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCompti(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteComptus operation middleware
+func (siw *ServerInterfaceWrapper) DeleteComptus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteComptus(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetComptus operation middleware
+func (siw *ServerInterfaceWrapper) GetComptus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetComptus(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SuspendComptus operation middleware
+func (siw *ServerInterfaceWrapper) SuspendComptus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SuspendComptus(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UnsuspendComptus operation middleware
+func (siw *ServerInterfaceWrapper) UnsuspendComptus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnsuspendComptus(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCountries operation middleware
+func (siw *ServerInterfaceWrapper) ListCountries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCountries(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateCountry operation middleware
+func (siw *ServerInterfaceWrapper) CreateCountry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCountry(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteCountry operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCountry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCountry(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateCountry operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCountry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCountry(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DisableCountry operation middleware
+func (siw *ServerInterfaceWrapper) DisableCountry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisableCountry(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// EnableCountry operation middleware
+func (siw *ServerInterfaceWrapper) EnableCountry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnableCountry(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetDocs operation middleware
 func (siw *ServerInterfaceWrapper) GetDocs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -52,7 +668,393 @@ func (siw *ServerInterfaceWrapper) GetDocs(w http.ResponseWriter, r *http.Reques
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDocs(w, r)
+		siw.Handler.GetDocs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListLanguages operation middleware
+func (siw *ServerInterfaceWrapper) ListLanguages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLanguages(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateLanguage operation middleware
+func (siw *ServerInterfaceWrapper) CreateLanguage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateLanguage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteLanguage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLanguage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteLanguage(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DisableLanguage operation middleware
+func (siw *ServerInterfaceWrapper) DisableLanguage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisableLanguage(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// EnableLanguage operation middleware
+func (siw *ServerInterfaceWrapper) EnableLanguage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnableLanguage(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListOrbesSocii operation middleware
+func (siw *ServerInterfaceWrapper) ListOrbesSocii(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOrbesSociiParams
+
+	// ------------- Optional query parameter "region" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "region", r.URL.Query(), &params.Region)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "region", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrbesSocii(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListOrbisSociusLaunchRequests operation middleware
+func (siw *ServerInterfaceWrapper) ListOrbisSociusLaunchRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOrbisSociusLaunchRequestsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOrbisSociusLaunchRequests(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetOrbisSociusLaunchRequest operation middleware
+func (siw *ServerInterfaceWrapper) GetOrbisSociusLaunchRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrbisSociusLaunchRequest(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ApproveOrbisSociusLaunchRequest operation middleware
+func (siw *ServerInterfaceWrapper) ApproveOrbisSociusLaunchRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveOrbisSociusLaunchRequest(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RejectOrbisSociusLaunchRequest operation middleware
+func (siw *ServerInterfaceWrapper) RejectOrbisSociusLaunchRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectOrbisSociusLaunchRequest(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetOrbisSociusMinProtocolVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetOrbisSociusMinProtocolVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOrbisSociusMinProtocolVersion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetOrbisSociusMinProtocolVersion operation middleware
+func (siw *ServerInterfaceWrapper) SetOrbisSociusMinProtocolVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetOrbisSociusMinProtocolVersion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RedeliverWebhook operation middleware
+func (siw *ServerInterfaceWrapper) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RedeliverWebhook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DelistOrbisSocius operation middleware
+func (siw *ServerInterfaceWrapper) DelistOrbisSocius(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DelistOrbisSocius(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -62,14 +1064,25 @@ func (siw *ServerInterfaceWrapper) GetDocs(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+// GetOrbisSociusHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetOrbisSociusHealth(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
+		siw.Handler.GetOrbisSociusHealth(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -79,14 +1092,120 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetInfo operation middleware
-func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
+// RotateOrbisSociusAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) RotateOrbisSociusAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateOrbisSociusAPIKey(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteOrbisSociusWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOrbisSociusWebhook(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetInfo(w, r)
+		siw.Handler.DeleteOrbisSociusWebhook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetOrbisSociusWebhook operation middleware
+func (siw *ServerInterfaceWrapper) SetOrbisSociusWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetOrbisSociusWebhook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveries(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -113,6 +1232,180 @@ func (siw *ServerInterfaceWrapper) RefreshSession(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ListRegions operation middleware
+func (siw *ServerInterfaceWrapper) ListRegions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRegions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateRegion operation middleware
+func (siw *ServerInterfaceWrapper) CreateRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRegion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRegion operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRegion(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRegion operation middleware
+func (siw *ServerInterfaceWrapper) GetRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRegion(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateRegion operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateRegion(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DisableRegion operation middleware
+func (siw *ServerInterfaceWrapper) DisableRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisableRegion(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// EnableRegion operation middleware
+func (siw *ServerInterfaceWrapper) EnableRegion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnableRegion(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // SignIn operation middleware
 func (siw *ServerInterfaceWrapper) SignIn(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -277,14 +1570,104 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.HandleFunc(options.BaseURL+"/admin-roles", wrapper.ListAdminRoles).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/admin-roles", wrapper.CreateAdminRole).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/admins", wrapper.ListAdmins).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/admins", wrapper.CreateAdmin).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/admins/{id}/roles/{role}", wrapper.RevokeAdminRole).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/admins/{id}/roles/{role}", wrapper.AssignAdminRole).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/app-settings", wrapper.GetAppSettingsStatus).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/app-settings/reload", wrapper.ReloadAppSettings).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/compti", wrapper.ListCompti).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/compti/{id}", wrapper.DeleteComptus).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/compti/{id}", wrapper.GetComptus).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/compti/{id}/suspend", wrapper.SuspendComptus).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/compti/{id}/unsuspend", wrapper.UnsuspendComptus).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/countries", wrapper.ListCountries).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/countries", wrapper.CreateCountry).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/countries/{code}", wrapper.DeleteCountry).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/countries/{code}", wrapper.UpdateCountry).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/countries/{code}/disable", wrapper.DisableCountry).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/countries/{code}/enable", wrapper.EnableCountry).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/docs", wrapper.GetDocs).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/health", wrapper.GetHealth).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/info", wrapper.GetInfo).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/languages", wrapper.ListLanguages).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/languages", wrapper.CreateLanguage).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/languages/{code}", wrapper.DeleteLanguage).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/languages/{code}/disable", wrapper.DisableLanguage).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/languages/{code}/enable", wrapper.EnableLanguage).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii", wrapper.ListOrbesSocii).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/launch-requests", wrapper.ListOrbisSociusLaunchRequests).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/launch-requests/{id}", wrapper.GetOrbisSociusLaunchRequest).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/launch-requests/{id}/approve", wrapper.ApproveOrbisSociusLaunchRequest).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/launch-requests/{id}/reject", wrapper.RejectOrbisSociusLaunchRequest).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/min-protocol-version", wrapper.GetOrbisSociusMinProtocolVersion).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/min-protocol-version", wrapper.SetOrbisSociusMinProtocolVersion).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/webhook-deliveries/{id}/redeliver", wrapper.RedeliverWebhook).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/{id}/delist", wrapper.DelistOrbisSocius).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/{id}/health", wrapper.GetOrbisSociusHealth).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/{id}/rotate-api-key", wrapper.RotateOrbisSociusAPIKey).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/{id}/webhook", wrapper.DeleteOrbisSociusWebhook).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/{id}/webhook", wrapper.SetOrbisSociusWebhook).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/orbes-socii/{id}/webhook-deliveries", wrapper.ListWebhookDeliveries).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/refresh-session", wrapper.RefreshSession).Methods("POST")
 
+	r.HandleFunc(options.BaseURL+"/regions", wrapper.ListRegions).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/regions", wrapper.CreateRegion).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/regions/{code}", wrapper.DeleteRegion).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/regions/{code}", wrapper.GetRegion).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/regions/{code}", wrapper.UpdateRegion).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/regions/{code}/disable", wrapper.DisableRegion).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/regions/{code}/enable", wrapper.EnableRegion).Methods("POST")
+
 	r.HandleFunc(options.BaseURL+"/sign-in", wrapper.SignIn).Methods("POST")
 
 	r.HandleFunc(options.BaseURL+"/sign-out", wrapper.SignOut).Methods("DELETE")
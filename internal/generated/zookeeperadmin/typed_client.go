@@ -0,0 +1,341 @@
+// Code generated by typedgen. DO NOT EDIT.
+
+package zookeeperadmin
+
+import (
+	"context"
+	"io"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+)
+
+// TypedClient wraps Client. Its methods return data of success response or
+// *apiclient.Error.
+type TypedClient struct {
+	*Client
+}
+
+// NewTypedClient creates TypedClient, with reasonable defaults.
+func NewTypedClient(server string, opts ...ClientOption) (*TypedClient, error) {
+	c, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedClient{Client: c}, nil
+}
+
+// ApproveOrbisSociusLaunchRequest calls ApproveOrbisSociusLaunchRequest of Client and returns data of response.
+func (c *TypedClient) ApproveOrbisSociusLaunchRequest(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (ApproveOrbisSociusLaunchRequestResponseData, error) {
+	return apiclient.Decode[ApproveOrbisSociusLaunchRequestResponseData](c.Client.ApproveOrbisSociusLaunchRequest(ctx, id, reqEditors...))
+}
+
+// AssignAdminRole calls AssignAdminRole of Client and returns data of response.
+func (c *TypedClient) AssignAdminRole(ctx context.Context, id int64, role string, reqEditors ...RequestEditorFn) (Admin, error) {
+	return apiclient.Decode[Admin](c.Client.AssignAdminRole(ctx, id, role, reqEditors...))
+}
+
+// CreateAdmin calls CreateAdmin of Client and returns data of response.
+func (c *TypedClient) CreateAdmin(ctx context.Context, body CreateAdminJSONRequestBody, reqEditors ...RequestEditorFn) (Admin, error) {
+	return apiclient.Decode[Admin](c.Client.CreateAdmin(ctx, body, reqEditors...))
+}
+
+// CreateAdminRole calls CreateAdminRole of Client and returns data of response.
+func (c *TypedClient) CreateAdminRole(ctx context.Context, body CreateAdminRoleJSONRequestBody, reqEditors ...RequestEditorFn) (AdminRole, error) {
+	return apiclient.Decode[AdminRole](c.Client.CreateAdminRole(ctx, body, reqEditors...))
+}
+
+// CreateAdminRoleWithBody calls CreateAdminRoleWithBody of Client and returns data of response.
+func (c *TypedClient) CreateAdminRoleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (AdminRole, error) {
+	return apiclient.Decode[AdminRole](c.Client.CreateAdminRoleWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// CreateAdminWithBody calls CreateAdminWithBody of Client and returns data of response.
+func (c *TypedClient) CreateAdminWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (Admin, error) {
+	return apiclient.Decode[Admin](c.Client.CreateAdminWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// CreateCountry calls CreateCountry of Client and returns data of response.
+func (c *TypedClient) CreateCountry(ctx context.Context, body CreateCountryJSONRequestBody, reqEditors ...RequestEditorFn) (Country, error) {
+	return apiclient.Decode[Country](c.Client.CreateCountry(ctx, body, reqEditors...))
+}
+
+// CreateCountryWithBody calls CreateCountryWithBody of Client and returns data of response.
+func (c *TypedClient) CreateCountryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (Country, error) {
+	return apiclient.Decode[Country](c.Client.CreateCountryWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// CreateLanguage calls CreateLanguage of Client and returns data of response.
+func (c *TypedClient) CreateLanguage(ctx context.Context, body CreateLanguageJSONRequestBody, reqEditors ...RequestEditorFn) (Language, error) {
+	return apiclient.Decode[Language](c.Client.CreateLanguage(ctx, body, reqEditors...))
+}
+
+// CreateLanguageWithBody calls CreateLanguageWithBody of Client and returns data of response.
+func (c *TypedClient) CreateLanguageWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (Language, error) {
+	return apiclient.Decode[Language](c.Client.CreateLanguageWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// CreateRegion calls CreateRegion of Client and returns data of response.
+func (c *TypedClient) CreateRegion(ctx context.Context, body CreateRegionJSONRequestBody, reqEditors ...RequestEditorFn) (Region, error) {
+	return apiclient.Decode[Region](c.Client.CreateRegion(ctx, body, reqEditors...))
+}
+
+// CreateRegionWithBody calls CreateRegionWithBody of Client and returns data of response.
+func (c *TypedClient) CreateRegionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (Region, error) {
+	return apiclient.Decode[Region](c.Client.CreateRegionWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// DeleteComptus calls DeleteComptus of Client and checks response.
+func (c *TypedClient) DeleteComptus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.DeleteComptus(ctx, id, reqEditors...))
+}
+
+// DeleteCountry calls DeleteCountry of Client and checks response.
+func (c *TypedClient) DeleteCountry(ctx context.Context, code string, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.DeleteCountry(ctx, code, reqEditors...))
+}
+
+// DeleteLanguage calls DeleteLanguage of Client and checks response.
+func (c *TypedClient) DeleteLanguage(ctx context.Context, code string, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.DeleteLanguage(ctx, code, reqEditors...))
+}
+
+// DeleteOrbisSociusWebhook calls DeleteOrbisSociusWebhook of Client and checks response.
+func (c *TypedClient) DeleteOrbisSociusWebhook(ctx context.Context, id int64, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.DeleteOrbisSociusWebhook(ctx, id, reqEditors...))
+}
+
+// DeleteRegion calls DeleteRegion of Client and checks response.
+func (c *TypedClient) DeleteRegion(ctx context.Context, code string, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.DeleteRegion(ctx, code, reqEditors...))
+}
+
+// DelistOrbisSocius calls DelistOrbisSocius of Client and returns data of response.
+func (c *TypedClient) DelistOrbisSocius(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (OrbisSocius, error) {
+	return apiclient.Decode[OrbisSocius](c.Client.DelistOrbisSocius(ctx, id, reqEditors...))
+}
+
+// DisableCountry calls DisableCountry of Client and returns data of response.
+func (c *TypedClient) DisableCountry(ctx context.Context, code string, reqEditors ...RequestEditorFn) (Country, error) {
+	return apiclient.Decode[Country](c.Client.DisableCountry(ctx, code, reqEditors...))
+}
+
+// DisableLanguage calls DisableLanguage of Client and returns data of response.
+func (c *TypedClient) DisableLanguage(ctx context.Context, code string, reqEditors ...RequestEditorFn) (Language, error) {
+	return apiclient.Decode[Language](c.Client.DisableLanguage(ctx, code, reqEditors...))
+}
+
+// DisableRegion calls DisableRegion of Client and returns data of response.
+func (c *TypedClient) DisableRegion(ctx context.Context, code string, reqEditors ...RequestEditorFn) (DisableRegionResponseData, error) {
+	return apiclient.Decode[DisableRegionResponseData](c.Client.DisableRegion(ctx, code, reqEditors...))
+}
+
+// EnableCountry calls EnableCountry of Client and returns data of response.
+func (c *TypedClient) EnableCountry(ctx context.Context, code string, reqEditors ...RequestEditorFn) (Country, error) {
+	return apiclient.Decode[Country](c.Client.EnableCountry(ctx, code, reqEditors...))
+}
+
+// EnableLanguage calls EnableLanguage of Client and returns data of response.
+func (c *TypedClient) EnableLanguage(ctx context.Context, code string, reqEditors ...RequestEditorFn) (Language, error) {
+	return apiclient.Decode[Language](c.Client.EnableLanguage(ctx, code, reqEditors...))
+}
+
+// EnableRegion calls EnableRegion of Client and returns data of response.
+func (c *TypedClient) EnableRegion(ctx context.Context, code string, reqEditors ...RequestEditorFn) (Region, error) {
+	return apiclient.Decode[Region](c.Client.EnableRegion(ctx, code, reqEditors...))
+}
+
+// GetAppSettingsStatus calls GetAppSettingsStatus of Client and returns data of response.
+func (c *TypedClient) GetAppSettingsStatus(ctx context.Context, reqEditors ...RequestEditorFn) (AppSettingsStatus, error) {
+	return apiclient.Decode[AppSettingsStatus](c.Client.GetAppSettingsStatus(ctx, reqEditors...))
+}
+
+// GetComptus calls GetComptus of Client and returns data of response.
+func (c *TypedClient) GetComptus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (Comptus, error) {
+	return apiclient.Decode[Comptus](c.Client.GetComptus(ctx, id, reqEditors...))
+}
+
+// GetDocs calls GetDocs of Client and checks response.
+func (c *TypedClient) GetDocs(ctx context.Context, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.GetDocs(ctx, reqEditors...))
+}
+
+// GetHealth calls GetHealth of Client and returns data of response.
+func (c *TypedClient) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (GetHealthData, error) {
+	return apiclient.Decode[GetHealthData](c.Client.GetHealth(ctx, reqEditors...))
+}
+
+// GetInfo calls GetInfo of Client and returns data of response.
+func (c *TypedClient) GetInfo(ctx context.Context, reqEditors ...RequestEditorFn) (GetInfoData, error) {
+	return apiclient.Decode[GetInfoData](c.Client.GetInfo(ctx, reqEditors...))
+}
+
+// GetOrbisSociusHealth calls GetOrbisSociusHealth of Client and returns data of response.
+func (c *TypedClient) GetOrbisSociusHealth(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (OrbisSociusHealth, error) {
+	return apiclient.Decode[OrbisSociusHealth](c.Client.GetOrbisSociusHealth(ctx, id, reqEditors...))
+}
+
+// GetOrbisSociusLaunchRequest calls GetOrbisSociusLaunchRequest of Client and returns data of response.
+func (c *TypedClient) GetOrbisSociusLaunchRequest(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (OrbisSociusLaunchRequest, error) {
+	return apiclient.Decode[OrbisSociusLaunchRequest](c.Client.GetOrbisSociusLaunchRequest(ctx, id, reqEditors...))
+}
+
+// GetOrbisSociusMinProtocolVersion calls GetOrbisSociusMinProtocolVersion of Client and returns data of response.
+func (c *TypedClient) GetOrbisSociusMinProtocolVersion(ctx context.Context, reqEditors ...RequestEditorFn) (OrbisSociusMinProtocolVersion, error) {
+	return apiclient.Decode[OrbisSociusMinProtocolVersion](c.Client.GetOrbisSociusMinProtocolVersion(ctx, reqEditors...))
+}
+
+// GetRegion calls GetRegion of Client and returns data of response.
+func (c *TypedClient) GetRegion(ctx context.Context, code string, reqEditors ...RequestEditorFn) (Region, error) {
+	return apiclient.Decode[Region](c.Client.GetRegion(ctx, code, reqEditors...))
+}
+
+// GetSpecs calls GetSpecs of Client and checks response.
+func (c *TypedClient) GetSpecs(ctx context.Context, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.GetSpecs(ctx, reqEditors...))
+}
+
+// ListAdminRoles calls ListAdminRoles of Client and returns data of response.
+func (c *TypedClient) ListAdminRoles(ctx context.Context, reqEditors ...RequestEditorFn) ([]AdminRole, error) {
+	return apiclient.Decode[[]AdminRole](c.Client.ListAdminRoles(ctx, reqEditors...))
+}
+
+// ListAdmins calls ListAdmins of Client and returns data of response.
+func (c *TypedClient) ListAdmins(ctx context.Context, reqEditors ...RequestEditorFn) ([]Admin, error) {
+	return apiclient.Decode[[]Admin](c.Client.ListAdmins(ctx, reqEditors...))
+}
+
+// ListCompti calls ListCompti of Client and returns data of response.
+func (c *TypedClient) ListCompti(ctx context.Context, params *ListComptiParams, reqEditors ...RequestEditorFn) ([]Comptus, error) {
+	return apiclient.Decode[[]Comptus](c.Client.ListCompti(ctx, params, reqEditors...))
+}
+
+// ListCountries calls ListCountries of Client and returns data of response.
+func (c *TypedClient) ListCountries(ctx context.Context, reqEditors ...RequestEditorFn) ([]Country, error) {
+	return apiclient.Decode[[]Country](c.Client.ListCountries(ctx, reqEditors...))
+}
+
+// ListLanguages calls ListLanguages of Client and returns data of response.
+func (c *TypedClient) ListLanguages(ctx context.Context, reqEditors ...RequestEditorFn) ([]Language, error) {
+	return apiclient.Decode[[]Language](c.Client.ListLanguages(ctx, reqEditors...))
+}
+
+// ListOrbesSocii calls ListOrbesSocii of Client and returns data of response.
+func (c *TypedClient) ListOrbesSocii(ctx context.Context, params *ListOrbesSociiParams, reqEditors ...RequestEditorFn) ([]OrbisSocius, error) {
+	return apiclient.Decode[[]OrbisSocius](c.Client.ListOrbesSocii(ctx, params, reqEditors...))
+}
+
+// ListOrbisSociusLaunchRequests calls ListOrbisSociusLaunchRequests of Client and returns data of response.
+func (c *TypedClient) ListOrbisSociusLaunchRequests(ctx context.Context, params *ListOrbisSociusLaunchRequestsParams, reqEditors ...RequestEditorFn) ([]OrbisSociusLaunchRequest, error) {
+	return apiclient.Decode[[]OrbisSociusLaunchRequest](c.Client.ListOrbisSociusLaunchRequests(ctx, params, reqEditors...))
+}
+
+// ListRegions calls ListRegions of Client and returns data of response.
+func (c *TypedClient) ListRegions(ctx context.Context, reqEditors ...RequestEditorFn) ([]Region, error) {
+	return apiclient.Decode[[]Region](c.Client.ListRegions(ctx, reqEditors...))
+}
+
+// ListWebhookDeliveries calls ListWebhookDeliveries of Client and returns data of response.
+func (c *TypedClient) ListWebhookDeliveries(ctx context.Context, id int64, params *ListWebhookDeliveriesParams, reqEditors ...RequestEditorFn) ([]WebhookDelivery, error) {
+	return apiclient.Decode[[]WebhookDelivery](c.Client.ListWebhookDeliveries(ctx, id, params, reqEditors...))
+}
+
+// RedeliverWebhook calls RedeliverWebhook of Client and returns data of response.
+func (c *TypedClient) RedeliverWebhook(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (WebhookDelivery, error) {
+	return apiclient.Decode[WebhookDelivery](c.Client.RedeliverWebhook(ctx, id, reqEditors...))
+}
+
+// RefreshSession calls RefreshSession of Client and returns data of response.
+func (c *TypedClient) RefreshSession(ctx context.Context, body RefreshSessionJSONRequestBody, reqEditors ...RequestEditorFn) (RefreshSessionResponseData, error) {
+	return apiclient.Decode[RefreshSessionResponseData](c.Client.RefreshSession(ctx, body, reqEditors...))
+}
+
+// RefreshSessionWithBody calls RefreshSessionWithBody of Client and returns data of response.
+func (c *TypedClient) RefreshSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (RefreshSessionResponseData, error) {
+	return apiclient.Decode[RefreshSessionResponseData](c.Client.RefreshSessionWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// RejectOrbisSociusLaunchRequest calls RejectOrbisSociusLaunchRequest of Client and returns data of response.
+func (c *TypedClient) RejectOrbisSociusLaunchRequest(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (OrbisSociusLaunchRequest, error) {
+	return apiclient.Decode[OrbisSociusLaunchRequest](c.Client.RejectOrbisSociusLaunchRequest(ctx, id, reqEditors...))
+}
+
+// ReloadAppSettings calls ReloadAppSettings of Client and returns data of response.
+func (c *TypedClient) ReloadAppSettings(ctx context.Context, reqEditors ...RequestEditorFn) (AppSettingsStatus, error) {
+	return apiclient.Decode[AppSettingsStatus](c.Client.ReloadAppSettings(ctx, reqEditors...))
+}
+
+// RevokeAdminRole calls RevokeAdminRole of Client and checks response.
+func (c *TypedClient) RevokeAdminRole(ctx context.Context, id int64, role string, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.RevokeAdminRole(ctx, id, role, reqEditors...))
+}
+
+// RotateOrbisSociusAPIKey calls RotateOrbisSociusAPIKey of Client and returns data of response.
+func (c *TypedClient) RotateOrbisSociusAPIKey(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (RotateOrbisSociusAPIKeyResponseData, error) {
+	return apiclient.Decode[RotateOrbisSociusAPIKeyResponseData](c.Client.RotateOrbisSociusAPIKey(ctx, id, reqEditors...))
+}
+
+// SetOrbisSociusMinProtocolVersion calls SetOrbisSociusMinProtocolVersion of Client and returns data of response.
+func (c *TypedClient) SetOrbisSociusMinProtocolVersion(ctx context.Context, body SetOrbisSociusMinProtocolVersionJSONRequestBody, reqEditors ...RequestEditorFn) (OrbisSociusMinProtocolVersion, error) {
+	return apiclient.Decode[OrbisSociusMinProtocolVersion](c.Client.SetOrbisSociusMinProtocolVersion(ctx, body, reqEditors...))
+}
+
+// SetOrbisSociusMinProtocolVersionWithBody calls SetOrbisSociusMinProtocolVersionWithBody of Client and returns data of response.
+func (c *TypedClient) SetOrbisSociusMinProtocolVersionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (OrbisSociusMinProtocolVersion, error) {
+	return apiclient.Decode[OrbisSociusMinProtocolVersion](c.Client.SetOrbisSociusMinProtocolVersionWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// SetOrbisSociusWebhook calls SetOrbisSociusWebhook of Client and returns data of response.
+func (c *TypedClient) SetOrbisSociusWebhook(ctx context.Context, id int64, body SetOrbisSociusWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (OrbisSociusWebhook, error) {
+	return apiclient.Decode[OrbisSociusWebhook](c.Client.SetOrbisSociusWebhook(ctx, id, body, reqEditors...))
+}
+
+// SetOrbisSociusWebhookWithBody calls SetOrbisSociusWebhookWithBody of Client and returns data of response.
+func (c *TypedClient) SetOrbisSociusWebhookWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (OrbisSociusWebhook, error) {
+	return apiclient.Decode[OrbisSociusWebhook](c.Client.SetOrbisSociusWebhookWithBody(ctx, id, contentType, body, reqEditors...))
+}
+
+// SignIn calls SignIn of Client and returns data of response.
+func (c *TypedClient) SignIn(ctx context.Context, body SignInJSONRequestBody, reqEditors ...RequestEditorFn) (SignInResponseData, error) {
+	return apiclient.Decode[SignInResponseData](c.Client.SignIn(ctx, body, reqEditors...))
+}
+
+// SignInWithBody calls SignInWithBody of Client and returns data of response.
+func (c *TypedClient) SignInWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (SignInResponseData, error) {
+	return apiclient.Decode[SignInResponseData](c.Client.SignInWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// SignOutWithBody calls SignOutWithBody of Client and checks response.
+func (c *TypedClient) SignOutWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) error {
+	return apiclient.Check(c.Client.SignOutWithBody(ctx, contentType, body, reqEditors...))
+}
+
+// SuspendComptus calls SuspendComptus of Client and returns data of response.
+func (c *TypedClient) SuspendComptus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (Comptus, error) {
+	return apiclient.Decode[Comptus](c.Client.SuspendComptus(ctx, id, reqEditors...))
+}
+
+// UnsuspendComptus calls UnsuspendComptus of Client and returns data of response.
+func (c *TypedClient) UnsuspendComptus(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (Comptus, error) {
+	return apiclient.Decode[Comptus](c.Client.UnsuspendComptus(ctx, id, reqEditors...))
+}
+
+// UpdateCountry calls UpdateCountry of Client and returns data of response.
+func (c *TypedClient) UpdateCountry(ctx context.Context, code string, body UpdateCountryJSONRequestBody, reqEditors ...RequestEditorFn) (Country, error) {
+	return apiclient.Decode[Country](c.Client.UpdateCountry(ctx, code, body, reqEditors...))
+}
+
+// UpdateCountryWithBody calls UpdateCountryWithBody of Client and returns data of response.
+func (c *TypedClient) UpdateCountryWithBody(ctx context.Context, code string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (Country, error) {
+	return apiclient.Decode[Country](c.Client.UpdateCountryWithBody(ctx, code, contentType, body, reqEditors...))
+}
+
+// UpdateRegion calls UpdateRegion of Client and returns data of response.
+func (c *TypedClient) UpdateRegion(ctx context.Context, code string, body UpdateRegionJSONRequestBody, reqEditors ...RequestEditorFn) (Region, error) {
+	return apiclient.Decode[Region](c.Client.UpdateRegion(ctx, code, body, reqEditors...))
+}
+
+// UpdateRegionWithBody calls UpdateRegionWithBody of Client and returns data of response.
+func (c *TypedClient) UpdateRegionWithBody(ctx context.Context, code string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (Region, error) {
+	return apiclient.Decode[Region](c.Client.UpdateRegionWithBody(ctx, code, contentType, body, reqEditors...))
+}
@@ -25,13 +25,149 @@ const (
 	Fail FailResponseStatus = "fail"
 )
 
+// Defines values for OrbisSociusLaunchRequestStatus.
+const (
+	Approved OrbisSociusLaunchRequestStatus = "approved"
+	Pending  OrbisSociusLaunchRequestStatus = "pending"
+	Rejected OrbisSociusLaunchRequestStatus = "rejected"
+	Viewed   OrbisSociusLaunchRequestStatus = "viewed"
+)
+
+// Defines values for RobustnessStatus.
+const (
+	Adaptable   RobustnessStatus = "adaptable"
+	Antifragile RobustnessStatus = "antifragile"
+	Fragile     RobustnessStatus = "fragile"
+	Resilient   RobustnessStatus = "resilient"
+	Vulnerable  RobustnessStatus = "vulnerable"
+)
+
 // Defines values for SuccessResponseStatus.
 const (
 	SuccessResponseStatusSuccess SuccessResponseStatus = "success"
 )
 
+// Defines values for WebhookDeliveryStatus.
+const (
+	Delivered WebhookDeliveryStatus = "delivered"
+	Failed    WebhookDeliveryStatus = "failed"
+	Scheduled WebhookDeliveryStatus = "scheduled"
+)
+
+// Defines values for WebhookEventType.
+const (
+	OrbisSociusApiKeyRotated     WebhookEventType = "orbis_socius.api_key_rotated"
+	OrbisSociusComplianceChanged WebhookEventType = "orbis_socius.compliance_changed"
+	OrbisSociusDelisted          WebhookEventType = "orbis_socius.delisted"
+	OrbisSociusHealthChanged     WebhookEventType = "orbis_socius.health_changed"
+	WebhookPing                  WebhookEventType = "webhook.ping"
+)
+
+// Admin defines model for Admin.
+type Admin struct {
+	CreatedAt time.Time `json:"created_at"`
+	Email     string    `json:"email"`
+	Id        int64     `json:"id"`
+	Roles     []string  `json:"roles"`
+}
+
+// AdminRole defines model for AdminRole.
+type AdminRole struct {
+	CreatedAt      time.Time `json:"created_at"`
+	CreatorAdminId int64     `json:"creator_admin_id"`
+	Id             int64     `json:"id"`
+	Name           string    `json:"name"`
+}
+
+// AppSettingsStatus defines model for AppSettingsStatus.
+type AppSettingsStatus struct {
+	// LastError error of the latest rejected reload.
+	LastError   *string    `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	LoadedAt    time.Time  `json:"loaded_at"`
+
+	// Source source is storage of settings (files or postgres).
+	Source string `json:"source"`
+
+	// Version version is incremented on every successful reload.
+	Version int64 `json:"version"`
+}
+
+// ApproveOrbisSociusLaunchRequestResponseData defines model for ApproveOrbisSociusLaunchRequestResponseData.
+type ApproveOrbisSociusLaunchRequestResponseData struct {
+	Invite        OrbisSociusLaunchInvite  `json:"invite"`
+	LaunchRequest OrbisSociusLaunchRequest `json:"launch_request"`
+}
+
+// Comptus defines model for Comptus.
+type Comptus struct {
+	Country     string     `json:"country"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Email       string     `json:"email"`
+	Id          int64      `json:"id"`
+	Language    string     `json:"language"`
+	SuspendedAt *time.Time `json:"suspended_at"`
+}
+
+// Country defines model for Country.
+type Country struct {
+	// Code code is ISO 3166-1 alpha-3 country code.
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+	Enabled   bool      `json:"enabled"`
+	Regions   []string  `json:"regions"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateAdminRequest defines model for CreateAdminRequest.
+type CreateAdminRequest struct {
+	Email    openapi_types.Email `json:"email"`
+	Password Password            `json:"password"`
+}
+
+// CreateAdminRoleRequest defines model for CreateAdminRoleRequest.
+type CreateAdminRoleRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateCountryRequest defines model for CreateCountryRequest.
+type CreateCountryRequest struct {
+	Code    string   `json:"code"`
+	Enabled *bool    `json:"enabled,omitempty"`
+	Regions []string `json:"regions"`
+}
+
+// CreateLanguageRequest defines model for CreateLanguageRequest.
+type CreateLanguageRequest struct {
+	Code    string `json:"code"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+// CreateRegionRequest defines model for CreateRegionRequest.
+type CreateRegionRequest struct {
+	Code               string  `json:"code"`
+	DataResidencyNotes *string `json:"data_residency_notes,omitempty"`
+	DefaultTimezone    string  `json:"default_timezone"`
+	DisplayName        string  `json:"display_name"`
+	Enabled            *bool   `json:"enabled,omitempty"`
+}
+
+// DisableRegionResponseData defines model for DisableRegionResponseData.
+type DisableRegionResponseData struct {
+	// OrbesSocii orbes_socii which still live in the disabled region.
+	OrbesSocii []OrbisSociusReference `json:"orbes_socii"`
+	Region     Region                 `json:"region"`
+	Warnings   []string               `json:"warnings"`
+}
+
+// ErrorCode Stable machine-readable error code, e.g. auth.invalid_credentials.
+type ErrorCode = string
+
 // ErrorResponseBody defines model for ErrorResponseBody.
 type ErrorResponseBody struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code *ErrorCode `json:"code,omitempty"`
+
 	// Message A meaningful, end-user-readable message, explaining what went wrong.
 	Message string              `json:"message"`
 	Status  ErrorResponseStatus `json:"status"`
@@ -45,13 +181,24 @@ type FailResponseStatus string
 
 // FailureResponseBody defines model for FailureResponseBody.
 type FailureResponseBody struct {
-	Data *map[string]interface{} `json:"data"`
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code   *ErrorCode              `json:"code,omitempty"`
+	Data   *map[string]interface{} `json:"data"`
+	Errors *[]FieldError           `json:"errors,omitempty"`
 
 	// Message A meaningful, end-user-readable message, explaining what went wrong.
 	Message *string            `json:"message,omitempty"`
 	Status  FailResponseStatus `json:"status"`
 }
 
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code    ErrorCode `json:"code"`
+	Field   string    `json:"field"`
+	Message string    `json:"message"`
+}
+
 // GetHealthData defines model for GetHealthData.
 type GetHealthData struct {
 	// Ok The OK is true if all is okay.
@@ -65,6 +212,9 @@ type GetInfoData struct {
 	// Name The Name is the service name.
 	Name string `json:"name"`
 
+	// ProtocolVersion The ProtocolVersion is version of federation protocol spoken by the service.
+	ProtocolVersion int `json:"protocol_version"`
+
 	// Version The Version is the service semver version.
 	Version string `json:"version"`
 }
@@ -81,9 +231,132 @@ type JSendResponseObject struct {
 	Status SuccessResponseStatus   `json:"status"`
 }
 
+// Language defines model for Language.
+type Language struct {
+	// Code code is ISO 639-3 language code.
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrbisSocius defines model for OrbisSocius.
+type OrbisSocius struct {
+	Alive           bool   `json:"alive"`
+	ApproverAdminId *int64 `json:"approver_admin_id,omitempty"`
+
+	// Compliant false if orbis socius speaks protocol older than minimal supported one.
+	Compliant      bool       `json:"compliant"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Description    string     `json:"description"`
+	Id             int64      `json:"id"`
+	LastPingedAt   *time.Time `json:"last_pinged_at,omitempty"`
+	Name           string     `json:"name"`
+	OwnerComptusId int64      `json:"owner_comptus_id"`
+
+	// ProtocolVersion protocol version reported by the latest heartbeat.
+	ProtocolVersion  *int             `json:"protocol_version,omitempty"`
+	Region           string           `json:"region"`
+	RobustnessStatus RobustnessStatus `json:"robustness_status"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	Url              string           `json:"url"`
+
+	// Version software version reported by the latest heartbeat.
+	Version *string `json:"version,omitempty"`
+}
+
+// OrbisSociusHealth defines model for OrbisSociusHealth.
+type OrbisSociusHealth struct {
+	// Availability availability is share of alive pings in stats.
+	Availability float64     `json:"availability"`
+	OrbisSocius  OrbisSocius `json:"orbis_socius"`
+
+	// Stats stats are the latest pings of orbis socius, the newest first.
+	Stats []OrbisSociusStat `json:"stats"`
+}
+
+// OrbisSociusLaunchInvite defines model for OrbisSociusLaunchInvite.
+type OrbisSociusLaunchInvite struct {
+	ApiKey    string    `json:"api_key"`
+	Code      string    `json:"code"`
+	ExpiredAt time.Time `json:"expired_at"`
+	Id        int64     `json:"id"`
+}
+
+// OrbisSociusLaunchRequest defines model for OrbisSociusLaunchRequest.
+type OrbisSociusLaunchRequest struct {
+	ComptusId   int64                          `json:"comptus_id"`
+	CreatedAt   time.Time                      `json:"created_at"`
+	Description string                         `json:"description"`
+	Id          int64                          `json:"id"`
+	Name        string                         `json:"name"`
+	Region      string                         `json:"region"`
+	Status      OrbisSociusLaunchRequestStatus `json:"status"`
+	Url         string                         `json:"url"`
+}
+
+// OrbisSociusLaunchRequestStatus defines model for OrbisSociusLaunchRequestStatus.
+type OrbisSociusLaunchRequestStatus string
+
+// OrbisSociusMinProtocolVersion defines model for OrbisSociusMinProtocolVersion.
+type OrbisSociusMinProtocolVersion struct {
+	// CurrentProtocolVersion protocol version spoken by zookeeper.
+	CurrentProtocolVersion int `json:"current_protocol_version"`
+	MinProtocolVersion     int `json:"min_protocol_version"`
+
+	// NonCompliant number of non-compliant orbes socii.
+	NonCompliant int64 `json:"non_compliant"`
+}
+
+// OrbisSociusReference defines model for OrbisSociusReference.
+type OrbisSociusReference struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// OrbisSociusStat defines model for OrbisSociusStat.
+type OrbisSociusStat struct {
+	Alive bool `json:"alive"`
+
+	// Capacity maximal number of members reported by heartbeat, 0 means unlimited.
+	Capacity  *int64    `json:"capacity,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Members number of members reported by heartbeat.
+	Members *int64 `json:"members,omitempty"`
+
+	// ProtocolVersion protocol version reported by heartbeat.
+	ProtocolVersion *int `json:"protocol_version,omitempty"`
+
+	// Version software version reported by heartbeat.
+	Version *string `json:"version,omitempty"`
+}
+
+// OrbisSociusWebhook defines model for OrbisSociusWebhook.
+type OrbisSociusWebhook struct {
+	OrbisSociusId int64 `json:"orbis_socius_id"`
+
+	// Secret secret of HMAC-SHA256 signature in Ecumenos-Signature header of webhooks.
+	Secret string `json:"secret"`
+	Url    string `json:"url"`
+}
+
 // Password defines model for Password.
 type Password = string
 
+// ProblemDetails RFC 7807 problem details. It is returned instead of fail and error responses if request has "Accept application/problem+json" header.
+type ProblemDetails struct {
+	// Code Stable machine-readable error code, e.g. auth.invalid_credentials.
+	Code      ErrorCode     `json:"code"`
+	Detail    *string       `json:"detail,omitempty"`
+	Errors    *[]FieldError `json:"errors,omitempty"`
+	RequestId *string       `json:"request_id,omitempty"`
+	Status    int           `json:"status"`
+	Title     string        `json:"title"`
+	Type      string        `json:"type"`
+}
+
 // RefreshSessionRequest defines model for RefreshSessionRequest.
 type RefreshSessionRequest struct {
 	RefreshToken string `json:"refresh_token"`
@@ -96,6 +369,19 @@ type RefreshSessionResponseData struct {
 	Token        string `json:"token"`
 }
 
+// Region defines model for Region.
+type Region struct {
+	Code               string    `json:"code"`
+	CreatedAt          time.Time `json:"created_at"`
+	DataResidencyNotes string    `json:"data_residency_notes"`
+
+	// DefaultTimezone default_timezone is IANA time zone name.
+	DefaultTimezone string    `json:"default_timezone"`
+	DisplayName     string    `json:"display_name"`
+	Enabled         bool      `json:"enabled"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
 // RequestDuration defines model for RequestDuration.
 type RequestDuration = int64
 
@@ -107,9 +393,28 @@ type ResponseStatus struct {
 	union json.RawMessage
 }
 
+// RobustnessStatus defines model for RobustnessStatus.
+type RobustnessStatus string
+
+// RotateOrbisSociusAPIKeyResponseData defines model for RotateOrbisSociusAPIKeyResponseData.
+type RotateOrbisSociusAPIKeyResponseData struct {
+	ApiKey string `json:"api_key"`
+	Id     int64  `json:"id"`
+}
+
 // SemverVersion defines model for SemverVersion.
 type SemverVersion = string
 
+// SetOrbisSociusMinProtocolVersionRequest defines model for SetOrbisSociusMinProtocolVersionRequest.
+type SetOrbisSociusMinProtocolVersionRequest struct {
+	MinProtocolVersion int `json:"min_protocol_version"`
+}
+
+// SetOrbisSociusWebhookRequest defines model for SetOrbisSociusWebhookRequest.
+type SetOrbisSociusWebhookRequest struct {
+	Url string `json:"url"`
+}
+
 // SignInRequest defines model for SignInRequest.
 type SignInRequest struct {
 	Email    openapi_types.Email `json:"email"`
@@ -129,6 +434,39 @@ type SuccessResponseStatus string
 // Timestamp defines model for Timestamp.
 type Timestamp = time.Time
 
+// UpdateCountryRequest defines model for UpdateCountryRequest.
+type UpdateCountryRequest struct {
+	Regions []string `json:"regions"`
+}
+
+// UpdateRegionRequest defines model for UpdateRegionRequest.
+type UpdateRegionRequest struct {
+	DataResidencyNotes string `json:"data_residency_notes"`
+	DefaultTimezone    string `json:"default_timezone"`
+	DisplayName        string `json:"display_name"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts       int                   `json:"attempts"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	Event          WebhookEventType      `json:"event"`
+	Id             int64                 `json:"id"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	LastError      *string               `json:"last_error,omitempty"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	OrbisSociusId  int64                 `json:"orbis_socius_id"`
+	Status         WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = FailureResponseBody
 
@@ -150,9 +488,66 @@ type NotFound = FailureResponseBody
 // Success defines model for Success.
 type Success = JSendResponseObject
 
+// ListComptiParams defines parameters for ListCompti.
+type ListComptiParams struct {
+	// Email email of comptus.
+	Email *string `form:"email,omitempty" json:"email,omitempty"`
+
+	// Limit maximum number of compti in response.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset number of compti to skip.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListOrbesSociiParams defines parameters for ListOrbesSocii.
+type ListOrbesSociiParams struct {
+	// Region region code of orbes socii.
+	Region *string `form:"region,omitempty" json:"region,omitempty"`
+}
+
+// ListOrbisSociusLaunchRequestsParams defines parameters for ListOrbisSociusLaunchRequests.
+type ListOrbisSociusLaunchRequestsParams struct {
+	// Status status of launch requests.
+	Status *OrbisSociusLaunchRequestStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Status status of webhook deliveries.
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// CreateAdminRoleJSONRequestBody defines body for CreateAdminRole for application/json ContentType.
+type CreateAdminRoleJSONRequestBody = CreateAdminRoleRequest
+
+// CreateAdminJSONRequestBody defines body for CreateAdmin for application/json ContentType.
+type CreateAdminJSONRequestBody = CreateAdminRequest
+
+// CreateCountryJSONRequestBody defines body for CreateCountry for application/json ContentType.
+type CreateCountryJSONRequestBody = CreateCountryRequest
+
+// UpdateCountryJSONRequestBody defines body for UpdateCountry for application/json ContentType.
+type UpdateCountryJSONRequestBody = UpdateCountryRequest
+
+// CreateLanguageJSONRequestBody defines body for CreateLanguage for application/json ContentType.
+type CreateLanguageJSONRequestBody = CreateLanguageRequest
+
+// SetOrbisSociusMinProtocolVersionJSONRequestBody defines body for SetOrbisSociusMinProtocolVersion for application/json ContentType.
+type SetOrbisSociusMinProtocolVersionJSONRequestBody = SetOrbisSociusMinProtocolVersionRequest
+
+// SetOrbisSociusWebhookJSONRequestBody defines body for SetOrbisSociusWebhook for application/json ContentType.
+type SetOrbisSociusWebhookJSONRequestBody = SetOrbisSociusWebhookRequest
+
 // RefreshSessionJSONRequestBody defines body for RefreshSession for application/json ContentType.
 type RefreshSessionJSONRequestBody = RefreshSessionRequest
 
+// CreateRegionJSONRequestBody defines body for CreateRegion for application/json ContentType.
+type CreateRegionJSONRequestBody = CreateRegionRequest
+
+// UpdateRegionJSONRequestBody defines body for UpdateRegion for application/json ContentType.
+type UpdateRegionJSONRequestBody = UpdateRegionRequest
+
 // SignInJSONRequestBody defines body for SignIn for application/json ContentType.
 type SignInJSONRequestBody = SignInRequest
 
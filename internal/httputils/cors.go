@@ -0,0 +1,196 @@
+package httputils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	DefaultCORSAllowedMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	DefaultCORSAllowedHeaders = []string{"Accept", "Authorization", "Content-Type", "X-Request-Id", "Traceparent", "Tracestate", IdempotencyKeyHeader}
+	DefaultCORSExposedHeaders = []string{"X-Request-Id", "X-App-Version", "X-Request-Duration", "X-Timestamp", IdempotentReplayedHeader}
+)
+
+type CORSConfig struct {
+	// AllowedOrigins are exact origins (https://app.example.com), origins
+	// with wildcard subdomain (https://*.example.com) or "*". CORS is
+	// disabled if list is empty.
+	AllowedOrigins   []string
+	AllowCredentials bool
+	// MaxAge is how long browsers cache preflight responses.
+	MaxAge time.Duration
+}
+
+type originPattern struct {
+	scheme string
+	host   string
+	port   string
+	// wildcard matches any subdomain of host, but not host itself.
+	wildcard bool
+}
+
+func parseOriginPattern(v string) (originPattern, error) {
+	u, err := url.Parse(v)
+	if err != nil {
+		return originPattern{}, err
+	}
+	if u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		return originPattern{}, errors.New("origin must be scheme://host[:port]")
+	}
+	p := originPattern{scheme: strings.ToLower(u.Scheme), host: strings.ToLower(u.Hostname()), port: u.Port()}
+	if rest, ok := strings.CutPrefix(p.host, "*."); ok {
+		p.wildcard, p.host = true, rest
+	}
+	if strings.Contains(p.host, "*") {
+		return originPattern{}, errors.New("wildcard is allowed only as the first label of host")
+	}
+
+	return p, nil
+}
+
+func (p originPattern) match(o originPattern) bool {
+	if p.scheme != o.scheme || p.port != o.port {
+		return false
+	}
+	if p.wildcard {
+		return strings.HasSuffix(o.host, "."+p.host)
+	}
+
+	return p.host == o.host
+}
+
+// ValidateCORS validates allowed origins of policy, name is prefix of config
+// keys.
+func ValidateCORS(name string, origins []string, allowCredentials bool) error {
+	var errs []error
+	for _, o := range origins {
+		if o == "*" {
+			if allowCredentials {
+				errs = append(errs, fmt.Errorf("%v_allowed_origins can not contain \"*\" if credentials are allowed", name))
+			}
+			continue
+		}
+		if _, err := parseOriginPattern(o); err != nil {
+			errs = append(errs, fmt.Errorf("%v_allowed_origins contains invalid origin (origin = %v): %w", name, o, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewCORSMiddleware handles CORS and preflight requests. It must wrap the
+// whole router, because router doesn't route OPTIONS requests to handlers.
+func NewCORSMiddleware(c CORSConfig) func(next http.Handler) http.Handler {
+	var anyOrigin bool
+	patterns := make([]originPattern, 0, len(c.AllowedOrigins))
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			anyOrigin = true
+			continue
+		}
+		// invalid origins are rejected by ValidateCORS.
+		if p, err := parseOriginPattern(o); err == nil {
+			patterns = append(patterns, p)
+		}
+	}
+	allowed := func(origin string) bool {
+		if anyOrigin {
+			return true
+		}
+		o, err := parseOriginPattern(origin)
+		if err != nil || o.wildcard {
+			return false
+		}
+		for _, p := range patterns {
+			if p.match(o) {
+				return true
+			}
+		}
+
+		return false
+	}
+	allowedHeaders := make(map[string]struct{}, len(DefaultCORSAllowedHeaders))
+	for _, h := range DefaultCORSAllowedHeaders {
+		allowedHeaders[strings.ToLower(h)] = struct{}{}
+	}
+	allowedMethods := strings.Join(DefaultCORSAllowedMethods, ", ")
+	exposedHeaders := strings.Join(DefaultCORSExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(c.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		if len(c.AllowedOrigins) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(rw, r)
+				return
+			}
+			h := rw.Header()
+			h.Add("Vary", "Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if preflight {
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+			}
+			if !allowed(origin) {
+				if preflight {
+					rw.WriteHeader(http.StatusForbidden)
+					return
+				}
+				// browser blocks response without CORS headers.
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			// requested headers of preflight are checked before any CORS header
+			// is set, so rejected preflight has none of them.
+			var headers []string
+			for _, v := range r.Header.Values("Access-Control-Request-Headers") {
+				if !preflight {
+					break
+				}
+				for _, header := range strings.Split(v, ",") {
+					header = strings.TrimSpace(header)
+					if header == "" {
+						continue
+					}
+					if _, ok := allowedHeaders[strings.ToLower(header)]; !ok {
+						rw.WriteHeader(http.StatusForbidden)
+						return
+					}
+					headers = append(headers, header)
+				}
+			}
+			if anyOrigin && !c.AllowCredentials {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if c.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+			if !preflight {
+				h.Set("Access-Control-Expose-Headers", exposedHeaders)
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			h.Set("Access-Control-Allow-Methods", allowedMethods)
+			if len(headers) > 0 {
+				h.Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+			}
+			if c.MaxAge > 0 {
+				h.Set("Access-Control-Max-Age", maxAge)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
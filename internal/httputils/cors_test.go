@@ -0,0 +1,135 @@
+package httputils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name        string
+		config      CORSConfig
+		method      string
+		headers     map[string]string
+		code        int
+		allowOrigin string
+		expose      bool
+	}{
+		{
+			name:   "no origin",
+			config: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			method: http.MethodGet,
+			code:   http.StatusOK,
+		},
+		{
+			name:        "exact origin",
+			config:      CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://app.example.com"},
+			code:        http.StatusOK,
+			allowOrigin: "https://app.example.com",
+			expose:      true,
+		},
+		{
+			name:        "wildcard subdomain",
+			config:      CORSConfig{AllowedOrigins: []string{"https://*.example.com"}},
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://a.b.example.com"},
+			code:        http.StatusOK,
+			allowOrigin: "https://a.b.example.com",
+			expose:      true,
+		},
+		{
+			name:    "wildcard doesn't match apex and other ports",
+			config:  CORSConfig{AllowedOrigins: []string{"https://*.example.com"}},
+			method:  http.MethodGet,
+			headers: map[string]string{"Origin": "https://example.com"},
+			code:    http.StatusOK,
+		},
+		{
+			name:    "disallowed origin",
+			config:  CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			method:  http.MethodGet,
+			headers: map[string]string{"Origin": "https://evil.example.org"},
+			code:    http.StatusOK,
+		},
+		{
+			name:   "preflight",
+			config: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, MaxAge: time.Minute},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "content-type, authorization",
+			},
+			code:        http.StatusNoContent,
+			allowOrigin: "https://app.example.com",
+		},
+		{
+			name:   "preflight with disallowed header",
+			config: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  http.MethodPost,
+				"Access-Control-Request-Headers": "x-secret",
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name:   "preflight of disallowed origin",
+			config: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.org",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name:        "any origin",
+			config:      CORSConfig{AllowedOrigins: []string{"*"}},
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://any.example.org"},
+			code:        http.StatusOK,
+			allowOrigin: "*",
+			expose:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/countries", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			NewCORSMiddleware(tt.config)(next).ServeHTTP(rec, r)
+
+			if rec.Code != tt.code {
+				t.Fatalf("unexpected code (expected = %v, got = %v)", tt.code, rec.Code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Fatalf("unexpected allowed origin (expected = %q, got = %q)", tt.allowOrigin, got)
+			}
+			if got := rec.Header().Get("Access-Control-Expose-Headers") != ""; got != tt.expose {
+				t.Fatalf("unexpected exposed headers (expected = %v, got = %v)", tt.expose, got)
+			}
+		})
+	}
+}
+
+func TestValidateCORS(t *testing.T) {
+	if err := ValidateCORS("app_cors", []string{"https://app.example.com", "http://localhost:3000", "https://*.example.com"}, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, origins := range [][]string{{"*"}, {"app.example.com"}, {"https://app.*.com"}, {"https://app.example.com/path"}} {
+		if err := ValidateCORS("app_cors", origins, true); err == nil {
+			t.Fatalf("expected error (origins = %v)", origins)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package httputils
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DebugPathPrefix is prefix of debug endpoints of admin servers.
+const DebugPathPrefix = "/debug/"
+
+type LogLevels struct {
+	Level   string            `json:"level"`
+	Loggers map[string]string `json:"loggers"`
+}
+
+type SetLogLevelRequest struct {
+	Level string `json:"level"`
+	// Logger is name of logger. Root level is set if it is empty.
+	Logger string `json:"logger,omitempty"`
+}
+
+// MountDebugHandlers mounts runtime log levels, pprof and expvar on router
+// behind auth middleware. CPU profile and trace must be requested for less
+// seconds than write timeout of server.
+func MountDebugHandlers(router *mux.Router, logger *zap.Logger, rf fxresponsefactory.Factory, levels *fxlogger.Levels, auth func(http.Handler) http.Handler) {
+	debug := router.PathPrefix(DebugPathPrefix).Subrouter()
+	debug.Use(mux.MiddlewareFunc(auth))
+
+	debug.HandleFunc("/log-level", func(rw http.ResponseWriter, r *http.Request) {
+		_ = rf.NewWriter(rw).WriteSuccess(r.Context(), toLogLevels(levels)) //nolint:errcheck
+	}).Methods(http.MethodGet)
+	debug.HandleFunc("/log-level", func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writer := rf.NewWriter(rw)
+		var req SetLogLevelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			_ = writer.WriteFail(ctx, nil, fxresponsefactory.WithCause(err), fxresponsefactory.WithMessage("failed to decode request")) //nolint:errcheck
+			return
+		}
+		lvl, err := zapcore.ParseLevel(req.Level)
+		if err != nil {
+			_ = writer.WriteAPIError(ctx, apierrors.Validation(apierrors.Field("level", apierrors.FieldInvalid, err.Error()))) //nolint:errcheck
+			return
+		}
+		if req.Logger == "" {
+			levels.SetRoot(lvl)
+		} else {
+			levels.SetNamed(req.Logger, lvl)
+		}
+		fxlogger.FromContext(ctx, logger).Info("log level is changed by admin", zap.String("logger", req.Logger), zap.Stringer("level", lvl))
+		_ = writer.WriteSuccess(ctx, toLogLevels(levels)) //nolint:errcheck
+	}).Methods(http.MethodPut)
+	debug.HandleFunc("/log-level/{logger}", func(rw http.ResponseWriter, r *http.Request) {
+		levels.ResetNamed(mux.Vars(r)["logger"])
+		_ = rf.NewWriter(rw).WriteSuccess(r.Context(), toLogLevels(levels)) //nolint:errcheck
+	}).Methods(http.MethodDelete)
+
+	debug.Handle("/vars", expvar.Handler()).Methods(http.MethodGet)
+	debug.HandleFunc("/pprof/cmdline", pprof.Cmdline)
+	debug.HandleFunc("/pprof/profile", pprof.Profile)
+	debug.HandleFunc("/pprof/symbol", pprof.Symbol)
+	debug.HandleFunc("/pprof/trace", pprof.Trace)
+	// Index serves named profiles too, e.g. /debug/pprof/heap.
+	debug.PathPrefix("/pprof/").HandlerFunc(pprof.Index)
+}
+
+func toLogLevels(levels *fxlogger.Levels) *LogLevels {
+	out := &LogLevels{Level: levels.Root().String(), Loggers: map[string]string{}}
+	for _, name := range levels.Named() {
+		out.Loggers[name] = levels.LevelFor(name).String()
+	}
+
+	return out
+}
@@ -0,0 +1,173 @@
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/timeutils"
+	"go.uber.org/zap"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	defaultIdempotencyLockTTL = time.Minute
+)
+
+// IdempotencyResponse is stored response of request.
+type IdempotencyResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type IdempotencyRecord struct {
+	// Fingerprint is hash of request body.
+	Fingerprint string
+	// Response is nil while original request is in progress.
+	Response *IdempotencyResponse
+}
+
+type IdempotencyStore interface {
+	// ReserveIdempotencyKey marks key as in progress and returns nil, or
+	// returns record if key is already taken. Expired keys and keys left in
+	// progress before staleBefore are taken over.
+	ReserveIdempotencyKey(ctx context.Context, scope, key, fingerprint string, expiresAt, staleBefore time.Time) (*IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, scope, key string, resp *IdempotencyResponse) error
+	// ReleaseIdempotencyKey removes key, so request can be retried.
+	ReleaseIdempotencyKey(ctx context.Context, scope, key string) error
+}
+
+type IdempotencyConfig struct {
+	Store IdempotencyStore
+	// TTL is how long response is replayed.
+	TTL time.Duration
+	// LockTTL is how long key stays in progress if original request never
+	// completes, e.g. process crashed. It must exceed request timeout.
+	LockTTL time.Duration
+}
+
+// NewIdempotencyMiddleware replays stored response of POST request which is
+// repeated with the same Idempotency-Key header. Keys are scoped by route and
+// Authorization header, so callers can't see responses of each other. Reuse
+// of key with another body is rejected. Responses with 5xx status are not
+// stored, so such requests can be retried with the same key. It must be used
+// after route matching and before handlers.
+func NewIdempotencyMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory, cfg IdempotencyConfig) func(next http.Handler) http.Handler {
+	lockTTL := cfg.LockTTL
+	if lockTTL <= 0 {
+		lockTTL = defaultIdempotencyLockTTL
+	}
+
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(rw, r)
+				return
+			}
+			ctx := r.Context()
+			writer := rf.NewWriter(rw)
+			l := fxlogger.FromContext(ctx, logger).With(zap.String("idempotency_key", key))
+			if len(key) > maxIdempotencyKeyLength {
+				_ = writer.WriteAPIError(ctx, apierrors.Newf(apierrors.IdempotencyKeyInvalid, //nolint:errcheck
+					"idempotency key must not be longer than %v characters", maxIdempotencyKeyLength))
+				return
+			}
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				_ = writer.WriteAPIError(ctx, apierrors.Wrap(apierrors.InvalidBody, err, "can not read request body")) //nolint:errcheck
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := idempotencyScope(r)
+			sum := sha256.Sum256(body)
+			fingerprint := hex.EncodeToString(sum[:])
+			now := time.Now()
+			rec, err := cfg.Store.ReserveIdempotencyKey(ctx, scope, key, fingerprint, now.Add(cfg.TTL), now.Add(-lockTTL))
+			if err != nil {
+				_ = writer.WriteError(ctx, "something went wrong", err) //nolint:errcheck
+				l.Error("can not reserve idempotency key", zap.Error(err))
+				return
+			}
+			if rec != nil {
+				switch {
+				case rec.Fingerprint != fingerprint:
+					_ = writer.WriteAPIError(ctx, apierrors.New(apierrors.IdempotencyKeyMismatch, //nolint:errcheck
+						"idempotency key is already used with another request body"))
+				case rec.Response == nil:
+					_ = writer.WriteAPIError(ctx, apierrors.New(apierrors.IdempotencyKeyInProgress, //nolint:errcheck
+						"request with the same idempotency key is in progress"))
+				default:
+					replayIdempotencyResponse(ctx, rw, rec.Response)
+				}
+				return
+			}
+
+			buf := &bufferedResponseWriter{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(buf, r)
+
+			// key is updated even if client has gone, otherwise it stays in
+			// progress until lock expires.
+			storeCtx := context.WithoutCancel(ctx)
+			if buf.status >= http.StatusInternalServerError {
+				if err := cfg.Store.ReleaseIdempotencyKey(storeCtx, scope, key); err != nil {
+					l.Error("can not release idempotency key", zap.Error(err))
+				}
+			} else {
+				header := buf.header.Clone()
+				for _, h := range perRequestHeaders {
+					header.Del(h)
+				}
+				resp := &IdempotencyResponse{StatusCode: buf.status, Header: header, Body: buf.body.Bytes()}
+				if err := cfg.Store.CompleteIdempotencyKey(storeCtx, scope, key, resp); err != nil {
+					l.Error("can not store response of idempotency key", zap.Error(err))
+				}
+			}
+			buf.flush(rw)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func idempotencyScope(r *http.Request) string {
+	scope := r.Method + " " + routeTemplate(r)
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		scope += " " + hex.EncodeToString(sum[:])
+	}
+
+	return scope
+}
+
+// perRequestHeaders are not stored, replayed response has them of replaying
+// request.
+var perRequestHeaders = []string{"X-Request-Id", "X-Timestamp", "X-Request-Duration"}
+
+func replayIdempotencyResponse(ctx context.Context, rw http.ResponseWriter, resp *IdempotencyResponse) {
+	for key, values := range resp.Header {
+		rw.Header()[key] = values
+	}
+	rw.Header().Set("X-Request-Id", contextutils.GetRequestID(ctx))
+	rw.Header().Set("X-Timestamp", timeutils.TimeToString(time.Now()))
+	if duration, err := httputils.GetRequestDuration(ctx); err == nil {
+		rw.Header().Set("X-Request-Duration", fmt.Sprint(duration.Milliseconds()))
+	}
+	rw.Header().Set(IdempotentReplayedHeader, "true")
+	rw.WriteHeader(resp.StatusCode)
+	_, _ = rw.Write(resp.Body)
+}
@@ -0,0 +1,111 @@
+package httputils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*IdempotencyRecord
+}
+
+func (s *memoryIdempotencyStore) ReserveIdempotencyKey(_ context.Context, scope, key, fingerprint string, _, _ time.Time) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[scope+key]; ok {
+		return rec, nil
+	}
+	s.records[scope+key] = &IdempotencyRecord{Fingerprint: fingerprint}
+
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) CompleteIdempotencyKey(_ context.Context, scope, key string, resp *IdempotencyResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[scope+key].Response = resp
+
+	return nil
+}
+
+func (s *memoryIdempotencyStore) ReleaseIdempotencyKey(_ context.Context, scope, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, scope+key)
+
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	logger := zap.NewNop()
+	rf := fxresponsefactory.NewFactory(logger, &fxresponsefactory.Config{}, "v0.0.1")
+	store := &memoryIdempotencyStore{records: map[string]*IdempotencyRecord{}}
+	var calls int
+	status := http.StatusCreated
+	router := mux.NewRouter()
+	router.Use(mux.MiddlewareFunc(NewEnrichContextMiddleware(logger, rf, &netutils.ClientIPExtractor{})))
+	router.Use(mux.MiddlewareFunc(NewIdempotencyMiddleware(logger, rf, IdempotencyConfig{Store: store, TTL: time.Hour})))
+	router.HandleFunc("/compti", func(rw http.ResponseWriter, r *http.Request) {
+		calls++
+		rw.Header().Set("Content-Type", "text/plain")
+		rw.WriteHeader(status)
+		_, _ = fmt.Fprintf(rw, "comptus %v", calls)
+	}).Methods(http.MethodPost)
+
+	post := func(key, body, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/compti", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, key)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	first := post("k1", `{"a":1}`, "")
+	replay := post("k1", `{"a":1}`, "")
+	if calls != 1 || replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Fatalf("response is not replayed (calls = %v, code = %v, body = %v)", calls, replay.Code, replay.Body.String())
+	}
+	if replay.Header().Get(IdempotentReplayedHeader) != "true" || replay.Header().Get("Content-Type") != "text/plain" {
+		t.Fatalf("unexpected replayed headers (headers = %v)", replay.Header())
+	}
+	if rw := post("k1", `{"a":2}`, ""); rw.Code != http.StatusUnprocessableEntity || calls != 1 {
+		t.Fatalf("key reuse with another body is not rejected (code = %v)", rw.Code)
+	}
+	if rw := post("k1", `{"a":1}`, "Bearer other"); rw.Code != http.StatusCreated || calls != 2 {
+		t.Fatalf("key is not scoped by caller (code = %v, calls = %v)", rw.Code, calls)
+	}
+
+	status = http.StatusInternalServerError
+	post("k2", `{}`, "")
+	status = http.StatusCreated
+	if rw := post("k2", `{}`, ""); rw.Code != http.StatusCreated || calls != 4 {
+		t.Fatalf("failed request can not be retried (code = %v, calls = %v)", rw.Code, calls)
+	}
+
+	sum := sha256.Sum256([]byte(`{}`))
+	store.records["POST /compti"+"k3"] = &IdempotencyRecord{Fingerprint: hex.EncodeToString(sum[:])}
+	if rw := post("k3", `{}`, ""); rw.Code != http.StatusConflict {
+		t.Fatalf("request in progress is not rejected (code = %v)", rw.Code)
+	}
+	if rw := post(strings.Repeat("k", maxIdempotencyKeyLength+1), `{}`, ""); rw.Code != http.StatusBadRequest {
+		t.Fatalf("too long key is not rejected (code = %v)", rw.Code)
+	}
+}
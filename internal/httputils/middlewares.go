@@ -2,32 +2,52 @@ package httputils
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
 	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
 	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
 	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
 	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-func NewEnrichContextMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory) func(next http.Handler) http.Handler {
+// NewEnrichContextMiddleware stores request ID, start time and client IP in
+// context. Client IP is extracted by ips, so it can't be spoofed by
+// forwarding headers of untrusted peers.
+func NewEnrichContextMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory, ips *netutils.ClientIPExtractor) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(rw http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 			writer := rf.NewWriter(rw)
 
-			ip, err := netutils.ExtractIPAddress(r)
+			ctx = contextutils.SetRequestID(ctx, httputils.ExtractRequestID(r))
+			ctx = contextutils.SetStartRequestTimestamp(ctx, time.Now())
+			ctx = contextutils.SetAccept(ctx, r.Header.Get("Accept"))
+			ip, err := ips.Extract(r)
 			if err != nil {
-				_ = writer.WriteFail(ctx, err, nil) //nolint:errcheck
+				_ = writer.WriteFail(ctx, nil, fxresponsefactory.WithCause(err), //nolint:errcheck
+					fxresponsefactory.WithMessage("can not extract IP address"))
 				logger.Error("can not extract IP address from request", zap.Error(err))
 				return
 			}
 			ctx = contextutils.SetIPAddress(ctx, ip)
-			ctx = contextutils.SetRequestID(ctx, httputils.ExtractRequestID(r))
-			ctx = contextutils.SetStartRequestTimestamp(ctx, time.Now())
 
 			next.ServeHTTP(rw, r.WithContext(ctx))
 		}
@@ -36,6 +56,134 @@ func NewEnrichContextMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory
 	}
 }
 
+// NewMetricsMiddleware records number and latency of requests by route
+// template and status code. server distinguishes app and admin listeners of
+// the same process.
+func NewMetricsMiddleware(m *fxmetrics.HTTPMetrics, server string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusResponseWriter{ResponseWriter: rw, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r)
+
+			route := routeTemplate(r)
+			status := strconv.Itoa(sw.status)
+			m.Requests.WithLabelValues(server, r.Method, route, status).Inc()
+			m.Duration.WithLabelValues(server, r.Method, route, status).Observe(time.Since(start).Seconds())
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// NewTracingMiddleware continues trace of W3C traceparent header or starts
+// new one and wraps handler into server span. It must be used after enrich
+// context middleware, so span is linked to request ID.
+func NewTracingMiddleware(server string) func(next http.Handler) http.Handler {
+	tracer := otel.Tracer("github.com/ecumenos/ecumenos/internal/httputils")
+
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			route := routeTemplate(r)
+			ctx, span := tracer.Start(ctx, r.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPMethod(r.Method),
+					semconv.HTTPRoute(route),
+					attribute.String("server", server),
+					attribute.String("request_id", contextutils.GetRequestID(r.Context())),
+				))
+			defer span.End()
+			sw := &statusResponseWriter{ResponseWriter: rw, status: http.StatusOK}
+
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPStatusCode(sw.status))
+			if sw.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(sw.status))
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func routeTemplate(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if tpl, err := current.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+
+	return "unknown"
+}
+
+type AccessLogConfig struct {
+	// SampleRatio is share of successful requests which are logged. Failed
+	// requests are always logged.
+	SampleRatio float64
+}
+
+// NewAccessLogMiddleware puts request scoped logger into context and writes
+// one line per request with "access" logger. It must be used after enrich
+// context and tracing middlewares, so scoped logger has request ID and trace
+// ID.
+func NewAccessLogMiddleware(logger *zap.Logger, cfg AccessLogConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			fields := append([]zap.Field{
+				zap.String("request_id", contextutils.GetRequestID(ctx)),
+				zap.String("ip", contextutils.GetIPAddress(ctx)),
+			}, fxtracing.LogFields(ctx)...)
+			ctx = fxlogger.WithContext(ctx, logger.With(fields...))
+			sw := &statusResponseWriter{ResponseWriter: rw, status: http.StatusOK}
+			start := time.Now()
+
+			next.ServeHTTP(sw, r.WithContext(ctx))
+
+			if sw.status < http.StatusBadRequest && rand.Float64() >= cfg.SampleRatio { //nolint:gosec
+				return
+			}
+			fxlogger.FromContext(ctx, logger).Named("access").Info("request is handled",
+				zap.String("method", r.Method),
+				zap.String("route", routeTemplate(r)),
+				zap.String("path", fxlogger.RedactQuery(r.URL)),
+				zap.Int("status", sw.status),
+				zap.Duration("duration", time.Since(start)),
+				zap.Int("bytes", sw.bytes),
+				zap.String("user_agent", r.UserAgent()),
+			)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
 func NewRecoverMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(rw http.ResponseWriter, r *http.Request) {
@@ -44,7 +192,7 @@ func NewRecoverMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory) func
 			defer func() {
 				if err := recover(); err != nil {
 					_ = rf.NewWriter(rw).WriteError(ctx, "something went wrong", fmt.Errorf("unexpected error (err=%v)", err)) //nolint:errcheck
-					logger.Error("recovering after panic", zap.Any("err", err))
+					fxlogger.FromContext(ctx, logger).Error("recovering after panic", zap.Any("err", err))
 					return
 				}
 			}()
@@ -60,6 +208,26 @@ type Authorizer interface {
 	Authorize(ctx context.Context, token string) (int64, int64, error)
 }
 
+// AuthorizerFunc adapts function to Authorizer, e.g. method of service.
+type AuthorizerFunc func(ctx context.Context, token string) (int64, int64, error)
+
+func (f AuthorizerFunc) Authorize(ctx context.Context, token string) (int64, int64, error) {
+	return f(ctx, token)
+}
+
+// NewStaticTokenAuthorizer authorizes requests by shared secret. It is used by
+// admin servers of services without admin accounts, admin and session IDs
+// are always 0.
+func NewStaticTokenAuthorizer(secret []byte) Authorizer {
+	return AuthorizerFunc(func(ctx context.Context, token string) (int64, int64, error) {
+		if len(secret) == 0 || subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+			return 0, 0, errors.New("invalid token")
+		}
+
+		return 0, 0, nil
+	})
+}
+
 func NewAdminAuthorizationMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory, auth Authorizer) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(rw http.ResponseWriter, r *http.Request) {
@@ -69,19 +237,22 @@ func NewAdminAuthorizationMiddleware(logger *zap.Logger, rf fxresponsefactory.Fa
 			token, err := httputils.ExtractJWTBearerToken(r)
 			if err != nil {
 				_ = writer.WriteFail(ctx, nil, fxresponsefactory.WithHTTPStatusCode(http.StatusUnauthorized),
-					fxresponsefactory.WithCause(err), fxresponsefactory.WithMessage("failed to get token")) //nolint:errcheck
-				logger.Error("can not extract JWT token from request", zap.Error(err))
+					fxresponsefactory.WithCause(err), fxresponsefactory.WithMessage("failed to get token"),
+					fxresponsefactory.WithCode(apierrors.AuthMissingToken)) //nolint:errcheck
+				fxlogger.FromContext(ctx, logger).Error("can not extract JWT token from request", zap.Error(err))
 				return
 			}
 			adminID, sessionID, err := auth.Authorize(ctx, token)
 			if err != nil {
 				_ = writer.WriteFail(ctx, nil, fxresponsefactory.WithHTTPStatusCode(http.StatusUnauthorized),
-					fxresponsefactory.WithCause(err), fxresponsefactory.WithMessage("failed to authorize")) //nolint:errcheck
-				logger.Error("can not authorize", zap.Error(err))
+					fxresponsefactory.WithCause(err), fxresponsefactory.WithMessage("failed to authorize"),
+					fxresponsefactory.WithCode(apierrors.AuthInvalidToken)) //nolint:errcheck
+				fxlogger.FromContext(ctx, logger).Error("can not authorize", zap.Error(err))
 				return
 			}
 			ctx = contextutils.SetAdminID(ctx, adminID)
 			ctx = contextutils.SetAdminSessionID(ctx, sessionID)
+			fxlogger.AddFields(ctx, zap.Int64("admin_id", adminID))
 
 			next.ServeHTTP(rw, r.WithContext(ctx))
 		}
@@ -0,0 +1,85 @@
+package httputils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	m, err := fxmetrics.NewHTTPMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	router := mux.NewRouter()
+	router.Use(mux.MiddlewareFunc(NewMetricsMiddleware(m, "admin")))
+	router.HandleFunc("/compti/{id}", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+
+	for _, path := range []string{"/compti/1", "/compti/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.Requests.WithLabelValues("admin", http.MethodGet, "/compti/{id}", "404")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.Duration))
+}
+
+func TestTracingMiddlewareContinuesTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	router := mux.NewRouter()
+	router.Use(mux.MiddlewareFunc(NewTracingMiddleware("app")))
+	router.HandleFunc("/compti/{id}", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}).Methods(http.MethodGet)
+
+	r := httptest.NewRequest(http.MethodGet, "/compti/1", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /compti/{id}", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Equal(t, "Error", spans[0].Status().Code.String())
+}
+
+func TestDebugLogLevelRequiresAuth(t *testing.T) {
+	logger := zap.NewNop()
+	rf := fxresponsefactory.NewFactory(logger, &fxresponsefactory.Config{}, "v0.0.1")
+	levels := fxlogger.NewLevels(zap.InfoLevel)
+	router := mux.NewRouter()
+	router.Use(mux.MiddlewareFunc(NewEnrichContextMiddleware(logger, rf, &netutils.ClientIPExtractor{})))
+	auth := NewAdminAuthorizationMiddleware(logger, rf, NewStaticTokenAuthorizer([]byte("secret")))
+	MountDebugHandlers(router, logger, rf, levels, auth)
+
+	r := httptest.NewRequest(http.MethodPut, "/debug/log-level", strings.NewReader(`{"level":"debug","logger":"access"}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	r = httptest.NewRequest(http.MethodPut, "/debug/log-level", strings.NewReader(`{"level":"debug","logger":"access"}`))
+	r.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, zap.DebugLevel, levels.LevelFor("access"))
+	assert.Equal(t, zap.InfoLevel, levels.Root())
+}
@@ -0,0 +1,296 @@
+package httputils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"go.uber.org/zap"
+)
+
+type OpenAPIValidationConfig struct {
+	// Spec is merged OpenAPI spec of the server.
+	Spec []byte
+	// ValidateResponses enables validation of responses. Responses are
+	// buffered, so it should be used in dev mode only.
+	ValidateResponses bool
+	// LogDrift enables logging of requests and responses which are not
+	// described by the spec.
+	LogDrift bool
+}
+
+var (
+	unsupportedPropertyRegex = regexp.MustCompile(`^property "(.*)" is unsupported$`)
+	defineStringFormats      sync.Once
+)
+
+// NewOpenAPIValidationMiddleware returns middleware which rejects requests
+// which don't match the spec. It must be used after enrich context middleware.
+func NewOpenAPIValidationMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory, cfg OpenAPIValidationConfig) (func(next http.Handler) http.Handler, error) {
+	router, err := newOpenAPIRouter(cfg.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				if cfg.LogDrift {
+					logger.Warn("request is not described by openapi spec",
+						zap.String("method", r.Method), zap.String("path", r.URL.Path), zap.Error(err))
+				}
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					MultiError:         true,
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+				_ = rf.NewWriter(rw).WriteAPIError(ctx, apierrors.Validation(requestFieldErrors(err)...)) //nolint:errcheck
+				return
+			}
+			if !cfg.ValidateResponses {
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			rec := &bufferedResponseWriter{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			err = openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 rec.status,
+				Header:                 rec.header,
+				Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+				Options: &openapi3filter.Options{
+					MultiError:            true,
+					IncludeResponseStatus: true,
+				},
+			})
+			if err != nil && cfg.LogDrift {
+				logger.Warn("response does not match openapi spec",
+					zap.String("method", r.Method), zap.String("route", route.Path),
+					zap.Int("status_code", rec.status), zap.Error(err))
+			}
+			rec.flush(rw)
+		}
+
+		return http.HandlerFunc(fn)
+	}, nil
+}
+
+func newOpenAPIRouter(spec []byte) (routers.Router, error) {
+	defineStringFormats.Do(func() {
+		openapi3.DefineStringFormat("email", openapi3.FormatOfStringForEmail)
+	})
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if path.Base(location.Path) == "shared-internal.yaml" {
+			return openapi.SharedSpec(), nil
+		}
+
+		return nil, fmt.Errorf("unknown openapi spec reference (location = %v)", location)
+	}
+	doc, err := loader.LoadFromDataWithPath(spec, &url.URL{Path: "spec.yaml"})
+	if err != nil {
+		return nil, fmt.Errorf("failed load openapi spec: %w", err)
+	}
+	// Server URL is template in embedded specs, routes are matched by path
+	// only.
+	doc.Servers = nil
+	dropUnsupportedPatterns(doc)
+	disallowUnknownRequestFields(doc)
+
+	return gorillamux.NewRouter(doc)
+}
+
+// disallowUnknownRequestFields makes request body objects strict unless spec
+// allows additional properties explicitly.
+func disallowUnknownRequestFields(doc *openapi3.T) {
+	visited := map[*openapi3.Schema]bool{}
+	var visit func(ref *openapi3.SchemaRef)
+	visit = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil || visited[ref.Value] {
+			return
+		}
+		s := ref.Value
+		visited[s] = true
+		// Subschemas of compositions are validated separately, each of them
+		// sees properties of the others.
+		if len(s.AllOf) > 0 || len(s.AnyOf) > 0 || len(s.OneOf) > 0 {
+			return
+		}
+		if len(s.Properties) > 0 && s.AdditionalProperties.Has == nil && s.AdditionalProperties.Schema == nil {
+			disallowed := false
+			s.AdditionalProperties.Has = &disallowed
+		}
+		for _, p := range s.Properties {
+			visit(p)
+		}
+		visit(s.Items)
+	}
+	for _, item := range doc.Paths {
+		for _, op := range item.Operations() {
+			if op.RequestBody == nil || op.RequestBody.Value == nil {
+				continue
+			}
+			for _, mt := range op.RequestBody.Value.Content {
+				visit(mt.Schema)
+			}
+		}
+	}
+}
+
+// dropUnsupportedPatterns removes patterns which Go regexp can not compile
+// (e.g. lookaheads). They are checked by services.
+func dropUnsupportedPatterns(doc *openapi3.T) {
+	visited := map[*openapi3.Schema]bool{}
+	var visit func(ref *openapi3.SchemaRef)
+	visit = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil || visited[ref.Value] {
+			return
+		}
+		s := ref.Value
+		visited[s] = true
+		if s.Pattern != "" {
+			if _, err := regexp.Compile(s.Pattern); err != nil {
+				s.Pattern = ""
+			}
+		}
+		for _, p := range s.Properties {
+			visit(p)
+		}
+		for _, refs := range []openapi3.SchemaRefs{s.AllOf, s.AnyOf, s.OneOf} {
+			for _, r := range refs {
+				visit(r)
+			}
+		}
+		visit(s.Items)
+		visit(s.AdditionalProperties.Schema)
+	}
+	for _, ref := range doc.Components.Schemas {
+		visit(ref)
+	}
+	for _, item := range doc.Paths {
+		for _, op := range item.Operations() {
+			for _, p := range op.Parameters {
+				if p.Value != nil {
+					visit(p.Value.Schema)
+				}
+			}
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				for _, mt := range op.RequestBody.Value.Content {
+					visit(mt.Schema)
+				}
+			}
+		}
+	}
+}
+
+func requestFieldErrors(err error) []apierrors.FieldError {
+	var fields []apierrors.FieldError
+	// RequestError unwraps to MultiError of schema errors, so top level
+	// MultiError is checked without unwrapping.
+	if me, ok := err.(openapi3.MultiError); ok { //nolint:errorlint
+		for _, e := range me {
+			fields = append(fields, requestFieldErrors(e)...)
+		}
+		return fields
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return []apierrors.FieldError{apierrors.Field("request", apierrors.FieldInvalid, err.Error())}
+	}
+	field := "body"
+	if p := reqErr.Parameter; p != nil {
+		field = p.In + "." + p.Name
+	}
+	if reqErr.Err == nil {
+		return []apierrors.FieldError{apierrors.Field(field, apierrors.FieldInvalid, reqErr.Error())}
+	}
+	var me openapi3.MultiError
+	if errors.As(reqErr.Err, &me) {
+		for _, e := range me {
+			fields = append(fields, schemaFieldError(field, e))
+		}
+		return fields
+	}
+
+	return []apierrors.FieldError{schemaFieldError(field, reqErr.Err)}
+}
+
+func schemaFieldError(prefix string, err error) apierrors.FieldError {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+			return apierrors.Field(prefix, apierrors.FieldRequired, err.Error())
+		}
+		return apierrors.Field(prefix, apierrors.FieldInvalid, err.Error())
+	}
+
+	pointer := schemaErr.JSONPointer()
+	code := apierrors.FieldInvalid
+	switch {
+	case schemaErr.SchemaField == "required":
+		code = apierrors.FieldRequired
+	case unsupportedPropertyRegex.MatchString(schemaErr.Reason):
+		code = apierrors.FieldUnknown
+		pointer = append(pointer, unsupportedPropertyRegex.FindStringSubmatch(schemaErr.Reason)[1])
+	}
+	field := prefix
+	if len(pointer) > 0 && prefix == "body" {
+		field = strings.Join(pointer, ".")
+	}
+
+	return apierrors.Field(field, code, schemaErr.Reason)
+}
+
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(statusCode int) {
+	w.status = statusCode
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) flush(rw http.ResponseWriter) {
+	for key, values := range w.header {
+		rw.Header()[key] = values
+	}
+	rw.WriteHeader(w.status)
+	_, _ = rw.Write(w.body.Bytes())
+}
@@ -0,0 +1,67 @@
+package httputils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/openapi"
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOpenAPIRouterLoadsEmbeddedSpecs(t *testing.T) {
+	for name, spec := range map[string][]byte{
+		"accounts":         openapi.AccountsSpec(""),
+		"orbissocius":      openapi.OrbisSociusSpec(""),
+		"orbissociusadmin": openapi.OrbisSociusAdminSpec(""),
+		"pds":              openapi.PDSSpec(""),
+		"pdsadmin":         openapi.PDSAdminSpec(""),
+		"zookeeper":        openapi.ZookeeperSpec(""),
+		"zookeeperadmin":   openapi.ZookeeperAdminSpec(""),
+	} {
+		_, err := newOpenAPIRouter(spec)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestOpenAPIValidationMiddleware(t *testing.T) {
+	logger := zap.NewNop()
+	rf := fxresponsefactory.NewFactory(logger, &fxresponsefactory.Config{}, "v0.0.1")
+	validation, err := NewOpenAPIValidationMiddleware(logger, rf, OpenAPIValidationConfig{Spec: openapi.ZookeeperSpec("")})
+	require.NoError(t, err)
+	var called bool
+	handler := NewEnrichContextMiddleware(logger, rf, &netutils.ClientIPExtractor{})(validation(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		called = true
+	})))
+
+	body := `{"email":"not-email","password":"secret","country":"ukr","nickname":"x"}`
+	r := httptest.NewRequest(http.MethodPost, "/auth/sign-up", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var resp fxresponsefactory.FailureResp[interface{}]
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	codes := map[string]apierrors.Code{}
+	for _, f := range resp.Errors {
+		codes[f.Field] = f.Code
+	}
+	assert.Equal(t, apierrors.FieldInvalid, codes["email"])
+	assert.Equal(t, apierrors.FieldRequired, codes["language"])
+	assert.Equal(t, apierrors.FieldUnknown, codes["nickname"])
+
+	body = `{"email":"user@example.com","password":"Secret-pass1","country":"ukr","language":"ukr"}`
+	r = httptest.NewRequest(http.MethodPost, "/auth/sign-up", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.True(t, called)
+}
@@ -0,0 +1,194 @@
+package httputils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	"go.uber.org/zap"
+)
+
+// tlsReloadCheckInterval limits how often files are checked for changes.
+const tlsReloadCheckInterval = time.Second
+
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables verification of client certificates against CA.
+	ClientCAFile string
+	// RequireClientCert rejects connections without client certificate,
+	// otherwise certificate is verified only if client sends it.
+	RequireClientCert bool
+}
+
+// NewTLSConfig returns nil if certificate is not configured. Certificate,
+// key and CA are reloaded when their files change, so rotation doesn't
+// require restart.
+func NewTLSConfig(c TLSConfig, logger *zap.Logger) (*tls.Config, error) {
+	if c.CertFile == "" {
+		return nil, nil
+	}
+	r := &tlsReloader{config: c, logger: logger}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.checkedAt = time.Now()
+
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.configForClient,
+	}, nil
+}
+
+type tlsReloader struct {
+	config TLSConfig
+	logger *zap.Logger
+
+	mu        sync.Mutex
+	current   *tls.Config
+	modTimes  map[string]time.Time
+	checkedAt time.Time
+}
+
+func (r *tlsReloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+
+	return files
+}
+
+func (r *tlsReloader) load() error {
+	modTimes := make(map[string]time.Time, 3)
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+		modTimes[f] = info.ModTime()
+	}
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("can not load certificate (cert = %v, key = %v): %w", r.config.CertFile, r.config.KeyFile, err)
+	}
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if r.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates in client CA (file = %v)", r.config.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.config.RequireClientCert {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = cfg
+	r.modTimes = modTimes
+
+	return nil
+}
+
+func (r *tlsReloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	if r.changed() {
+		if err := r.load(); err != nil {
+			r.logger.Error("TLS files reload is rejected, previous certificate is kept", zap.Error(err))
+		} else {
+			r.logger.Info("TLS files are reloaded")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.current, nil
+}
+
+func (r *tlsReloader) changed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) < tlsReloadCheckInterval {
+		return false
+	}
+	r.checkedAt = time.Now()
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			// file is being replaced, it is checked again later.
+			return false
+		}
+		if !info.ModTime().Equal(r.modTimes[f]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientIdentity is URI SAN (e.g. SPIFFE ID) of certificate, or its common
+// name if certificate has no URI.
+func ClientIdentity(cert *x509.Certificate) string {
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+
+	return cert.Subject.CommonName
+}
+
+// NewClientIdentityMiddleware puts identity of verified client certificate
+// into context.
+func NewClientIdentityMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+				next.ServeHTTP(rw, r)
+				return
+			}
+			identity := ClientIdentity(r.TLS.VerifiedChains[0][0])
+			ctx := contextutils.SetClientIdentity(r.Context(), identity)
+			fxlogger.AddFields(ctx, zap.String("client_identity", identity))
+			next.ServeHTTP(rw, r.WithContext(ctx))
+		})
+	}
+}
+
+// NewRequireClientCertMiddleware rejects requests without verified client
+// certificate. It protects service-to-service endpoints on listeners which
+// verify client certificates only if they are sent.
+func NewRequireClientCertMiddleware(logger *zap.Logger, rf fxresponsefactory.Factory) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if _, ok := contextutils.GetClientIdentity(ctx); !ok {
+				err := errors.New("request has no verified client certificate")
+				_ = rf.NewWriter(rw).WriteFail(ctx, nil, fxresponsefactory.WithHTTPStatusCode(http.StatusUnauthorized),
+					fxresponsefactory.WithCause(err), fxresponsefactory.WithCode(apierrors.AuthMissingClientCert)) //nolint:errcheck
+				fxlogger.FromContext(ctx, logger).Warn("client certificate is required", zap.Error(err))
+				return
+			}
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Package localenames provides localized names of countries, languages and
+// regions. Country and language names come from CLDR data bundled with
+// golang.org/x/text, gaps and ecumenos macro regions are filled from
+// names.yaml. Everything is offline.
+package localenames
+
+import (
+	_ "embed"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed names.yaml
+var namesYAML []byte
+
+type Name struct {
+	Code       string
+	Name       string
+	NativeName string
+}
+
+type entry struct {
+	NativeName string            `yaml:"native_name"`
+	Names      map[string]string `yaml:"names"`
+}
+
+type dataset struct {
+	Countries map[string]entry `yaml:"countries"`
+	Languages map[string]entry `yaml:"languages"`
+	Regions   map[string]entry `yaml:"regions"`
+}
+
+var data = func() dataset {
+	var d dataset
+	if err := yaml.Unmarshal(namesYAML, &d); err != nil {
+		panic("localenames: invalid names.yaml: " + err.Error())
+	}
+	return d
+}()
+
+var supported = func() map[language.Base]struct{} {
+	out := map[language.Base]struct{}{}
+	for _, t := range display.Supported.Tags() {
+		b, _ := t.Base()
+		out[b] = struct{}{}
+	}
+	return out
+}()
+
+// Preferences builds fallback chain of languages: languages from
+// Accept-Language header ordered by quality, then lingua (ISO 639-3 code of
+// comptus language), then English.
+func Preferences(acceptLanguage, lingua string) []language.Tag {
+	var prefs []language.Tag
+	if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+		prefs = append(prefs, tags...)
+	}
+	if b, err := language.ParseBase(lingua); err == nil && lingua != "" {
+		if t, err := language.Compose(b); err == nil {
+			prefs = append(prefs, t)
+		}
+	}
+
+	return append(prefs, language.English)
+}
+
+// Country returns name of the country by ISO 3166-1 alpha-3 code. Native name
+// is the name in the most likely language of the country.
+func Country(code string, prefs []language.Tag) Name {
+	n := Name{Code: code}
+	override := data.Countries[code]
+	region, err := language.ParseRegion(code)
+	cldr := func(b language.Base) string {
+		if err != nil {
+			return ""
+		}
+		t, _ := language.Compose(b)
+		return display.Regions(t).Name(region)
+	}
+	n.Name = lookup(prefs, override, cldr)
+	n.NativeName = override.NativeName
+	if n.NativeName == "" && err == nil {
+		t, _ := language.Compose(language.Und, region)
+		b, _ := t.Base()
+		n.NativeName = cldr(b)
+	}
+
+	return finalize(n)
+}
+
+// Language returns name of the language by ISO 639-3 code. Native name is the
+// name of the language in itself.
+func Language(code string, prefs []language.Tag) Name {
+	n := Name{Code: code}
+	override := data.Languages[code]
+	base, err := language.ParseBase(code)
+	var tag language.Tag
+	if err == nil {
+		tag, _ = language.Compose(base)
+	}
+	cldr := func(b language.Base) string {
+		if err != nil {
+			return ""
+		}
+		t, _ := language.Compose(b)
+		return display.Languages(t).Name(tag)
+	}
+	n.Name = lookup(prefs, override, cldr)
+	n.NativeName = override.NativeName
+	if n.NativeName == "" && err == nil {
+		n.NativeName = display.Self.Name(tag)
+	}
+
+	return finalize(n)
+}
+
+// Region returns name of the ecumenos region. Display name configured by
+// administrators is used as native name and as the last resort name.
+func Region(code, displayName string, prefs []language.Tag) Name {
+	n := Name{Code: code, NativeName: displayName}
+	n.Name = lookup(prefs, data.Regions[code], nil)
+	if n.Name == "" {
+		n.Name = displayName
+	}
+
+	return finalize(n)
+}
+
+func lookup(prefs []language.Tag, override entry, cldr func(language.Base) string) string {
+	for _, p := range prefs {
+		b, _ := p.Base()
+		if name := override.Names[b.String()]; name != "" {
+			return name
+		}
+		if cldr == nil {
+			continue
+		}
+		if _, ok := supported[b]; !ok {
+			continue
+		}
+		if name := cldr(b); name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func finalize(n Name) Name {
+	if n.Name == "" {
+		n.Name = strings.ToUpper(n.Code)
+	}
+	if n.NativeName == "" {
+		n.NativeName = n.Name
+	}
+
+	return n
+}
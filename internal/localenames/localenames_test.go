@@ -0,0 +1,30 @@
+package localenames_test
+
+import (
+	"testing"
+
+	"github.com/ecumenos/ecumenos/internal/localenames"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountry(t *testing.T) {
+	prefs := localenames.Preferences("de-CH, fr;q=0.8", "ukr")
+	require.Equal(t, localenames.Name{Code: "deu", Name: "Deutschland", NativeName: "Deutschland"}, localenames.Country("deu", prefs))
+	require.Equal(t, localenames.Name{Code: "ukr", Name: "Ukraine", NativeName: "Україна"}, localenames.Country("ukr", prefs))
+	require.Equal(t, localenames.Name{Code: "xkx", Name: "Kosovo", NativeName: "Kosova"}, localenames.Country("xkx", prefs))
+
+	prefs = localenames.Preferences("", "ukr")
+	require.Equal(t, "Албанія", localenames.Country("alb", prefs).Name)
+}
+
+func TestLanguage(t *testing.T) {
+	prefs := localenames.Preferences("xx, en-GB", "")
+	require.Equal(t, localenames.Name{Code: "ukr", Name: "Ukrainian", NativeName: "українська"}, localenames.Language("ukr", prefs))
+	require.Equal(t, localenames.Name{Code: "apc", Name: "Levantine Arabic", NativeName: "شامي"}, localenames.Language("apc", prefs))
+}
+
+func TestRegion(t *testing.T) {
+	require.Equal(t, "Mitteleuropa", localenames.Region("eu_cent", "Central Europe", localenames.Preferences("de", "")).Name)
+	require.Equal(t, "Central Europe", localenames.Region("eu_cent", "Central Europe", localenames.Preferences("ja", "")).Name)
+	require.Equal(t, localenames.Name{Code: "as_east", Name: "East Asia", NativeName: "East Asia"}, localenames.Region("as_east", "East Asia", localenames.Preferences("de", "")))
+}
@@ -0,0 +1,14 @@
+package openapi
+
+import (
+	_ "embed"
+)
+
+//go:embed shared-internal.yaml
+var sharedSpec []byte
+
+// SharedSpec returns spec which is referenced by merged service specs as
+// "./shared-internal.yaml".
+func SharedSpec() []byte {
+	return sharedSpec
+}
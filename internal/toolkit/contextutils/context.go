@@ -17,6 +17,8 @@ const (
 	comptusIDKey             ctxKey = "k_account_id"
 	adminSessionIDKey        ctxKey = "k_admin_session_id"
 	comptusSessionIDKey      ctxKey = "k_session_id"
+	acceptKey                ctxKey = "k_accept"
+	clientIdentityKey        ctxKey = "k_client_identity"
 )
 
 func getValueFromContext(ctx context.Context, key ctxKey) string {
@@ -122,3 +124,24 @@ func GetComptusSessionID(ctx context.Context) (int64, bool) {
 
 	return i, true
 }
+
+// SetAccept stores Accept header of request. It is used by response writers
+// for content negotiation.
+func SetAccept(ctx context.Context, v string) context.Context {
+	return setValue(ctx, acceptKey, v)
+}
+
+func GetAccept(ctx context.Context) string {
+	return getValueFromContext(ctx, acceptKey)
+}
+
+// SetClientIdentity stores identity of verified client certificate.
+func SetClientIdentity(ctx context.Context, v string) context.Context {
+	return setValue(ctx, clientIdentityKey, v)
+}
+
+func GetClientIdentity(ctx context.Context) (string, bool) {
+	v := getValueFromContext(ctx, clientIdentityKey)
+
+	return v, v != ""
+}
@@ -2,25 +2,139 @@ package httputils
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
 	"net/http"
 	"time"
 
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
 	"github.com/hashicorp/go-retryablehttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-func RobustHTTPClient(logger *zap.Logger) *http.Client {
+// ClientOption configures client returned by RobustHTTPClient.
+type ClientOption func(c *clientConfig)
+
+type clientConfig struct {
+	retry       *retryablehttp.Client
+	timeout     time.Duration
+	noRedirects bool
+}
+
+// WithClientCertificate makes client present certificate to servers which
+// verify client certificates.
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *clientConfig) {
+		transport := c.retry.HTTPClient.Transport.(*http.Transport)
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}
+	}
+}
+
+// WithTimeout limits duration of request with all its retries, it is 30
+// seconds by default.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetryMax sets number of retries of failed request, 0 disables
+// retries.
+func WithRetryMax(n int) ClientOption {
+	return func(c *clientConfig) {
+		c.retry.RetryMax = n
+	}
+}
+
+// WithoutRedirects makes client return redirect as response instead of
+// following it.
+func WithoutRedirects() ClientOption {
+	return func(c *clientConfig) {
+		c.noRedirects = true
+	}
+}
+
+// WithPublicAddressesOnly makes client refuse to connect to loopback,
+// link-local and private addresses and to follow redirects, so URLs given by
+// users don't reach internal network. Proxy is not used, it would connect to
+// server instead of the dialer.
+func WithPublicAddressesOnly() ClientOption {
+	return func(c *clientConfig) {
+		transport := c.retry.HTTPClient.Transport.(*http.Transport)
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   netutils.DenyPrivateAddress,
+		}
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+		c.noRedirects = true
+	}
+}
+
+func RobustHTTPClient(logger *zap.Logger, opts ...ClientOption) *http.Client {
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = 3
 	retryClient.RetryWaitMin = 1 * time.Second
 	retryClient.RetryWaitMax = 10 * time.Second
 	retryClient.Logger = retryablehttp.LeveledLogger(LeveledZap{inner: logger})
 	retryClient.CheckRetry = RetryPolicy
+	cfg := &clientConfig{retry: retryClient, timeout: 30 * time.Second}
+	for _, opt := range opts {
+		opt(cfg)
+	}
 	client := retryClient.StandardClient()
-	client.Timeout = 30 * time.Second
+	client.Transport = &tracingTransport{base: client.Transport}
+	client.Timeout = cfg.timeout
+	if cfg.noRedirects {
+		// redirect is returned as response by both underlying and outer
+		// clients.
+		retryClient.HTTPClient.CheckRedirect = noRedirect
+		client.CheckRedirect = noRedirect
+	}
 	return client
 }
 
+func noRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// tracingTransport wraps outgoing request with all its retries into client
+// span and passes trace context to callee in traceparent header.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer("github.com/ecumenos/ecumenos/internal/toolkit/httputils").Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPMethod(req.Method), semconv.URLFull(req.URL.Redacted())))
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
+
 type LeveledZap struct {
 	inner *zap.Logger
 }
@@ -0,0 +1,110 @@
+package netutils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIPExtractor finds IP address of client behind trusted reverse
+// proxies. Forwarding headers are taken into account only if request comes
+// from trusted proxy, and they are walked from the right, so hops added by
+// client itself are never trusted.
+type ClientIPExtractor struct {
+	trusted []*net.IPNet
+}
+
+// NewClientIPExtractor parses trusted proxies (IPs or CIDRs). Without
+// trusted proxies client IP is always taken from remote address.
+func NewClientIPExtractor(trustedProxies ...string) (*ClientIPExtractor, error) {
+	trusted, err := ParseSubnets(trustedProxies...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	return &ClientIPExtractor{trusted: trusted}, nil
+}
+
+func (e *ClientIPExtractor) Extract(r *http.Request) (string, error) {
+	host, err := SplitHost(r.RemoteAddr)
+	if err != nil {
+		return "", err
+	}
+	peer := net.ParseIP(host)
+	if peer == nil {
+		return "", fmt.Errorf("invalid remote address (address = %v)", r.RemoteAddr)
+	}
+	if !e.isTrusted(peer) {
+		return peer.String(), nil
+	}
+
+	hops := forwardedFor(r)
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := parseHop(hops[i])
+		if ip == nil {
+			// hop is obfuscated or malformed, the last trusted hop is
+			// the best known address.
+			break
+		}
+		client = ip
+		if !e.isTrusted(ip) {
+			break
+		}
+	}
+
+	return client.String(), nil
+}
+
+func (e *ClientIPExtractor) isTrusted(ip net.IP) bool {
+	for _, n := range e.trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forwardedFor returns hops of Forwarded header (RFC 7239), or of
+// X-Forwarded-For if Forwarded is not set.
+func forwardedFor(r *http.Request) []string {
+	var hops []string
+	if values := r.Header.Values("Forwarded"); len(values) > 0 {
+		for _, v := range values {
+			for _, element := range strings.Split(v, ",") {
+				for _, pair := range strings.Split(element, ";") {
+					key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+					if ok && strings.EqualFold(key, "for") {
+						hops = append(hops, value)
+					}
+				}
+			}
+		}
+
+		return hops
+	}
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(v, ",") {
+			hops = append(hops, hop)
+		}
+	}
+
+	return hops
+}
+
+// parseHop parses IP of hop, e.g. 192.0.2.60, "[2001:db8::1]:4711" or
+// 192.0.2.60:4711.
+func parseHop(hop string) net.IP {
+	hop = strings.Trim(strings.TrimSpace(hop), `"`)
+	if ip := net.ParseIP(hop); ip != nil {
+		return ip
+	}
+	host, err := SplitHost(hop)
+	if err != nil {
+		return nil
+	}
+
+	return net.ParseIP(strings.Trim(host, "[]"))
+}
@@ -0,0 +1,70 @@
+package netutils
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClientIPExtractor(t *testing.T) {
+	e, err := NewClientIPExtractor("10.0.0.0/8", "2001:db8::/32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		expected   string
+	}{
+		{
+			name:       "untrusted peer ignores headers",
+			remoteAddr: "203.0.113.7:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "trusted peer without headers",
+			remoteAddr: "10.0.0.1:5000",
+			expected:   "10.0.0.1",
+		},
+		{
+			name:       "spoofed left hops are skipped",
+			remoteAddr: "10.0.0.1:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 198.51.100.1", "10.0.0.2"}},
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "all hops are trusted",
+			remoteAddr: "10.0.0.1:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			expected:   "10.0.0.3",
+		},
+		{
+			name:       "malformed hop",
+			remoteAddr: "10.0.0.1:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1, garbage, 10.0.0.2"}},
+			expected:   "10.0.0.2",
+		},
+		{
+			name:       "forwarded header takes precedence",
+			remoteAddr: "[2001:db8::1]:5000",
+			headers: map[string][]string{
+				"Forwarded":       {`for=198.51.100.1;proto=https, for="[2001:db8::2]:4711"`},
+				"X-Forwarded-For": {"192.0.2.1"},
+			},
+			expected: "198.51.100.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tt.remoteAddr, Header: http.Header(tt.headers)}
+			ip, err := e.Extract(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ip != tt.expected {
+				t.Fatalf("unexpected IP (expected = %v, got = %v)", tt.expected, ip)
+			}
+		})
+	}
+}
@@ -4,35 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"net/http"
 	"strings"
+	"syscall"
 
 	"github.com/ecumenos/ecumenos/internal/toolkit/sliceutils"
 )
 
-func ExtractIPAddress(r *http.Request) (string, error) {
-	// Get the IP address from the "X-Forwarded-For" header.
-	ipAddress := r.Header.Get("X-Forwarded-For")
-
-	if ipAddress == "" {
-		// If the "X-Forwarded-For" header is not set, try to get the IP address from the remote address of the connection.
-		ipAddress, _, err := SplitHostPort(r.RemoteAddr)
-		if err != nil {
-			return "", err
-		}
-
-		return ipAddress, nil
-	}
-
-	// If the "X-Forwarded-For" header is set, split it and return the first IP address in the list.
-	ipAddresses := strings.Split(ipAddress, ", ")
-	if len(ipAddresses) < 1 {
-		return "", fmt.Errorf("failed extract IP address from forwarded (IP address=%v)", ipAddress)
-	}
-
-	return ipAddresses[0], nil
-}
-
 // Bit lengths of IP addresses.
 const (
 	IPv4BitLen = net.IPv4len * 8
@@ -191,3 +168,39 @@ func ValidateIP(ip net.IP) (err error) {
 		return fmt.Errorf("invalid IP address length (length=%v)", l)
 	}
 }
+
+// sharedAddressSpace is carrier-grade NAT range (RFC 6598).
+var sharedAddressSpace = &net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(10, IPv4BitLen)}
+
+// IsPublicIP returns false for loopback, link-local, private, shared,
+// unspecified and multicast addresses. Requests to URLs given by users must
+// not reach internal network.
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// ErrPrivateAddress is returned by DenyPrivateAddress for addresses which are
+// not public.
+var ErrPrivateAddress = errors.New("address is loopback, link-local or private")
+
+// DenyPrivateAddress is control of net.Dialer which refuses to connect to
+// addresses which are not public. Address is checked after resolution, so
+// host names resolved to internal addresses are refused too.
+func DenyPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w (address = %v)", ErrPrivateAddress, address)
+	}
+
+	return nil
+}
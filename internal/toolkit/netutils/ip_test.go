@@ -0,0 +1,30 @@
+package netutils
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fc00::1":         false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"224.0.0.1":       false,
+		"::ffff:10.0.0.1": false,
+	}
+	for addr, expected := range tests {
+		if actual := IsPublicIP(net.ParseIP(addr)); actual != expected {
+			t.Errorf("IsPublicIP(%v) = %v, expected %v", addr, actual, expected)
+		}
+	}
+}
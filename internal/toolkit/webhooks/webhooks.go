@@ -0,0 +1,141 @@
+// Package webhooks signs webhooks of zookeeper and verifies them on receiver
+// side. Heartbeats of orbes socii are signed the same way by their API keys.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader has value "t=<unix timestamp>,v1=<hex HMAC-SHA256>".
+	// HMAC is calculated by secret of webhook over "<timestamp>.<body>".
+	SignatureHeader = "Ecumenos-Signature"
+	EventHeader     = "Ecumenos-Event"
+	DeliveryHeader  = "Ecumenos-Delivery"
+
+	// DefaultTolerance is maximal age of signature, older webhooks are
+	// rejected as replayed.
+	DefaultTolerance = 5 * time.Minute
+	// MaxBodySize is maximal size of webhook body which is read by
+	// VerifyRequest.
+	MaxBodySize = 1 << 20
+)
+
+var (
+	ErrInvalidHeader     = errors.New("signature header is invalid")
+	ErrSignatureMismatch = errors.New("signature does not match")
+	ErrTooOld            = errors.New("signature timestamp is out of tolerance")
+)
+
+// Event is body of webhook. ID is the same for all attempts and
+// redeliveries, so receiver can skip duplicates.
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	OrbisSociusID int64           `json:"orbis_socius_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Sign returns value of signature header.
+func Sign(secret []byte, ts time.Time, body []byte) string {
+	t := strconv.FormatInt(ts.Unix(), 10)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac(secret, t, body))
+}
+
+func mac(secret []byte, t string, body []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(t))
+	h.Write([]byte{'.'})
+	h.Write(body)
+
+	return h.Sum(nil)
+}
+
+// Verify checks signature header of body. Signature is accepted if any of
+// v1 values matches, and it is not older or newer than tolerance.
+func Verify(secret []byte, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	t, unix, signatures, err := parseHeader(header)
+	if err != nil {
+		return err
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w (timestamp = %v)", ErrTooOld, unix)
+	}
+	expected := mac(secret, t, body)
+	for _, sig := range signatures {
+		if hmac.Equal(sig, expected) {
+			return nil
+		}
+	}
+
+	return ErrSignatureMismatch
+}
+
+// SignedAt returns timestamp of signature header. It is trusted only after
+// header is verified.
+func SignedAt(header string) (time.Time, error) {
+	_, unix, _, err := parseHeader(header)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(unix, 0), nil
+}
+
+func parseHeader(header string) (string, int64, [][]byte, error) {
+	var (
+		t          string
+		signatures [][]byte
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return "", 0, nil, ErrInvalidHeader
+		}
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			sig, err := hex.DecodeString(value)
+			if err != nil {
+				return "", 0, nil, ErrInvalidHeader
+			}
+			signatures = append(signatures, sig)
+		}
+	}
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return "", 0, nil, ErrInvalidHeader
+	}
+
+	return t, unix, signatures, nil
+}
+
+// VerifyRequest reads body of webhook request and verifies its signature.
+func VerifyRequest(r *http.Request, secret []byte, tolerance time.Duration) (*Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(secret, r.Header.Get(SignatureHeader), body, tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	var e Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		return nil, fmt.Errorf("can not decode webhook event: %w", err)
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,57 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`{"id":1,"type":"webhook.ping","orbis_socius_id":2,"created_at":"2024-01-02T03:04:05Z","data":{}}`)
+	now := time.Unix(1700000000, 0)
+	header := Sign(secret, now, body)
+
+	tests := []struct {
+		name     string
+		secret   []byte
+		header   string
+		body     []byte
+		now      time.Time
+		expected error
+	}{
+		{name: "valid", secret: secret, header: header, body: body, now: now},
+		{name: "valid with rotated secret", secret: secret, header: header + ",v1=00ff", body: body, now: now.Add(time.Minute)},
+		{name: "another secret", secret: []byte("other"), header: header, body: body, now: now, expected: ErrSignatureMismatch},
+		{name: "modified body", secret: secret, header: header, body: append([]byte(" "), body...), now: now, expected: ErrSignatureMismatch},
+		{name: "replayed", secret: secret, header: header, body: body, now: now.Add(DefaultTolerance + time.Second), expected: ErrTooOld},
+		{name: "timestamp from future", secret: secret, header: header, body: body, now: now.Add(-DefaultTolerance - time.Second), expected: ErrTooOld},
+		{name: "no signature", secret: secret, header: "t=1700000000", body: body, now: now, expected: ErrInvalidHeader},
+		{name: "malformed", secret: secret, header: "garbage", body: body, now: now, expected: ErrInvalidHeader},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, DefaultTolerance, tt.now)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("unexpected error (expected = %v, actual = %v)", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	secret := []byte("secret")
+	body := `{"id":1,"type":"webhook.ping","orbis_socius_id":2,"created_at":"2024-01-02T03:04:05Z","data":{}}`
+	r := httptest.NewRequest("POST", "/webhooks", strings.NewReader(body))
+	r.Header.Set(SignatureHeader, Sign(secret, time.Now(), []byte(body)))
+
+	e, err := VerifyRequest(r, secret, DefaultTolerance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ID != 1 || e.Type != "webhook.ping" || e.OrbisSociusID != 2 {
+		t.Fatalf("unexpected event (event = %+v)", e)
+	}
+}
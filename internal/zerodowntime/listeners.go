@@ -0,0 +1,148 @@
+package zerodowntime
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// listenersEnv lists addresses of listeners inherited from parent
+	// process. Listener of i-th address is passed as file descriptor 3+i.
+	listenersEnv = "ZERODOWNTIME_LISTENERS"
+	// readyEnv is file descriptor of pipe which child closes when it is
+	// ready to accept connections.
+	readyEnv = "ZERODOWNTIME_READY_FD"
+
+	firstInheritedFD = 3
+)
+
+type fileListener interface {
+	net.Listener
+	File() (*os.File, error)
+}
+
+var listeners = struct {
+	sync.Mutex
+	inherited map[string]*os.File
+	active    map[string]fileListener
+	order     []string
+}{
+	active: map[string]fileListener{},
+}
+
+// Listen returns TCP listener of addr. Listener inherited from parent
+// process is reused, so connections are not refused during restart. All
+// listeners are passed on to child on the next restart.
+func Listen(addr string) (net.Listener, error) {
+	listeners.Lock()
+	defer listeners.Unlock()
+
+	if listeners.inherited == nil {
+		inherited, err := inheritedFiles()
+		if err != nil {
+			return nil, err
+		}
+		listeners.inherited = inherited
+	}
+
+	var ln net.Listener
+	if f, ok := listeners.inherited[addr]; ok {
+		delete(listeners.inherited, addr)
+		l, err := net.FileListener(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("can not use inherited listener (addr = %v): %w", addr, err)
+		}
+		ln = l
+	} else {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		ln = l
+	}
+
+	fl, ok := ln.(fileListener)
+	if !ok {
+		return ln, nil
+	}
+	if _, exists := listeners.active[addr]; !exists {
+		listeners.order = append(listeners.order, addr)
+	}
+	listeners.active[addr] = fl
+
+	return ln, nil
+}
+
+func inheritedFiles() (map[string]*os.File, error) {
+	files := map[string]*os.File{}
+	v := os.Getenv(listenersEnv)
+	if v == "" {
+		return files, nil
+	}
+	for i, addr := range strings.Split(v, ",") {
+		fd := firstInheritedFD + i
+		files[addr] = os.NewFile(uintptr(fd), "listener "+addr)
+	}
+
+	return files, nil
+}
+
+// activeFiles duplicates descriptors of active listeners for child process.
+func activeFiles() ([]string, []*os.File, error) {
+	listeners.Lock()
+	defer listeners.Unlock()
+
+	addrs := make([]string, 0, len(listeners.order))
+	files := make([]*os.File, 0, len(listeners.order))
+	for _, addr := range listeners.order {
+		f, err := listeners.active[addr].File()
+		if err != nil {
+			closeFiles(files)
+			return nil, nil, fmt.Errorf("can not duplicate listener (addr = %v): %w", addr, err)
+		}
+		addrs = append(addrs, addr)
+		files = append(files, f)
+	}
+
+	return addrs, files, nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		_ = f.Close()
+	}
+}
+
+// notifyReady tells parent process that it can stop.
+func notifyReady() {
+	v := os.Getenv(readyEnv)
+	if v == "" {
+		return
+	}
+	_ = os.Unsetenv(readyEnv)
+	_ = os.Unsetenv(listenersEnv)
+	fd, err := strconv.Atoi(v)
+	if err != nil {
+		return
+	}
+	f := os.NewFile(uintptr(fd), "ready")
+	_, _ = f.Write([]byte{1})
+	_ = f.Close()
+}
+
+// closeUnused closes inherited listeners which were not taken over, e.g.
+// address was changed in configuration.
+func closeUnused() {
+	listeners.Lock()
+	defer listeners.Unlock()
+
+	for addr, f := range listeners.inherited {
+		_ = f.Close()
+		delete(listeners.inherited, addr)
+	}
+}
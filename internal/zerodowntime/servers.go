@@ -0,0 +1,37 @@
+package zerodowntime
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+type Server interface {
+	Start(ctx context.Context) error
+	Shutdown(ctx context.Context) error
+}
+
+// Serve starts servers with fx app and shuts them down when it stops.
+func Serve(lc fx.Lifecycle, servers ...Server) {
+	for _, s := range servers {
+		lc.Append(fx.Hook{
+			OnStart: s.Start,
+			OnStop:  s.Shutdown,
+		})
+	}
+}
+
+// Drain stops server gracefully: it stops accepting connections and waits
+// for active requests at most timeout. It returns false if connections were
+// still active after timeout and were closed.
+func Drain(ctx context.Context, srv *http.Server, timeout time.Duration) (bool, error) {
+	drainCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := srv.Shutdown(drainCtx); err != nil {
+		return false, srv.Close()
+	}
+
+	return true, nil
+}
@@ -2,27 +2,168 @@ package zerodowntime
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
-func HandleApp(app *fx.App) error {
-	startCtx, cancel := context.WithTimeout(context.Background(), app.StartTimeout())
-	defer cancel()
+// RestartSignal starts graceful restart: process execs its own binary with
+// the same arguments, passes listeners to it and stops once child is ready.
+const RestartSignal = syscall.SIGUSR2
 
-	if err := app.Start(startCtx); err != nil {
-		return err
+func HandleApp(logger *zap.Logger, app *fx.App) error {
+	return HandleApps(logger, app)
+}
+
+// HandleApps runs several apps in one process. Apps are started in order
+// and stopped in reverse order when any of them is done. Signals, restarts
+// and stops are logged by logger, which is nil if app failed to build.
+func HandleApps(logger *zap.Logger, apps ...*fx.App) error {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	for i, app := range apps {
+		startCtx, cancel := context.WithTimeout(context.Background(), app.StartTimeout())
+		err := app.Start(startCtx)
+		cancel()
+		if err != nil {
+			return errors.Join(err, stopApps(logger, apps[:i]))
+		}
+	}
+	closeUnused()
+	notifyReady()
+
+	sigs := make(chan os.Signal, len(apps))
+	for _, app := range apps {
+		go func(done <-chan os.Signal) {
+			sigs <- <-done
+		}(app.Done())
+	}
+	restarts := make(chan os.Signal, 1)
+	signal.Notify(restarts, RestartSignal)
+	defer signal.Stop(restarts)
+
+wait:
+	for {
+		select {
+		case sig := <-sigs:
+			logger.Info("received signal", zap.Stringer("signal", sig))
+			break wait
+		case sig := <-restarts:
+			logger.Info("received signal, restarting", zap.Stringer("signal", sig))
+			pid, err := handoff(maxTimeout(apps, (*fx.App).StartTimeout))
+			if err != nil {
+				logger.Error("restart failed, process keeps running", zap.Error(err))
+				continue
+			}
+			logger.Info("child process is ready", zap.Int("pid", pid))
+			break wait
+		}
 	}
 
-	sigs := app.Done()
+	return stopApps(logger, apps)
+}
+
+func stopApps(logger *zap.Logger, apps []*fx.App) error {
+	var errs []error
+	for i := len(apps) - 1; i >= 0; i-- {
+		app := apps[i]
+		logger.Info("stopping app", zap.Duration("stop_timeout", app.StopTimeout()))
+		stopCtx, cancel := context.WithTimeout(context.Background(), app.StopTimeout())
+		errs = append(errs, app.Stop(stopCtx))
+		cancel()
+	}
 
-	fmt.Println(fmt.Sprintf("received signal: %v", <-sigs))
-	fmt.Println(fmt.Sprintf("exiting in %s", app.StartTimeout().String()))
+	return errors.Join(errs...)
+}
 
-	stopCtx, cancel := context.WithTimeout(context.Background(), app.StopTimeout())
-	defer cancel()
+func maxTimeout(apps []*fx.App, timeout func(*fx.App) time.Duration) time.Duration {
+	var longest time.Duration
+	for _, app := range apps {
+		if t := timeout(app); t > longest {
+			longest = t
+		}
+	}
+
+	return longest
+}
+
+// handoff starts child process and waits until it is ready. Child is killed
+// if it isn't ready in timeout.
+func handoff(timeout time.Duration) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	addrs, files, err := activeFiles()
+	if err != nil {
+		return 0, err
+	}
+	defer closeFiles(files)
+	r, w, err := os.Pipe()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = append(files, w)
+	cmd.Env = append(childEnv(),
+		listenersEnv+"="+strings.Join(addrs, ","),
+		readyEnv+"="+strconv.Itoa(firstInheritedFD+len(files)))
+	err = cmd.Start()
+	_ = w.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	ready := make(chan error, 1)
+	go func() {
+		// read returns EOF if child exits before it is ready.
+		_, err := r.Read(make([]byte, 1))
+		if errors.Is(err, io.EOF) {
+			err = errors.New("child process exited before it was ready")
+		}
+		ready <- err
+	}()
+	select {
+	case err = <-ready:
+	case <-time.After(timeout):
+		err = fmt.Errorf("child process is not ready (timeout = %v)", timeout)
+	}
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return 0, err
+	}
+	pid := cmd.Process.Pid
+	// child outlives parent, it doesn't need to be waited for.
+	_ = cmd.Process.Release()
+
+	return pid, nil
+}
+
+func childEnv() []string {
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, listenersEnv+"=") || strings.HasPrefix(kv, readyEnv+"=") {
+			continue
+		}
+		env = append(env, kv)
+	}
 
-	fmt.Println("stopping app")
-	return app.Stop(stopCtx)
+	return env
 }
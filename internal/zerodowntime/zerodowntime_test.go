@@ -0,0 +1,172 @@
+package zerodowntime
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// testChildEnv makes test binary behave as restarted child process.
+	testChildEnv = "ZERODOWNTIME_TEST_CHILD"
+	// testAddrEnv is configured address of listener of child process.
+	testAddrEnv = "ZERODOWNTIME_TEST_ADDR"
+)
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(testChildEnv) {
+	case "serve":
+		os.Exit(runTestChild())
+	case "fail":
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// runTestChild takes over listener of parent and answers the first
+// connection.
+func runTestChild() int {
+	ln, err := Listen(os.Getenv(testAddrEnv))
+	if err != nil {
+		return 2
+	}
+	closeUnused()
+	notifyReady()
+	_ = ln.(*net.TCPListener).SetDeadline(time.Now().Add(10 * time.Second))
+	conn, err := ln.Accept()
+	if err != nil {
+		return 3
+	}
+	defer conn.Close()
+	_, _ = conn.Write([]byte("child\n"))
+
+	return 0
+}
+
+func resetListeners(t *testing.T) {
+	t.Cleanup(func() {
+		listeners.Lock()
+		defer listeners.Unlock()
+		for _, l := range listeners.active {
+			_ = l.Close()
+		}
+		listeners.inherited = nil
+		listeners.active = map[string]fileListener{}
+		listeners.order = nil
+	})
+}
+
+func TestHandoffPassesListenerToChild(t *testing.T) {
+	resetListeners(t)
+	const addr = "127.0.0.1:0"
+	ln, err := Listen(addr)
+	require.NoError(t, err)
+	t.Setenv(testChildEnv, "serve")
+	t.Setenv(testAddrEnv, addr)
+
+	pid, err := handoff(10 * time.Second)
+	require.NoError(t, err)
+	assert.NotZero(t, pid)
+
+	// parent stops accepting, connection is served by child through the
+	// same socket.
+	require.NoError(t, ln.Close())
+	conn, err := net.DialTimeout("tcp", ln.Addr().String(), 5*time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "child\n", line)
+}
+
+func TestHandoffFailsIfChildIsNotReady(t *testing.T) {
+	resetListeners(t)
+	ln, err := Listen("127.0.0.1:0")
+	require.NoError(t, err)
+	t.Setenv(testChildEnv, "fail")
+
+	_, err = handoff(10 * time.Second)
+	require.Error(t, err)
+
+	// parent keeps serving.
+	conn, err := net.DialTimeout("tcp", ln.Addr().String(), 5*time.Second)
+	require.NoError(t, err)
+	_ = conn.Close()
+}
+
+func TestListenWithoutInheritedListeners(t *testing.T) {
+	resetListeners(t)
+	t.Setenv(listenersEnv, "")
+
+	ln, err := Listen("127.0.0.1:0")
+	require.NoError(t, err)
+	addrs, files, err := activeFiles()
+	require.NoError(t, err)
+	defer closeFiles(files)
+	assert.Equal(t, []string{"127.0.0.1:0"}, addrs)
+	assert.Len(t, files, 1)
+	assert.NotNil(t, ln)
+}
+
+func TestDrainWaitsForActiveRequests(t *testing.T) {
+	started := make(chan struct{})
+	srv := &http.Server{Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		rw.WriteHeader(http.StatusNoContent)
+	})}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+
+	resp := make(chan error, 1)
+	go func() {
+		r, err := http.Get("http://" + ln.Addr().String())
+		if err == nil {
+			_ = r.Body.Close()
+		}
+		resp <- err
+	}()
+	<-started
+	drained, err := Drain(context.Background(), srv, 5*time.Second)
+	require.NoError(t, err)
+	assert.True(t, drained)
+	require.NoError(t, <-resp, "active request is not finished")
+}
+
+func TestDrainClosesConnectionsAfterTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	srv := &http.Server{Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+
+	resp := make(chan error, 1)
+	go func() {
+		r, err := http.Get("http://" + ln.Addr().String())
+		if err == nil {
+			_ = r.Body.Close()
+		}
+		resp <- err
+	}()
+	<-started
+	start := time.Now()
+	drained, err := Drain(context.Background(), srv, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, drained)
+	assert.Less(t, time.Since(start), time.Second, "drain outlives timeout")
+	require.Error(t, <-resp, "hanging connection is not closed")
+}
@@ -0,0 +1,25 @@
+package orbissocius
+
+import (
+	"database/sql"
+	"time"
+)
+
+// CrawlTarget is member which records are fetched from its PDS.
+type CrawlTarget struct {
+	MemberID      int64        `json:"member_id"`
+	Handle        string       `json:"handle"`
+	PDSURL        string       `json:"pds_url"`
+	Host          string       `json:"host"`
+	LastCrawledAt sql.NullTime `json:"last_crawled_at"`
+}
+
+// CrawlHost is state of PDS host, crawler doesn't call host until its
+// backoff is over.
+type CrawlHost struct {
+	Host         string         `json:"host"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	Failures     int            `json:"failures"`
+	BackoffUntil sql.NullTime   `json:"backoff_until"`
+	LastError    sql.NullString `json:"last_error"`
+}
@@ -0,0 +1,16 @@
+package orbissocius
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Member struct {
+	ID         int64          `json:"id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  sql.NullTime   `json:"deleted_at"`
+	Tombstoned bool           `json:"tombstoned"`
+	Handle     string         `json:"handle"`
+	PDSURL     sql.NullString `json:"pds_url"`
+}
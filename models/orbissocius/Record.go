@@ -0,0 +1,13 @@
+package orbissocius
+
+import "time"
+
+// Record is public record of member indexed by crawler.
+type Record struct {
+	URI        string    `json:"uri"`
+	MemberID   int64     `json:"member_id"`
+	Collection string    `json:"collection"`
+	CreatedAt  time.Time `json:"created_at"`
+	IndexedAt  time.Time `json:"indexed_at"`
+	Data       []byte    `json:"data"`
+}
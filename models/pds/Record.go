@@ -0,0 +1,15 @@
+package pds
+
+import "time"
+
+// Record is public record stored by PDS. ID orders records by indexing, it is
+// used as cursor of pages.
+type Record struct {
+	ID         int64     `json:"id"`
+	URI        string    `json:"uri"`
+	Author     string    `json:"author"`
+	Collection string    `json:"collection"`
+	CreatedAt  time.Time `json:"created_at"`
+	IndexedAt  time.Time `json:"indexed_at"`
+	Data       []byte    `json:"data"`
+}
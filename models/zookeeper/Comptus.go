@@ -15,4 +15,5 @@ type Comptus struct {
 	PasswordHash string       `json:"password_hash"`
 	Patria       string       `json:"patria"`
 	Lingua       string       `json:"lingua"`
+	SuspendedAt  sql.NullTime `json:"suspended_at"`
 }
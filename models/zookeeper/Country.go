@@ -0,0 +1,11 @@
+package zookeeper
+
+import "time"
+
+type Country struct {
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Enabled   bool      `json:"enabled"`
+	Regions   []string  `json:"regions"`
+}
@@ -0,0 +1,35 @@
+package zookeeper
+
+import "time"
+
+// Event is domain event stored in outbox. AggregateID is ID of entity which
+// was changed.
+type Event struct {
+	ID          int64     `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Type        EventType `json:"type"`
+	AggregateID int64     `json:"aggregate_id"`
+	Data        []byte    `json:"data"`
+}
+
+// ConsumerOffset is position of consumer in outbox. Attempts counts failed
+// attempts to handle the event after LastEventID.
+type ConsumerOffset struct {
+	LastEventID int64
+	Attempts    int
+}
+
+type EventType string
+
+const (
+	OrbisSociusLaunchRequestApprovedEvent EventType = "orbis_socius_launch_request.approved"
+	OrbisSociusLaunchRequestRejectedEvent EventType = "orbis_socius_launch_request.rejected"
+	OrbisSociusLaunchInviteRedeemedEvent  EventType = "orbis_socius_launch_invite.redeemed"
+	OrbisSociusHealthChangedEvent         EventType = "orbis_socius.health_changed"
+	OrbisSociusAPIKeyRotatedEvent         EventType = "orbis_socius.api_key_rotated"
+	OrbisSociusDelistedEvent              EventType = "orbis_socius.delisted"
+	OrbisSociusComplianceChangedEvent     EventType = "orbis_socius.compliance_changed"
+	ComptusDeletedEvent                   EventType = "comptus.deleted"
+	ComptusSuspendedEvent                 EventType = "comptus.suspended"
+	ComptusUnsuspendedEvent               EventType = "comptus.unsuspended"
+)
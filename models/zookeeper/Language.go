@@ -0,0 +1,10 @@
+package zookeeper
+
+import "time"
+
+type Language struct {
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Enabled   bool      `json:"enabled"`
+}
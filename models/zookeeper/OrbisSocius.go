@@ -21,6 +21,17 @@ type OrbisSocius struct {
 	Description      string           `json:"description"`
 	URL              string           `json:"url"`
 	APIKey           string           `json:"api_key"`
+	Version          sql.NullString   `json:"version"`
+	ProtocolVersion  sql.NullInt64    `json:"protocol_version"`
+	Compliant        bool             `json:"compliant"`
+}
+
+// OrbisSociusCompliance tells whether orbis socius speaks protocol version
+// which is not older than minimal supported one.
+type OrbisSociusCompliance struct {
+	OrbisSociusID   int64         `json:"orbis_socius_id"`
+	Compliant       bool          `json:"compliant"`
+	ProtocolVersion sql.NullInt64 `json:"protocol_version"`
 }
 
 type RobustnessStatus uint32
@@ -6,8 +6,20 @@ import (
 )
 
 type OrbisSociusStat struct {
-	ID            int64         `json:"id"`
-	CreatedAt     time.Time     `json:"created_at"`
-	OrbisSociusID sql.NullInt64 `json:"orbis_socius_id"`
-	Alive         bool          `json:"alive"`
+	ID              int64          `json:"id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	OrbisSociusID   sql.NullInt64  `json:"orbis_socius_id"`
+	Alive           bool           `json:"alive"`
+	Version         sql.NullString `json:"version"`
+	ProtocolVersion sql.NullInt64  `json:"protocol_version"`
+	Members         sql.NullInt64  `json:"members"`
+	Capacity        sql.NullInt64  `json:"capacity"`
+}
+
+// OrbisSociusHeartbeat is state reported by orbis socius instance.
+type OrbisSociusHeartbeat struct {
+	Version         string `json:"version"`
+	ProtocolVersion int    `json:"protocol_version"`
+	Members         int64  `json:"members"`
+	Capacity        int64  `json:"capacity"`
 }
@@ -0,0 +1,11 @@
+package zookeeper
+
+import "time"
+
+type OrbisSociusWebhook struct {
+	OrbisSociusID int64     `json:"orbis_socius_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	URL           string    `json:"url"`
+	Secret        string    `json:"secret"`
+}
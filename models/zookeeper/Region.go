@@ -0,0 +1,13 @@
+package zookeeper
+
+import "time"
+
+type Region struct {
+	Code               string    `json:"code"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Enabled            bool      `json:"enabled"`
+	DisplayName        string    `json:"display_name"`
+	DefaultTimezone    string    `json:"default_timezone"`
+	DataResidencyNotes string    `json:"data_residency_notes"`
+}
@@ -0,0 +1,39 @@
+package zookeeper
+
+import (
+	"database/sql"
+	"time"
+)
+
+type WebhookDelivery struct {
+	ID             int64                 `json:"id"`
+	CreatedAt      time.Time             `json:"created_at"`
+	OrbisSociusID  int64                 `json:"orbis_socius_id"`
+	Event          WebhookEvent          `json:"event"`
+	Data           []byte                `json:"data"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  sql.NullTime          `json:"next_attempt_at"`
+	LastAttemptAt  sql.NullTime          `json:"last_attempt_at"`
+	LastStatusCode sql.NullInt32         `json:"last_status_code"`
+	LastError      sql.NullString        `json:"last_error"`
+	DeliveredAt    sql.NullTime          `json:"delivered_at"`
+}
+
+type WebhookEvent string
+
+const (
+	PingWebhookEvent                         WebhookEvent = "webhook.ping"
+	OrbisSociusAPIKeyRotatedWebhookEvent     WebhookEvent = "orbis_socius.api_key_rotated"
+	OrbisSociusHealthChangedWebhookEvent     WebhookEvent = "orbis_socius.health_changed"
+	OrbisSociusDelistedWebhookEvent          WebhookEvent = "orbis_socius.delisted"
+	OrbisSociusComplianceChangedWebhookEvent WebhookEvent = "orbis_socius.compliance_changed"
+)
+
+type WebhookDeliveryStatus uint32
+
+const (
+	ScheduledWebhookDelivery WebhookDeliveryStatus = 0
+	DeliveredWebhookDelivery WebhookDeliveryStatus = 1
+	FailedWebhookDelivery    WebhookDeliveryStatus = 2
+)
@@ -63,3 +63,14 @@ func VerifyPassword(password string) error {
 	}
 	return nil
 }
+
+// VerifyAPIKey checks that API key of orbis socius is long enough to not be
+// guessed. Generated keys are 64 characters long.
+func VerifyAPIKey(apiKey string) error {
+	const minAPIKeyLength = 32
+	if len(strings.TrimSpace(apiKey)) < minAPIKeyLength {
+		return fmt.Errorf("api key must be at least %d characters long", minAPIKeyLength)
+	}
+
+	return nil
+}
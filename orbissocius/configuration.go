@@ -0,0 +1,36 @@
+package orbissocius
+
+import (
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
+	"github.com/ecumenos/ecumenos/orbissocius/config"
+	"go.uber.org/fx"
+)
+
+// Configuration supplies configuration of service and configurations of
+// shared modules derived from it.
+type Configuration struct {
+	fx.Out
+
+	Config        *config.Config
+	LoggerConfig  *fxlogger.Config
+	MetricsConfig *fxmetrics.Config
+	TracingConfig *fxtracing.Config
+	HealthConfig  *fxhealth.Config
+}
+
+func NewConfiguration(cfg *config.Config) Configuration {
+	return Configuration{
+		Config:        cfg,
+		LoggerConfig:  &fxlogger.Config{Prod: cfg.Prod},
+		MetricsConfig: &fxmetrics.Config{Addr: cfg.MetricsAddr},
+		TracingConfig: &fxtracing.Config{
+			Exporter:    cfg.TracingExporter,
+			FilePath:    cfg.TracingFile,
+			SampleRatio: cfg.TracingSampleRatio,
+		},
+		HealthConfig: &fxhealth.Config{DrainDelay: cfg.ReadinessDrainDelay},
+	}
+}
@@ -0,0 +1,14 @@
+begin;
+
+create table members
+(
+  id         bigint primary key,
+  created_at timestamp(0) with time zone default current_timestamp not null,
+  updated_at timestamp(0) with time zone default current_timestamp not null,
+  deleted_at timestamp(0) with time zone,
+  tombstoned boolean not null default false,
+  handle     text not null
+);
+create unique index members_handle_uindex on members (handle);
+
+commit;
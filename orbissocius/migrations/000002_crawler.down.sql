@@ -0,0 +1,10 @@
+begin;
+
+drop table if exists records cascade;
+drop table if exists crawl_hosts cascade;
+drop table if exists crawl_cursors cascade;
+
+alter table members
+  drop column if exists pds_url;
+
+commit;
@@ -0,0 +1,35 @@
+begin;
+
+alter table members
+  add column pds_url text;
+
+create table crawl_cursors
+(
+  member_id       bigint primary key references members (id),
+  updated_at      timestamp(0) with time zone default current_timestamp not null,
+  last_cursor     text,
+  last_crawled_at timestamp(0) with time zone,
+  last_error      text
+);
+
+create table crawl_hosts
+(
+  host          text primary key,
+  updated_at    timestamp(0) with time zone default current_timestamp not null,
+  failures      integer not null default 0,
+  backoff_until timestamp(0) with time zone,
+  last_error    text
+);
+
+create table records
+(
+  uri        text primary key,
+  member_id  bigint references members (id) not null,
+  collection text not null,
+  created_at timestamp(0) with time zone not null,
+  indexed_at timestamp(0) with time zone default current_timestamp not null,
+  data       jsonb not null
+);
+create index records_member_id_created_at_idx on records (member_id, created_at);
+
+commit;
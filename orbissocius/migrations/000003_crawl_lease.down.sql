@@ -0,0 +1,9 @@
+begin;
+
+alter table members
+  drop column if exists pds_url_checked_at;
+
+alter table crawl_cursors
+  drop column if exists locked_until;
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+alter table crawl_cursors
+  add column locked_until timestamp(0) with time zone;
+
+alter table members
+  add column pds_url_checked_at timestamp(0) with time zone;
+
+commit;
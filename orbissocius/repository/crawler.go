@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/errorsutils"
+	models "github.com/ecumenos/ecumenos/models/orbissocius"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetCrawlTargets returns members with known PDS which host is not in
+// backoff, the least recently crawled first.
+func (r *Repository) GetCrawlTargets(ctx context.Context, now time.Time, limit int) ([]*models.CrawlTarget, error) {
+	q := `
+  select
+    m.id, m.handle, m.pds_url, t.host, c.last_crawled_at
+  from members m
+  cross join lateral (select substring(m.pds_url from '^https?://([^/?#]+)') as host) t
+  left join crawl_cursors c on c.member_id = m.id
+  left join crawl_hosts h on h.host = t.host
+  where m.tombstoned=false and t.host is not null
+    and (h.backoff_until is null or h.backoff_until <= $1)
+  order by c.last_crawled_at nulls first, m.id
+  limit $2;`
+	rows, err := r.driver.QueryRows(ctx, q, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var targets []*models.CrawlTarget
+	for rows.Next() {
+		var t models.CrawlTarget
+		if err := rows.Scan(&t.MemberID, &t.Handle, &t.PDSURL, &t.Host, &t.LastCrawledAt); err != nil {
+			return nil, err
+		}
+		targets = append(targets, &t)
+	}
+
+	return targets, rows.Err()
+}
+
+// ClaimCrawlCursor returns cursor of member and leases it until given time,
+// so member is crawled by one replica. It returns false if cursor is leased by
+// another replica.
+func (r *Repository) ClaimCrawlCursor(ctx context.Context, memberID int64, now, lockedUntil time.Time) (sql.NullString, bool, error) {
+	var (
+		cursor  sql.NullString
+		claimed bool
+	)
+	err := r.driver.InTx(ctx, func(ctx context.Context) error {
+		query := `insert into crawl_cursors
+  (member_id, updated_at)
+  values ($1, $2)
+  on conflict (member_id) do nothing;`
+		if err := r.driver.ExecuteQuery(ctx, query, memberID, now); err != nil {
+			return err
+		}
+
+		q := `
+  update crawl_cursors
+  set locked_until = $3
+  where member_id=$1 and (locked_until is null or locked_until <= $2)
+  returning last_cursor;`
+		row, err := r.driver.QueryRow(ctx, q, memberID, now, lockedUntil)
+		if err != nil {
+			return err
+		}
+		if err := row.Scan(&cursor); err != nil {
+			if errorsutils.Equals(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		claimed = true
+
+		return nil
+	})
+	if err != nil {
+		return sql.NullString{}, false, err
+	}
+
+	return cursor, claimed, nil
+}
+
+// SetCrawlCursor stores cursor of the last indexed record and releases lease
+// of member. Nil cursor keeps the previous one. It returns false if cursor was
+// moved from prev by another replica after lease of this one expired.
+func (r *Repository) SetCrawlCursor(ctx context.Context, memberID int64, prev sql.NullString, cursor *string, crawledAt time.Time) (bool, error) {
+	q := `
+  with updated as (
+    update crawl_cursors
+    set updated_at = $2, last_cursor = coalesce($3, last_cursor), last_crawled_at = $2, last_error = null, locked_until = null
+    where member_id=$1 and last_cursor is not distinct from $4
+    returning 1
+  )
+  select count(*) from updated;`
+	count, err := r.driver.CountRows(ctx, q, memberID, crawledAt, cursor, prev)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// ReleaseCrawlCursor releases lease of member, so it is crawled again without
+// waiting for lease to expire.
+func (r *Repository) ReleaseCrawlCursor(ctx context.Context, memberID int64) error {
+	return r.driver.ExecuteQuery(ctx, "update crawl_cursors set locked_until = null where member_id=$1", memberID)
+}
+
+func (r *Repository) SetCrawlCursorError(ctx context.Context, memberID int64, crawledAt time.Time, lastError string) error {
+	query := `insert into crawl_cursors
+  (member_id, updated_at, last_crawled_at, last_error)
+  values ($1, $2, $2, $3)
+  on conflict (member_id) do update
+  set updated_at = excluded.updated_at, last_crawled_at = excluded.last_crawled_at, last_error = excluded.last_error, locked_until = null;`
+
+	return r.driver.ExecuteQuery(ctx, query, memberID, crawledAt, lastError)
+}
+
+// UpsertRecord inserts record or updates it if it was changed on PDS.
+func (r *Repository) UpsertRecord(ctx context.Context, rec *models.Record) error {
+	query := `insert into records
+  (uri, member_id, collection, created_at, indexed_at, data)
+  values ($1, $2, $3, $4, $5, $6)
+  on conflict (uri) do update
+  set collection = excluded.collection, created_at = excluded.created_at, indexed_at = excluded.indexed_at, data = excluded.data
+  where records.member_id = excluded.member_id;`
+
+	return r.driver.ExecuteQuery(ctx, query, rec.URI, rec.MemberID, rec.Collection, rec.CreatedAt, rec.IndexedAt, string(rec.Data))
+}
+
+func (r *Repository) GetCrawlHosts(ctx context.Context) ([]*models.CrawlHost, error) {
+	q := `
+  select
+    host, updated_at, failures, backoff_until, last_error
+  from crawl_hosts;`
+	rows, err := r.driver.QueryRows(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hosts []*models.CrawlHost
+	for rows.Next() {
+		var h models.CrawlHost
+		if err := rows.Scan(&h.Host, &h.UpdatedAt, &h.Failures, &h.BackoffUntil, &h.LastError); err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, &h)
+	}
+
+	return hosts, rows.Err()
+}
+
+func (r *Repository) UpsertCrawlHost(ctx context.Context, h *models.CrawlHost) error {
+	query := `insert into crawl_hosts
+  (host, updated_at, failures, backoff_until, last_error)
+  values ($1, $2, $3, $4, $5)
+  on conflict (host) do update
+  set updated_at = excluded.updated_at, failures = excluded.failures, backoff_until = excluded.backoff_until, last_error = excluded.last_error;`
+
+	return r.driver.ExecuteQuery(ctx, query, h.Host, h.UpdatedAt, h.Failures, h.BackoffUntil, h.LastError)
+}
@@ -1,7 +1,14 @@
 package repository
 
-import "go.uber.org/fx"
+import (
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"go.uber.org/fx"
+)
 
 var Module = fx.Options(
 	fx.Provide(New),
+	fx.Provide(
+		fxhealth.AsCheck(newPostgresCheck),
+		fxhealth.AsCheck(newMigrationsCheck),
+	),
 )
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	models "github.com/ecumenos/ecumenos/models/orbissocius"
+)
+
+func (r *Repository) CountMembers(ctx context.Context) (int, error) {
+	q := `select count(*) from members where tombstoned=false;`
+	return r.driver.CountRows(ctx, q)
+}
+
+// GetPDSDiscoveryTargets returns members which PDS was not discovered since
+// given time, the least recently checked first.
+func (r *Repository) GetPDSDiscoveryTargets(ctx context.Context, checkedBefore time.Time, limit int) ([]*models.Member, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, handle, pds_url
+  from members
+  where tombstoned=false and (pds_url_checked_at is null or pds_url_checked_at < $1)
+  order by pds_url_checked_at nulls first, id
+  limit $2;`
+	rows, err := r.driver.QueryRows(ctx, q, checkedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*models.Member
+	for rows.Next() {
+		var m models.Member
+		if err := rows.Scan(&m.ID, &m.CreatedAt, &m.UpdatedAt, &m.DeletedAt, &m.Tombstoned, &m.Handle, &m.PDSURL); err != nil {
+			return nil, err
+		}
+		members = append(members, &m)
+	}
+
+	return members, rows.Err()
+}
+
+// SetMemberPDSURL stores discovered PDS URL of member. Null URL keeps URL set
+// by operator.
+func (r *Repository) SetMemberPDSURL(ctx context.Context, id int64, pdsURL sql.NullString, checkedAt time.Time) error {
+	query := `update members
+  set pds_url = coalesce($2, pds_url), pds_url_checked_at = $3
+  where id=$1;`
+
+	return r.driver.ExecuteQuery(ctx, query, id, pdsURL, checkedAt)
+}
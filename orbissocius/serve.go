@@ -0,0 +1,52 @@
+package orbissocius
+
+import (
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
+	"github.com/ecumenos/ecumenos/internal/zerodowntime"
+	"github.com/ecumenos/ecumenos/orbissocius/admin"
+	"github.com/ecumenos/ecumenos/orbissocius/app"
+	"github.com/ecumenos/ecumenos/orbissocius/config"
+	"github.com/ecumenos/ecumenos/orbissocius/service"
+	"go.uber.org/fx"
+)
+
+// ServerOptions builds fx app serving orbissocius. Servers are started by
+// ServeApp, ServeAdmin or ServeAll. Background jobs run only in the process
+// which serves app, so api and admin processes don't both register in
+// zookeeper and crawl members.
+func ServerOptions(cfg *config.Config) fx.Option {
+	return fx.Options(
+		fx.Provide(func() Configuration {
+			return NewConfiguration(cfg)
+		}),
+		Module,
+		fxlogger.Module,
+		fxmetrics.Module,
+		fxtracing.Module,
+		fxhealth.Module,
+		fx.StopTimeout(cfg.StopTimeout()),
+	)
+}
+
+// appJobs are background jobs of the process which serves app.
+var appJobs = fx.Options(
+	fx.Invoke(service.RunZookeeperHeartbeat),
+	fx.Invoke(service.RunCrawler),
+)
+
+var (
+	ServeApp = fx.Options(appJobs, fx.Invoke(func(lc fx.Lifecycle, s *app.Server) {
+		zerodowntime.Serve(lc, s)
+	}))
+	ServeAdmin = fx.Invoke(func(lc fx.Lifecycle, s *admin.Server) {
+		zerodowntime.Serve(lc, s)
+	})
+	// ServeAll runs both servers in one process, so they share repository
+	// and its connection pool.
+	ServeAll = fx.Options(appJobs, fx.Invoke(func(lc fx.Lifecycle, appServer *app.Server, adminServer *admin.Server) {
+		zerodowntime.Serve(lc, appServer, adminServer)
+	}))
+)
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	gen "github.com/ecumenos/ecumenos/internal/generated/pds"
+	models "github.com/ecumenos/ecumenos/models/orbissocius"
+	"github.com/ecumenos/ecumenos/orbissocius/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	crawlTargetsLimit   = 500
+	crawlMaxPages       = 10
+	crawlMaxErrorLength = 512
+)
+
+// crawlHost is host of PDS crawled during one round. Failed host is not
+// called until end of the round and then backs off.
+type crawlHost struct {
+	state  *models.CrawlHost
+	failed atomic.Bool
+	err    error
+}
+
+// Crawl fetches new public records of members from their PDS instances. Every
+// host is called by at most crawlHostConcurrency workers at once. It returns
+// number of indexed records.
+func (s *Service) Crawl(ctx context.Context) (int, error) {
+	now := time.Now()
+	targets, err := s.repo.GetCrawlTargets(ctx, now, crawlTargetsLimit)
+	if err != nil {
+		return 0, err
+	}
+	states, err := s.repo.GetCrawlHosts(ctx)
+	if err != nil {
+		return 0, err
+	}
+	hosts := make(map[string]*crawlHost)
+	for _, h := range states {
+		hosts[h.Host] = &crawlHost{state: h}
+	}
+	queues := make(map[string][]*models.CrawlTarget)
+	for _, t := range targets {
+		if _, ok := hosts[t.Host]; !ok {
+			hosts[t.Host] = &crawlHost{state: &models.CrawlHost{Host: t.Host}}
+		}
+		queues[t.Host] = append(queues[t.Host], t)
+	}
+
+	var indexed atomic.Int64
+	var wg sync.WaitGroup
+	for name, queue := range queues {
+		host := hosts[name]
+		ch := make(chan *models.CrawlTarget, len(queue))
+		for _, t := range queue {
+			ch <- t
+		}
+		close(ch)
+		workers := s.crawlHostConcurrency
+		if workers > len(queue) {
+			workers = len(queue)
+		}
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for t := range ch {
+					if host.failed.Load() || ctx.Err() != nil {
+						return
+					}
+					n, err := s.crawlMember(ctx, t)
+					indexed.Add(int64(n))
+					s.metrics.indexedRecords.Add(float64(n))
+					if err != nil {
+						s.handleCrawlError(ctx, host, t, err)
+					}
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	for name := range queues {
+		if err := s.updateCrawlHost(ctx, hosts[name]); err != nil {
+			fxlogger.FromContext(ctx, s.logger).Error("can not update state of crawled host", zap.String("host", name), zap.Error(err))
+		}
+	}
+
+	return int(indexed.Load()), nil
+}
+
+// errCrawlCursorMoved rolls back page which was stored by another replica.
+var errCrawlCursorMoved = errors.New("crawl cursor is moved by another replica")
+
+// crawlMember fetches pages of member records after stored cursor. Cursor is
+// leased while page is fetched outside of transaction, then page is stored
+// with its cursor in one short transaction. Records are fetched again only if
+// they were not stored.
+func (s *Service) crawlMember(ctx context.Context, t *models.CrawlTarget) (int, error) {
+	c, err := gen.NewTypedClient(t.PDSURL, gen.WithHTTPClient(s.crawlClient))
+	if err != nil {
+		return 0, err
+	}
+
+	var indexed int
+	for page := 0; page < crawlMaxPages; page++ {
+		now := time.Now()
+		cursor, ok, err := s.repo.ClaimCrawlCursor(ctx, t.MemberID, now, now.Add(s.crawlLease))
+		if err != nil {
+			return indexed, err
+		}
+		if !ok {
+			// member is crawled by another replica.
+			break
+		}
+
+		params := &gen.ListRecordsParams{Author: &t.Handle, Limit: &s.crawlPageSize}
+		if cursor.Valid {
+			params.Cursor = &cursor.String
+		}
+		p, err := c.ListRecords(ctx, params)
+		if err != nil {
+			if err := s.repo.ReleaseCrawlCursor(ctx, t.MemberID); err != nil {
+				fxlogger.FromContext(ctx, s.logger).Error("can not release crawl cursor", zap.Int64("member_id", t.MemberID), zap.Error(err))
+			}
+			return indexed, err
+		}
+		s.metrics.crawlRequests.WithLabelValues("fetched").Inc()
+
+		stored, err := s.storeCrawledPage(ctx, t, cursor, p)
+		if errors.Is(err, errCrawlCursorMoved) {
+			break
+		}
+		if err != nil {
+			return indexed, err
+		}
+		indexed += stored
+		if len(p.Records) < s.crawlPageSize || p.Cursor == nil {
+			break
+		}
+	}
+
+	return indexed, nil
+}
+
+// storeCrawledPage stores records of page and moves cursor of member from
+// prev to cursor of page. It returns number of stored records.
+func (s *Service) storeCrawledPage(ctx context.Context, t *models.CrawlTarget, prev sql.NullString, p gen.RecordsPage) (int, error) {
+	var stored int
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		stored = 0
+		now := time.Now()
+		for _, r := range p.Records {
+			// PDS must not return records of other authors, they are not
+			// trusted to be records of member.
+			if r.Author != t.Handle {
+				continue
+			}
+			data, err := json.Marshal(r.Data)
+			if err != nil {
+				return err
+			}
+			err = s.repo.UpsertRecord(ctx, &models.Record{
+				URI:        r.Uri,
+				MemberID:   t.MemberID,
+				Collection: r.Collection,
+				CreatedAt:  r.CreatedAt,
+				IndexedAt:  now,
+				Data:       data,
+			})
+			if err != nil {
+				return err
+			}
+			stored++
+		}
+		moved, err := s.repo.SetCrawlCursor(ctx, t.MemberID, prev, p.Cursor, now)
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return errCrawlCursorMoved
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return stored, nil
+}
+
+// handleCrawlError stores error of member. Host backs off if it is not
+// available or rate limits crawler, rejected requests of single member don't
+// affect host.
+func (s *Service) handleCrawlError(ctx context.Context, host *crawlHost, t *models.CrawlTarget, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	logger := fxlogger.FromContext(ctx, s.logger).With(zap.Int64("member_id", t.MemberID), zap.String("host", t.Host))
+	if isHostError(err) {
+		s.metrics.crawlRequests.WithLabelValues("failed").Inc()
+		if host.failed.CompareAndSwap(false, true) {
+			host.err = err
+			logger.Warn("PDS host is not available, crawler backs off", zap.Error(err))
+		}
+		return
+	}
+
+	s.metrics.crawlRequests.WithLabelValues("rejected").Inc()
+	logger.Warn("can not crawl records of member", zap.Error(err))
+	if err := s.repo.SetCrawlCursorError(ctx, t.MemberID, time.Now(), truncateCrawlError(err)); err != nil {
+		logger.Error("can not store crawl error of member", zap.Error(err))
+	}
+}
+
+func isHostError(err error) bool {
+	var apiErr *apiclient.Error
+	if !errors.As(err, &apiErr) {
+		// network error or invalid response.
+		return true
+	}
+
+	return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+// updateCrawlHost stores backoff of failed host or resets it after success.
+func (s *Service) updateCrawlHost(ctx context.Context, host *crawlHost) error {
+	h := host.state
+	if !host.failed.Load() {
+		if h.Failures == 0 {
+			return nil
+		}
+		h.Failures = 0
+		h.BackoffUntil = sql.NullTime{}
+		h.LastError = sql.NullString{}
+	} else {
+		h.Failures++
+		h.BackoffUntil = sql.NullTime{Time: time.Now().Add(s.crawlBackoff(h.Failures)), Valid: true}
+		h.LastError = sql.NullString{String: truncateCrawlError(host.err), Valid: true}
+	}
+	h.UpdatedAt = time.Now()
+
+	return s.repo.UpsertCrawlHost(ctx, h)
+}
+
+// crawlBackoff doubles delay after every failed round.
+func (s *Service) crawlBackoff(failures int) time.Duration {
+	delay := s.crawlMinBackoff
+	for i := 1; i < failures && delay < s.crawlMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.crawlMaxBackoff {
+		return s.crawlMaxBackoff
+	}
+
+	return delay
+}
+
+func truncateCrawlError(err error) string {
+	msg := err.Error()
+	if len(msg) > crawlMaxErrorLength {
+		msg = msg[:crawlMaxErrorLength]
+	}
+
+	return msg
+}
+
+// RunCrawler discovers PDS of members and crawls PDS instances while app is
+// running. Crawler is disabled if crawl interval is 0.
+func RunCrawler(lc fx.Lifecycle, cfg *config.Config, s *Service, jobs *fxhealth.Jobs, logger *zap.Logger) {
+	if cfg.CrawlInterval == 0 {
+		return
+	}
+
+	logger = logger.With(zap.String("job", "crawler"))
+	job := jobs.Register("crawler", cfg.CrawlInterval)
+	ctx, cancel := context.WithCancel(fxlogger.WithContext(context.Background(), logger))
+	stopped := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(stopped)
+				ticker := time.NewTicker(cfg.CrawlInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+					if cfg.CrawlDiscoveryInterval > 0 {
+						if _, err := s.DiscoverPDS(ctx); err != nil {
+							logger.Error("can not discover PDS of members", zap.Error(err))
+						}
+					}
+					count, err := s.Crawl(ctx)
+					job.Tick(cfg.CrawlInterval)
+					if err != nil {
+						logger.Error("can not crawl PDS instances", zap.Error(err))
+						continue
+					}
+					if count > 0 {
+						logger.Info("records are indexed by crawler", zap.Int("count", count))
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-stopped:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
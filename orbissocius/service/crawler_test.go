@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	gen "github.com/ecumenos/ecumenos/internal/generated/pds"
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	models "github.com/ecumenos/ecumenos/models/orbissocius"
+	"github.com/ecumenos/ecumenos/orbissocius/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// crawlDB is state of crawler tables served by fake driver.
+type crawlDB struct {
+	mu      sync.Mutex
+	members []string
+	host    string
+	pdsURL  string
+	hosts   [][]interface{}
+	cursors map[int64]sql.NullString
+	leased  map[int64]bool
+	// moved makes cursors look moved by another replica.
+	moved bool
+}
+
+func (db *crawlDB) driver() *pgxtest.Driver {
+	return &pgxtest.Driver{
+		OnQueryRows: func(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+			db.mu.Lock()
+			defer db.mu.Unlock()
+			switch {
+			case strings.Contains(query, "from members m"):
+				var rows [][]interface{}
+				for i, handle := range db.members {
+					rows = append(rows, []interface{}{int64(i + 1), handle, db.pdsURL, db.host, nil})
+				}
+				return pgxtest.Rows(rows...), nil
+			case strings.Contains(query, "from crawl_hosts"):
+				return pgxtest.Rows(db.hosts...), nil
+			}
+			return pgxtest.Rows(), nil
+		},
+		OnQueryRow: func(_ context.Context, query string, args ...interface{}) (pgx.Row, error) {
+			db.mu.Lock()
+			defer db.mu.Unlock()
+			if strings.Contains(query, "set locked_until = $3") {
+				id := args[0].(int64)
+				if db.leased[id] {
+					return pgxtest.NoRows(), nil
+				}
+				if cursor := db.cursors[id]; cursor.Valid {
+					return pgxtest.Row(cursor.String), nil
+				}
+				return pgxtest.Row(nil), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+		OnCountRows: func(_ context.Context, query string, args ...interface{}) (int, error) {
+			db.mu.Lock()
+			defer db.mu.Unlock()
+			if !strings.Contains(query, "update crawl_cursors") {
+				return 0, nil
+			}
+			id := args[0].(int64)
+			if db.moved || db.cursors[id] != args[3].(sql.NullString) {
+				return 0, nil
+			}
+			if cursor := args[2].(*string); cursor != nil {
+				db.cursors[id] = sql.NullString{String: *cursor, Valid: true}
+			}
+			return 1, nil
+		},
+	}
+}
+
+// newPDS serves records of authors, cursor is index of the last record.
+func newPDS(t *testing.T, records map[string]int, handle func(rw http.ResponseWriter, r *http.Request) bool) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if handle != nil && !handle(rw, r) {
+			return
+		}
+		author := r.URL.Query().Get("author")
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		after, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		page := gen.RecordsPage{Records: []gen.Record{}}
+		for i := after + 1; i <= records[author] && len(page.Records) < limit; i++ {
+			page.Records = append(page.Records, gen.Record{
+				Uri:        fmt.Sprintf("ecumenos://%v/%v", author, i),
+				Author:     author,
+				Collection: "post",
+				CreatedAt:  time.Now(),
+				Data:       map[string]interface{}{"text": "hello"},
+			})
+			cursor := strconv.Itoa(i)
+			page.Cursor = &cursor
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"status": "success", "data": page})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newCrawlTestService(t *testing.T, driver *pgxtest.Driver, srv *httptest.Server) *Service {
+	s := newTestService(t, driver)
+	s.crawlClient = srv.Client()
+	s.crawlHostConcurrency = 2
+	s.crawlPageSize = 2
+	s.crawlMinBackoff = time.Minute
+	s.crawlMaxBackoff = time.Hour
+	s.crawlLease = time.Minute
+
+	return s
+}
+
+func newCrawlDB(srv *httptest.Server, members ...string) *crawlDB {
+	return &crawlDB{
+		members: members,
+		host:    strings.TrimPrefix(srv.URL, "http://"),
+		pdsURL:  srv.URL,
+		cursors: map[int64]sql.NullString{},
+		leased:  map[int64]bool{},
+	}
+}
+
+func TestCrawlAdvancesCursor(t *testing.T) {
+	srv := newPDS(t, map[string]int{"alice": 3}, nil)
+	db := newCrawlDB(srv, "alice")
+	driver := db.driver()
+	s := newCrawlTestService(t, driver, srv)
+
+	indexed, err := s.Crawl(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, indexed)
+	assert.Equal(t, sql.NullString{String: "3", Valid: true}, db.cursors[1])
+
+	var claims, cursors []pgxtest.Query
+	for _, q := range driver.Committed() {
+		switch {
+		case strings.Contains(q.SQL, "set locked_until = $3"):
+			claims = append(claims, q)
+		case strings.Contains(q.SQL, "update crawl_cursors"):
+			cursors = append(cursors, q)
+		}
+	}
+	require.Len(t, cursors, 2)
+	assert.Equal(t, sql.NullString{}, cursors[0].Args[3])
+	assert.Equal(t, "2", *cursors[0].Args[2].(*string))
+	assert.Equal(t, sql.NullString{String: "2", Valid: true}, cursors[1].Args[3])
+	assert.Equal(t, "3", *cursors[1].Args[2].(*string))
+	require.Len(t, claims, 2)
+	assert.NotEqual(t, claims[0].Tx, cursors[0].Tx, "page is fetched in transaction of lease")
+
+	// the next round fetches only new records.
+	indexed, err = s.Crawl(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+}
+
+func TestCrawlSkipsMemberLeasedByAnotherReplica(t *testing.T) {
+	var requests int
+	srv := newPDS(t, map[string]int{"alice": 3}, func(http.ResponseWriter, *http.Request) bool {
+		requests++
+		return true
+	})
+	db := newCrawlDB(srv, "alice")
+	db.leased[1] = true
+	s := newCrawlTestService(t, db.driver(), srv)
+
+	indexed, err := s.Crawl(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+	assert.Zero(t, requests)
+}
+
+func TestCrawlDropsPageStoredByAnotherReplica(t *testing.T) {
+	srv := newPDS(t, map[string]int{"alice": 3}, nil)
+	db := newCrawlDB(srv, "alice")
+	db.moved = true
+	driver := db.driver()
+	s := newCrawlTestService(t, driver, srv)
+
+	indexed, err := s.Crawl(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+	for _, q := range driver.Committed() {
+		assert.NotContains(t, q.SQL, "insert into records")
+	}
+}
+
+func TestCrawlSkipsRecordsOfOtherAuthors(t *testing.T) {
+	srv := newPDS(t, map[string]int{"alice": 1}, func(_ http.ResponseWriter, r *http.Request) bool {
+		q := r.URL.Query()
+		q.Set("author", "alice")
+		r.URL.RawQuery = q.Encode()
+		return true
+	})
+	db := newCrawlDB(srv, "bob")
+	driver := db.driver()
+	s := newCrawlTestService(t, driver, srv)
+
+	indexed, err := s.Crawl(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+}
+
+func TestCrawlLimitsConcurrencyPerHost(t *testing.T) {
+	var (
+		mu               sync.Mutex
+		inFlight, maxOut int
+	)
+	srv := newPDS(t, map[string]int{}, func(http.ResponseWriter, *http.Request) bool {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxOut {
+			maxOut = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return true
+	})
+	db := newCrawlDB(srv, "a", "b", "c", "d", "e", "f")
+	s := newCrawlTestService(t, db.driver(), srv)
+
+	_, err := s.Crawl(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, s.crawlHostConcurrency, maxOut)
+	assert.Len(t, db.cursors, 0, "members without records have no cursors")
+}
+
+func TestCrawlBacksOffFailedHost(t *testing.T) {
+	var requests int
+	srv := newPDS(t, nil, func(rw http.ResponseWriter, _ *http.Request) bool {
+		requests++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return false
+	})
+	db := newCrawlDB(srv, "alice", "bob", "carol")
+	db.hosts = [][]interface{}{{db.host, time.Now(), 1, time.Now().Add(-time.Minute), "unavailable"}}
+	driver := db.driver()
+	s := newCrawlTestService(t, driver, srv)
+	s.crawlHostConcurrency = 1
+
+	_, err := s.Crawl(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests, "failed host is called again in the same round")
+
+	var released, hosts []pgxtest.Query
+	for _, q := range driver.Committed() {
+		switch {
+		case strings.Contains(q.SQL, "set locked_until = null"):
+			released = append(released, q)
+		case strings.Contains(q.SQL, "insert into crawl_hosts"):
+			hosts = append(hosts, q)
+		}
+	}
+	assert.Len(t, released, 1, "lease of failed member is not released")
+	require.Len(t, hosts, 1)
+	assert.Equal(t, 2, hosts[0].Args[2])
+	backoffUntil := hosts[0].Args[3].(sql.NullTime)
+	require.True(t, backoffUntil.Valid)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), backoffUntil.Time, 5*time.Second)
+}
+
+func TestCrawlResetsBackoffOfRecoveredHost(t *testing.T) {
+	srv := newPDS(t, map[string]int{"alice": 1}, nil)
+	db := newCrawlDB(srv, "alice")
+	db.hosts = [][]interface{}{{db.host, time.Now(), 3, time.Now().Add(-time.Minute), "unavailable"}}
+	driver := db.driver()
+	s := newCrawlTestService(t, driver, srv)
+
+	_, err := s.Crawl(context.Background())
+	require.NoError(t, err)
+	var hosts []pgxtest.Query
+	for _, q := range driver.Committed() {
+		if strings.Contains(q.SQL, "insert into crawl_hosts") {
+			hosts = append(hosts, q)
+		}
+	}
+	require.Len(t, hosts, 1)
+	assert.Equal(t, 0, hosts[0].Args[2])
+	assert.False(t, hosts[0].Args[3].(sql.NullTime).Valid)
+}
+
+func TestCrawlBackoff(t *testing.T) {
+	s := &Service{crawlMinBackoff: time.Minute, crawlMaxBackoff: 10 * time.Minute}
+	for failures, want := range map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		4:  8 * time.Minute,
+		5:  10 * time.Minute,
+		40: 10 * time.Minute,
+	} {
+		assert.Equal(t, want, s.crawlBackoff(failures), "failures = %v", failures)
+	}
+}
+
+func TestCrawlClientRefusesInternalAddresses(t *testing.T) {
+	var requests int
+	srv := newPDS(t, map[string]int{"alice": 1}, func(http.ResponseWriter, *http.Request) bool {
+		requests++
+		return true
+	})
+	db := newCrawlDB(srv, "alice")
+	s := newTestService(t, db.driver())
+	s.crawlClient = newCrawlClient(&config.Config{CrawlTimeout: time.Second}, zap.NewNop())
+	s.crawlPageSize = 2
+	s.crawlLease = time.Minute
+
+	_, err := s.crawlMember(context.Background(), &models.CrawlTarget{MemberID: 1, Handle: "alice", PDSURL: srv.URL, Host: db.host})
+	assert.ErrorIs(t, err, netutils.ErrPrivateAddress)
+	_, err = s.resolvePDSURL(context.Background(), strings.TrimPrefix(srv.URL, "http://"))
+	assert.ErrorIs(t, err, netutils.ErrPrivateAddress)
+	assert.Zero(t, requests)
+}
+
+func TestCrawlClientDoesNotFollowRedirects(t *testing.T) {
+	var redirected bool
+	target := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		http.Redirect(rw, r, target.URL, http.StatusFound)
+	}))
+	defer srv.Close()
+	s := newTestService(t, &pgxtest.Driver{})
+	s.crawlClient = newCrawlClient(&config.Config{CrawlTimeout: time.Second, CrawlAllowPrivate: true}, zap.NewNop())
+
+	resp, err := s.crawlClient.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.False(t, redirected)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"go.uber.org/zap"
+)
+
+const (
+	// pdsWellKnownPath is served by domain of member handle, its body is URL
+	// of PDS of member.
+	pdsWellKnownPath      = "/.well-known/ecumenos-pds"
+	pdsDiscoveryLimit     = 100
+	pdsWellKnownMaxLength = 2048
+)
+
+// DiscoverPDS resolves PDS URLs of members which were not checked during
+// discovery interval. Member keeps URL set by operator if its handle doesn't
+// publish PDS. Member which discovery failed is checked again after crawl max
+// backoff instead of discovery interval. It returns number of discovered URLs.
+func (s *Service) DiscoverPDS(ctx context.Context) (int, error) {
+	now := time.Now()
+	members, err := s.repo.GetPDSDiscoveryTargets(ctx, now.Add(-s.pdsDiscoveryInterval), pdsDiscoveryLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	var discovered int
+	for _, m := range members {
+		if ctx.Err() != nil {
+			return discovered, ctx.Err()
+		}
+		checkedAt := now
+		pdsURL, err := s.resolvePDSURL(ctx, m.Handle)
+		if err != nil {
+			if ctx.Err() != nil {
+				return discovered, ctx.Err()
+			}
+			fxlogger.FromContext(ctx, s.logger).Warn("can not discover PDS of member", zap.Int64("member_id", m.ID), zap.Error(err))
+			checkedAt = s.pdsDiscoveryRetryAt(now)
+		}
+		if pdsURL != "" {
+			discovered++
+		}
+		if err := s.repo.SetMemberPDSURL(ctx, m.ID, sql.NullString{String: pdsURL, Valid: pdsURL != ""}, checkedAt); err != nil {
+			return discovered, err
+		}
+	}
+
+	return discovered, nil
+}
+
+// pdsDiscoveryRetryAt returns check time which makes member target of
+// discovery again after crawl max backoff.
+func (s *Service) pdsDiscoveryRetryAt(now time.Time) time.Time {
+	if s.crawlMaxBackoff >= s.pdsDiscoveryInterval {
+		return now
+	}
+	return now.Add(s.crawlMaxBackoff - s.pdsDiscoveryInterval)
+}
+
+// resolvePDSURL fetches PDS URL published by domain of handle. It returns
+// empty URL if handle is not domain name or domain doesn't publish PDS.
+// Published URL must be https URL.
+func (s *Service) resolvePDSURL(ctx context.Context, handle string) (string, error) {
+	if !strings.Contains(handle, ".") {
+		return "", nil
+	}
+	u, err := url.Parse("https://" + handle + pdsWellKnownPath)
+	if err != nil || u.Host != handle {
+		return "", nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.crawlClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status of PDS discovery (handle = %v, status = %v)", handle, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, pdsWellKnownMaxLength))
+	if err != nil {
+		return "", err
+	}
+	pdsURL := strings.TrimSpace(string(body))
+	pu, err := url.Parse(pdsURL)
+	if err != nil || pu.Scheme != "https" || pu.Host == "" {
+		return "", fmt.Errorf("invalid PDS URL is published (handle = %v, url = %q)", handle, pdsURL)
+	}
+
+	return pdsURL, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverPDS(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		status     int
+		body       string
+		want       sql.NullString
+		discovered int
+		failed     bool
+	}{
+		{name: "published", status: http.StatusOK, body: "https://pds.example.com\n", want: sql.NullString{String: "https://pds.example.com", Valid: true}, discovered: 1},
+		{name: "not published", status: http.StatusNotFound},
+		{name: "invalid URL", status: http.StatusOK, body: "ftp://pds.example.com", failed: true},
+		{name: "http URL", status: http.StatusOK, body: "http://169.254.169.254/latest", failed: true},
+		{name: "unavailable", status: http.StatusServiceUnavailable, failed: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, pdsWellKnownPath, r.URL.Path)
+				rw.WriteHeader(tc.status)
+				_, _ = rw.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+			handle := strings.TrimPrefix(srv.URL, "https://")
+			driver := &pgxtest.Driver{
+				OnQueryRows: func(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+					if strings.Contains(query, "from members") {
+						return pgxtest.Rows([]interface{}{int64(1), time.Now(), time.Now(), nil, false, handle, "https://manual.example.com"}), nil
+					}
+					return pgxtest.Rows(), nil
+				},
+			}
+			s := newTestService(t, driver)
+			s.crawlClient = srv.Client()
+			s.pdsDiscoveryInterval = time.Hour
+			s.crawlMaxBackoff = time.Minute
+
+			start := time.Now()
+			discovered, err := s.DiscoverPDS(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.discovered, discovered)
+
+			var updates []pgxtest.Query
+			for _, q := range driver.Queries() {
+				if strings.Contains(q.SQL, "update members") {
+					updates = append(updates, q)
+				}
+			}
+			require.Len(t, updates, 1, "member is not marked as checked")
+			assert.Equal(t, tc.want, updates[0].Args[1], "URL of operator is not kept")
+			checkedAt := updates[0].Args[2].(time.Time)
+			if tc.failed {
+				assert.WithinRange(t, checkedAt, start.Add(-time.Hour+time.Minute), time.Now().Add(-time.Hour+time.Minute), "failed member is not retried after backoff")
+			} else {
+				assert.WithinRange(t, checkedAt, start, time.Now())
+			}
+		})
+	}
+}
+
+func TestResolvePDSURLSkipsHandlesWhichAreNotDomains(t *testing.T) {
+	s := newTestService(t, &pgxtest.Driver{})
+	s.crawlClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Errorf("request is sent for handle which is not domain: %v", r.URL)
+		return nil, http.ErrUseLastResponse
+	})}
+
+	for _, handle := range []string{"alice", "alice.example.com/path", "alice@example.com"} {
+		pdsURL, err := s.resolvePDSURL(context.Background(), handle)
+		require.NoError(t, err)
+		assert.Empty(t, pdsURL)
+	}
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
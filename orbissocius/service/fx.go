@@ -1,21 +1,70 @@
 package service
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
 	"github.com/ecumenos/ecumenos/orbissocius/config"
 	"github.com/ecumenos/ecumenos/orbissocius/repository"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 type Service struct {
-	repo *repository.Repository
+	repo    *repository.Repository
+	metrics *metrics
+	logger  *zap.Logger
+
+	crawlClient          *http.Client
+	crawlHostConcurrency int
+	crawlPageSize        int
+	crawlMinBackoff      time.Duration
+	crawlMaxBackoff      time.Duration
+	crawlLease           time.Duration
+	pdsDiscoveryInterval time.Duration
 }
 
-func New(repo *repository.Repository, cfg *config.Config) *Service {
-	return &Service{
-		repo: repo,
+func New(repo *repository.Repository, cfg *config.Config, reg prometheus.Registerer, logger *zap.Logger) (*Service, error) {
+	m, err := newMetrics(reg)
+	if err != nil {
+		return nil, err
 	}
+
+	return &Service{
+		repo:    repo,
+		metrics: m,
+		logger:  logger,
+
+		crawlClient:          newCrawlClient(cfg, logger),
+		crawlHostConcurrency: cfg.CrawlHostConcurrency,
+		crawlPageSize:        cfg.CrawlPageSize,
+		crawlMinBackoff:      cfg.CrawlMinBackoff,
+		crawlMaxBackoff:      cfg.CrawlMaxBackoff,
+		// lease of member covers request to PDS limited by crawl timeout.
+		crawlLease:           2 * cfg.CrawlTimeout,
+		pdsDiscoveryInterval: cfg.CrawlDiscoveryInterval,
+	}, nil
 }
 
 var Module = fx.Options(
 	fx.Provide(New),
 )
+
+// newCrawlClient returns client which refuses to connect to internal network,
+// unless private addresses are allowed, and doesn't follow redirects. PDS URLs
+// are published by members, so they are not trusted. Failed host backs off
+// instead of retries.
+func newCrawlClient(cfg *config.Config, logger *zap.Logger) *http.Client {
+	opts := []httputils.ClientOption{
+		httputils.WithTimeout(cfg.CrawlTimeout),
+		httputils.WithRetryMax(0),
+		httputils.WithoutRedirects(),
+	}
+	if !cfg.CrawlAllowPrivate {
+		opts = append(opts, httputils.WithPublicAddressesOnly())
+	}
+
+	return httputils.RobustHTTPClient(logger, opts...)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	crawlRequests  *prometheus.CounterVec
+	indexedRecords prometheus.Counter
+}
+
+func newMetrics(reg prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		crawlRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "orbis_socius",
+			Name:      "crawl_requests_total",
+			Help:      "Number of requests of crawler to PDS by result: fetched, rejected by PDS or failed, which backs off host.",
+		}, []string{"result"}),
+		indexedRecords: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "orbis_socius",
+			Name:      "indexed_records_total",
+			Help:      "Number of records fetched from PDS and stored by crawler.",
+		}),
+	}
+	if err := reg.Register(m.crawlRequests); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.indexedRecords); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeper"
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/webhooks"
+	"github.com/ecumenos/ecumenos/orbissocius/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const registrationRetryInterval = 30 * time.Second
+
+// RegisterInZookeeper authenticates orbis socius in zookeeper by its API key.
+// The first registration redeems launch invite of the key.
+func (s *Service) RegisterInZookeeper(ctx context.Context, c *gen.TypedClient, apiKey string) (*gen.OrbisSociusRegistration, error) {
+	reg, err := c.RegisterOrbisSocius(ctx, func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &reg, nil
+}
+
+// SendHeartbeat reports state and protocol version of orbis socius to
+// zookeeper. Body is signed by API key, so the key itself is sent only on
+// registration.
+func (s *Service) SendHeartbeat(ctx context.Context, c *gen.TypedClient, id int64, apiKey string, capacity int64) error {
+	members, err := s.repo.CountMembers(ctx)
+	if err != nil {
+		return err
+	}
+	protocolVersion := int(fxtypes.CurrentProtocolVersion)
+	body, err := json.Marshal(gen.OrbisSociusHeartbeatRequest{
+		Version:         string(config.ServiceVersion),
+		ProtocolVersion: &protocolVersion,
+		Members:         int64(members),
+		Capacity:        capacity,
+	})
+	if err != nil {
+		return err
+	}
+	params := &gen.OrbisSociusHeartbeatParams{
+		EcumenosOrbisSocius: id,
+		EcumenosSignature:   webhooks.Sign([]byte(apiKey), time.Now(), body),
+	}
+
+	return c.OrbisSociusHeartbeatWithBody(ctx, params, "application/json", bytes.NewReader(body))
+}
+
+// RunZookeeperHeartbeat registers orbis socius in zookeeper and sends
+// heartbeats with interval requested by zookeeper while app is running. It is
+// disabled if zookeeper URL is not set.
+func RunZookeeperHeartbeat(lc fx.Lifecycle, cfg *config.Config, s *Service, jobs *fxhealth.Jobs, logger *zap.Logger) error {
+	if cfg.ZookeeperURL == "" {
+		logger.Info("zookeeper URL is not set, registration in zookeeper is disabled")
+		return nil
+	}
+	var clientOpts []httputils.ClientOption
+	if cfg.ZookeeperClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ZookeeperClientCertFile, cfg.ZookeeperClientKeyFile)
+		if err != nil {
+			return fmt.Errorf("can not load zookeeper client certificate (cert = %v, key = %v): %w", cfg.ZookeeperClientCertFile, cfg.ZookeeperClientKeyFile, err)
+		}
+		clientOpts = append(clientOpts, httputils.WithClientCertificate(cert))
+	}
+	doer := apiclient.NewDoer(logger, apiclient.WithHTTPClient(httputils.RobustHTTPClient(logger, clientOpts...)))
+	c, err := gen.NewTypedClient(cfg.ZookeeperURL, gen.WithHTTPClient(doer))
+	if err != nil {
+		return err
+	}
+
+	logger = logger.With(zap.String("job", "zookeeper_heartbeat"))
+	job := jobs.Register("zookeeper_heartbeat", registrationRetryInterval)
+	ctx, cancel := context.WithCancel(fxlogger.WithContext(context.Background(), logger))
+	stopped := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(stopped)
+				s.runZookeeperHeartbeat(ctx, c, cfg, job)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-stopped:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+
+	return nil
+}
+
+func (s *Service) runZookeeperHeartbeat(ctx context.Context, c *gen.TypedClient, cfg *config.Config, job *fxhealth.Job) {
+	logger := fxlogger.FromContext(ctx, s.logger)
+	var reg *gen.OrbisSociusRegistration
+	var wait time.Duration
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if reg == nil {
+			var err error
+			reg, err = s.RegisterInZookeeper(ctx, c, cfg.ZookeeperAPIKey)
+			if err != nil {
+				logger.Error("can not register in zookeeper", zap.Error(err))
+				wait = registrationRetryInterval
+				job.Tick(wait)
+				continue
+			}
+			logger.Info("registered in zookeeper",
+				zap.Int64("orbis_socius_id", reg.Id),
+				zap.Int("heartbeat_interval", reg.HeartbeatInterval))
+			if int(fxtypes.CurrentProtocolVersion) < reg.MinProtocolVersion {
+				logger.Warn("protocol version is not supported by zookeeper anymore, orbis socius is listed as non-compliant",
+					zap.Int("protocol_version", int(fxtypes.CurrentProtocolVersion)),
+					zap.Int("min_protocol_version", reg.MinProtocolVersion))
+			}
+		}
+
+		wait = time.Duration(reg.HeartbeatInterval) * time.Second
+		if err := s.SendHeartbeat(ctx, c, reg.Id, cfg.ZookeeperAPIKey, cfg.Capacity); err != nil {
+			logger.Error("can not send heartbeat to zookeeper", zap.Int64("orbis_socius_id", reg.Id), zap.Error(err))
+			// API key was rotated or orbis socius was removed, registration
+			// tells which one.
+			if errors.Is(err, apierrors.New(apierrors.OrbisSociusInvalidSignature, "")) {
+				reg = nil
+				wait = registrationRetryInterval
+			}
+		}
+		job.Tick(wait)
+	}
+}
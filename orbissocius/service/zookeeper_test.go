@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apiclient"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeper"
+	"github.com/ecumenos/ecumenos/internal/toolkit/webhooks"
+	"github.com/ecumenos/ecumenos/orbissocius/repository"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestService(t *testing.T, driver *pgxtest.Driver) *Service {
+	m, err := newMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	return &Service{
+		repo:    repository.NewWithDriver(driver, zap.NewNop()),
+		metrics: m,
+		logger:  zap.NewNop(),
+	}
+}
+
+func newZookeeperClient(t *testing.T, handler http.HandlerFunc) *gen.TypedClient {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := gen.NewTypedClient(srv.URL, gen.WithHTTPClient(apiclient.NewDoer(zap.NewNop(), apiclient.WithHTTPClient(srv.Client()))))
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestRegisterInZookeeper(t *testing.T) {
+	s := newTestService(t, &pgxtest.Driver{})
+	c := newZookeeperClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/orbes_socii/register", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(`{"status":"success","data":{"id":9,"heartbeat_interval":60,"min_protocol_version":1,"name":"name","region":"eu","url":"https://os.example.com"}}`))
+	})
+
+	reg, err := s.RegisterInZookeeper(context.Background(), c, "key")
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), reg.Id)
+	assert.Equal(t, 60, reg.HeartbeatInterval)
+}
+
+func TestSendHeartbeatIsSignedByAPIKey(t *testing.T) {
+	driver := &pgxtest.Driver{
+		OnCountRows: func(context.Context, string, ...interface{}) (int, error) {
+			return 4, nil
+		},
+	}
+	s := newTestService(t, driver)
+	c := newZookeeperClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/orbes_socii/heartbeat", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"), "API key is sent with heartbeat")
+		assert.Equal(t, "9", r.Header.Get("Ecumenos-Orbis-Socius"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.NoError(t, webhooks.Verify([]byte("key"), r.Header.Get("Ecumenos-Signature"), body, webhooks.DefaultTolerance, time.Now()))
+		assert.Error(t, webhooks.Verify([]byte("another"), r.Header.Get("Ecumenos-Signature"), body, webhooks.DefaultTolerance, time.Now()))
+
+		var heartbeat gen.OrbisSociusHeartbeatRequest
+		require.NoError(t, json.Unmarshal(body, &heartbeat))
+		assert.Equal(t, int64(4), heartbeat.Members)
+		assert.Equal(t, int64(10), heartbeat.Capacity)
+		assert.NotNil(t, heartbeat.ProtocolVersion)
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, s.SendHeartbeat(context.Background(), c, 9, "key", 10))
+}
@@ -1,11 +1,14 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	"github.com/ecumenos/ecumenos/internal/docs"
 	"github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
 	gen "github.com/ecumenos/ecumenos/internal/generated/pds"
 	"github.com/ecumenos/ecumenos/internal/openapi"
 	"github.com/ecumenos/ecumenos/pds/config"
@@ -53,9 +56,10 @@ func (h *handler) GetInfo(rw http.ResponseWriter, r *http.Request) {
 	deps := h.service.PingServices(ctx)
 	writer := h.responseFactory.NewWriter(rw)
 	_ = writer.WriteSuccess(ctx, gen.GetInfoData{ //nolint:errcheck
-		Name:    string(config.ServiceName),
-		Version: string(config.ServiceVersion),
-		Deps:    deps,
+		Name:            string(config.ServiceName),
+		Version:         string(config.ServiceVersion),
+		ProtocolVersion: int(fxtypes.CurrentProtocolVersion),
+		Deps:            deps,
 	})
 }
 
@@ -65,3 +69,49 @@ func (h *handler) GetSpecs(rw http.ResponseWriter, r *http.Request) {
 	rw.Header().Add("Content-Disposition", fmt.Sprintf(`attachment; filename="%v"`, filename))
 	_, _ = rw.Write(openapi.PDSSpec(h.selfURL))
 }
+
+const (
+	defaultRecordsLimit = 50
+	maxRecordsLimit     = 100
+)
+
+func (h *handler) ListRecords(rw http.ResponseWriter, r *http.Request, params gen.ListRecordsParams) {
+	ctx := r.Context()
+	writer := h.responseFactory.NewWriter(rw)
+	limit := defaultRecordsLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxRecordsLimit {
+		_ = writer.WriteAPIError(ctx, apierrors.Validation(apierrors.Field("query.limit", apierrors.FieldInvalid, "limit must be between 1 and 100"))) //nolint:errcheck
+		return
+	}
+	var author, cursor string
+	if params.Author != nil {
+		author = *params.Author
+	}
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+	page, err := h.service.ListRecords(ctx, author, cursor, limit)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	out := gen.RecordsPage{Records: make([]gen.Record, 0, len(page.Records)), Cursor: page.Cursor}
+	for _, rec := range page.Records {
+		var data map[string]interface{}
+		if err := json.Unmarshal(rec.Data, &data); err != nil {
+			_ = writer.WriteError(ctx, "can not decode record", err) //nolint:errcheck
+			return
+		}
+		out.Records = append(out.Records, gen.Record{
+			Uri:        rec.URI,
+			Author:     rec.Author,
+			Collection: rec.Collection,
+			CreatedAt:  rec.CreatedAt,
+			Data:       data,
+		})
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
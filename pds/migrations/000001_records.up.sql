@@ -0,0 +1,16 @@
+begin;
+
+create table records
+(
+  id         bigserial primary key,
+  uri        text not null,
+  author     text not null,
+  collection text not null,
+  created_at timestamp(0) with time zone not null,
+  indexed_at timestamp(0) with time zone default current_timestamp not null,
+  data       jsonb not null
+);
+create unique index records_uri_uindex on records (uri);
+create index records_author_id_idx on records (author, id);
+
+commit;
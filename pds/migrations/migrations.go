@@ -0,0 +1,8 @@
+// Package migrations contains migrations of pds database. They are embedded
+// in binary, so images don't need migration files.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres"
+	"github.com/ecumenos/ecumenos/pds/config"
+	"github.com/ecumenos/ecumenos/pds/migrations"
+)
+
+func newPostgresCheck(r *Repository) fxhealth.Check {
+	return fxpostgres.NewPingCheck(r.driver)
+}
+
+func newMigrationsCheck(cfg *config.Config, r *Repository) fxhealth.Check {
+	return fxpostgres.NewMigrationsCheck(r.driver, cfg.PostgresMigrationsPath, migrations.FS)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	models "github.com/ecumenos/ecumenos/models/pds"
+)
+
+// GetRecords returns records indexed after record with given ID in order of
+// their indexing. Records of all authors are returned if author is empty.
+func (r *Repository) GetRecords(ctx context.Context, author string, afterID int64, limit int) ([]*models.Record, error) {
+	q := `
+  select
+    id, uri, author, collection, created_at, indexed_at, data
+  from records
+  where id > $1 and ($2 = '' or author = $2)
+  order by id
+  limit $3;`
+	rows, err := r.driver.QueryRows(ctx, q, afterID, author, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*models.Record
+	for rows.Next() {
+		var rec models.Record
+		if err := rows.Scan(&rec.ID, &rec.URI, &rec.Author, &rec.Collection, &rec.CreatedAt, &rec.IndexedAt, &rec.Data); err != nil {
+			return nil, err
+		}
+		records = append(records, &rec)
+	}
+
+	return records, rows.Err()
+}
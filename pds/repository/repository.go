@@ -3,21 +3,27 @@ package repository
 import (
 	"context"
 
+	"github.com/ecumenos/ecumenos/internal/fxpostgres"
 	"github.com/ecumenos/ecumenos/internal/fxpostgres/postgres"
 	"github.com/ecumenos/ecumenos/pds/config"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
 type Repository struct {
-	driver *postgres.Driver
+	driver fxpostgres.Driver
 	logger *zap.Logger
 }
 
-func New(cfg *config.Config, logger *zap.Logger) (*Repository, error) {
+func New(cfg *config.Config, logger *zap.Logger, reg prometheus.Registerer) (*Repository, error) {
 	driver, err := postgres.New(context.Background(), cfg.PostgresURL)
 	if err != nil {
 		return nil, err
 	}
+	if err := reg.Register(fxpostgres.NewPoolCollector(driver)); err != nil {
+		driver.Close()
+		return nil, err
+	}
 
 	return &Repository{
 		driver: driver,
@@ -25,6 +31,15 @@ func New(cfg *config.Config, logger *zap.Logger) (*Repository, error) {
 	}, nil
 }
 
+// NewWithDriver returns repository which runs queries by driver, e.g. by mock
+// in tests.
+func NewWithDriver(driver fxpostgres.Driver, logger *zap.Logger) *Repository {
+	return &Repository{
+		driver: driver,
+		logger: logger,
+	}
+}
+
 func (r *Repository) Ping(ctx context.Context) error {
 	return r.driver.Ping(ctx)
 }
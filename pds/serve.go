@@ -0,0 +1,43 @@
+package pds
+
+import (
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
+	"github.com/ecumenos/ecumenos/internal/zerodowntime"
+	"github.com/ecumenos/ecumenos/pds/admin"
+	"github.com/ecumenos/ecumenos/pds/app"
+	"github.com/ecumenos/ecumenos/pds/config"
+	"go.uber.org/fx"
+)
+
+// ServerOptions builds fx app serving pds. Servers are started by
+// ServeApp, ServeAdmin or ServeAll.
+func ServerOptions(cfg *config.Config) fx.Option {
+	return fx.Options(
+		fx.Provide(func() Configuration {
+			return NewConfiguration(cfg)
+		}),
+		Module,
+		fxlogger.Module,
+		fxmetrics.Module,
+		fxtracing.Module,
+		fxhealth.Module,
+		fx.StopTimeout(cfg.StopTimeout()),
+	)
+}
+
+var (
+	ServeApp = fx.Invoke(func(lc fx.Lifecycle, s *app.Server) {
+		zerodowntime.Serve(lc, s)
+	})
+	ServeAdmin = fx.Invoke(func(lc fx.Lifecycle, s *admin.Server) {
+		zerodowntime.Serve(lc, s)
+	})
+	// ServeAll runs both servers in one process, so they share repository
+	// and its connection pool.
+	ServeAll = fx.Invoke(func(lc fx.Lifecycle, appServer *app.Server, adminServer *admin.Server) {
+		zerodowntime.Serve(lc, appServer, adminServer)
+	})
+)
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	models "github.com/ecumenos/ecumenos/models/pds"
+)
+
+// RecordsPage is page of records, cursor is nil if page is empty.
+type RecordsPage struct {
+	Records []*models.Record
+	Cursor  *string
+}
+
+// ListRecords returns records indexed after cursor. Cursor is opaque for
+// clients, it is ID of the last record of the previous page.
+func (s *Service) ListRecords(ctx context.Context, author, cursor string, limit int) (*RecordsPage, error) {
+	var afterID int64
+	if cursor != "" {
+		var err error
+		afterID, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || afterID < 0 {
+			return nil, apierrors.Validation(apierrors.Field("query.cursor", apierrors.FieldInvalid, "cursor is invalid"))
+		}
+	}
+	records, err := s.repo.GetRecords(ctx, author, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &RecordsPage{Records: records}
+	if len(records) > 0 {
+		next := strconv.FormatInt(records[len(records)-1].ID, 10)
+		page.Cursor = &next
+	}
+
+	return page, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	"github.com/ecumenos/ecumenos/pds/repository"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestListRecords(t *testing.T) {
+	var args []interface{}
+	driver := &pgxtest.Driver{
+		OnQueryRows: func(_ context.Context, _ string, a ...interface{}) (pgx.Rows, error) {
+			args = a
+			if a[0] == int64(7) {
+				return pgxtest.Rows(), nil
+			}
+			return pgxtest.Rows(
+				[]interface{}{int64(5), "ecumenos://alice/1", "alice", "post", time.Now(), time.Now(), []byte(`{}`)},
+				[]interface{}{int64(7), "ecumenos://alice/2", "alice", "post", time.Now(), time.Now(), []byte(`{}`)},
+			), nil
+		},
+	}
+	s := &Service{repo: repository.NewWithDriver(driver, zap.NewNop())}
+
+	page, err := s.ListRecords(context.Background(), "alice", "4", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(4), "alice", 2}, args)
+	require.Len(t, page.Records, 2)
+	require.NotNil(t, page.Cursor)
+	assert.Equal(t, "7", *page.Cursor)
+
+	page, err = s.ListRecords(context.Background(), "alice", *page.Cursor, 2)
+	require.NoError(t, err)
+	assert.Empty(t, page.Records)
+	assert.Nil(t, page.Cursor, "empty page has cursor")
+
+	for _, cursor := range []string{"abc", "-1"} {
+		_, err = s.ListRecords(context.Background(), "", cursor, 2)
+		assert.True(t, errors.Is(err, apierrors.New(apierrors.ValidationFailed, "")), "unexpected error: %v", err)
+	}
+}
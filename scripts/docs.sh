@@ -61,6 +61,17 @@ generate_docs()
     echo "removed temporary files (service=$SERVICE_NAME)"
 }
 
+generate_client()
+{
+    SERVICE_NAME=$1
+    oapi-codegen -o ./internal/generated/$SERVICE_NAME/client.go \
+        -generate client -package $SERVICE_NAME \
+        --import-mapping ./shared-internal.yaml:github.com/ecumenos/ecumenos/internal/generated/shared internal/openapi/$SERVICE_NAME-merged.yaml
+    go run ./internal/apiclient/typedgen -o ./internal/generated/$SERVICE_NAME/typed_client.go \
+        ./internal/generated/$SERVICE_NAME/client.go
+    echo "generated client by openapi file (service=$SERVICE_NAME)"
+}
+
 echo "starting generation of shared types..."
 mkdir -p "./internal/generated/shared"
 oapi-codegen -o internal/generated/shared/types.go \
@@ -76,3 +87,9 @@ generate_docs "orbissociusadmin"
 generate_docs "pds"
 generate_docs "pdsadmin"
 generate_docs "accounts"
+
+generate_client "zookeeper"
+generate_client "zookeeperadmin"
+generate_client "orbissocius"
+generate_client "pds"
+generate_client "accounts"
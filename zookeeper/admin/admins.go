@@ -0,0 +1,152 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"go.uber.org/zap"
+)
+
+func (h *handler) ListAdmins(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	admins, err := h.service.ListAdmins(ctx)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list admins", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.Admin, 0, len(admins))
+	for _, a := range admins {
+		roles, err := h.service.GetAdminRoleNames(ctx, a.ID)
+		if err != nil {
+			_ = writer.WriteError(ctx, "can not get admin roles", err) //nolint:errcheck
+			return
+		}
+		out = append(out, toAdmin(a, roles))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) CreateAdmin(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.CreateAdminRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	a, err := h.service.CreateAdmin(ctx, string(request.Email), request.Password)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("admin is created by admin", zap.Int64("new_admin_id", a.ID))
+	_ = writer.WriteSuccess(ctx, toAdmin(a, []string{})) //nolint:errcheck
+}
+
+func (h *handler) AssignAdminRole(rw http.ResponseWriter, r *http.Request, id int64, role string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	granterID, _ := contextutils.GetAdminID(ctx)
+	a, err := h.service.AssignAdminRoleByName(ctx, id, role, &granterID)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	roles, err := h.service.GetAdminRoleNames(ctx, a.ID)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not get admin roles", err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toAdmin(a, roles)) //nolint:errcheck
+}
+
+func (h *handler) RevokeAdminRole(rw http.ResponseWriter, r *http.Request, id int64, role string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	if err := h.service.RevokeAdminRoleByName(ctx, id, role); err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, nil, f.WithHTTPStatusCode(http.StatusNoContent))
+}
+
+func (h *handler) ListAdminRoles(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	roles, err := h.service.ListAdminRoles(ctx)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list admin roles", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.AdminRole, 0, len(roles))
+	for _, role := range roles {
+		out = append(out, toAdminRole(role))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) CreateAdminRole(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.CreateAdminRoleRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	creatorID, _ := contextutils.GetAdminID(ctx)
+	role, err := h.service.CreateAdminRole(ctx, request.Name, models.AdminRolePermissions{}, creatorID)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toAdminRole(role)) //nolint:errcheck
+}
+
+func toAdmin(a *models.Admin, roles []string) gen.Admin {
+	return gen.Admin{
+		Id:        a.ID,
+		Email:     a.Email,
+		Roles:     roles,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func toAdminRole(r *models.AdminRole) gen.AdminRole {
+	return gen.AdminRole{
+		Id:             r.ID,
+		Name:           r.Name,
+		CreatorAdminId: r.CreatorAdminID,
+		CreatedAt:      r.CreatedAt,
+	}
+}
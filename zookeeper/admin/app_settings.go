@@ -0,0 +1,443 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"go.uber.org/zap"
+)
+
+func (h *handler) GetAppSettingsStatus(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	_ = writer.WriteSuccess(ctx, toAppSettingsStatus(h.service.GetAppSettingsStatus())) //nolint:errcheck
+}
+
+func (h *handler) ReloadAppSettings(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	status, err := h.service.ReloadAppSettings(ctx)
+	if err != nil {
+		_ = writer.WriteFail(ctx, toAppSettingsStatus(status), f.WithCause(err), //nolint:errcheck
+			f.WithHTTPStatusCode(http.StatusUnprocessableEntity), f.WithMessage("app settings are invalid, previous version is kept"),
+			f.WithCode(apierrors.AppSettingsInvalid))
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("app settings are reloaded by admin", zap.Int64("version", status.Version))
+	_ = writer.WriteSuccess(ctx, toAppSettingsStatus(status)) //nolint:errcheck
+}
+
+func (h *handler) ListRegions(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	regions, err := h.service.ListRegions(ctx)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list regions", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.Region, 0, len(regions))
+	for _, region := range regions {
+		out = append(out, toRegion(region))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) CreateRegion(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.CreateRegionRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	region, err := h.service.CreateRegion(ctx, request.Code, request.DisplayName, request.DefaultTimezone,
+		valueOrDefault(request.DataResidencyNotes, ""), valueOrDefault(request.Enabled, true))
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toRegion(region)) //nolint:errcheck
+}
+
+func (h *handler) GetRegion(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	region, err := h.service.GetRegion(ctx, code)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not get region", err) //nolint:errcheck
+		return
+	}
+	if region == nil {
+		_ = writer.WriteFail(ctx, "region not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.RegionNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toRegion(region)) //nolint:errcheck
+}
+
+func (h *handler) UpdateRegion(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.UpdateRegionRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	region, err := h.service.UpdateRegion(ctx, code, request.DisplayName, request.DefaultTimezone, request.DataResidencyNotes)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if region == nil {
+		_ = writer.WriteFail(ctx, "region not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.RegionNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toRegion(region)) //nolint:errcheck
+}
+
+func (h *handler) DeleteRegion(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	found, err := h.service.DeleteRegion(ctx, code)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if !found {
+		_ = writer.WriteFail(ctx, "region not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.RegionNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, nil, f.WithHTTPStatusCode(http.StatusNoContent))
+}
+
+func (h *handler) EnableRegion(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	region, _, err := h.service.SetRegionEnabled(ctx, code, true)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if region == nil {
+		_ = writer.WriteFail(ctx, "region not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.RegionNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toRegion(region)) //nolint:errcheck
+}
+
+func (h *handler) DisableRegion(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	region, orbesSocii, err := h.service.SetRegionEnabled(ctx, code, false)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if region == nil {
+		_ = writer.WriteFail(ctx, "region not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.RegionNotFound)) //nolint:errcheck
+		return
+	}
+
+	data := gen.DisableRegionResponseData{
+		Region:     toRegion(region),
+		OrbesSocii: make([]gen.OrbisSociusReference, 0, len(orbesSocii)),
+		Warnings:   []string{},
+	}
+	for _, o := range orbesSocii {
+		data.OrbesSocii = append(data.OrbesSocii, gen.OrbisSociusReference{Id: o.ID, Name: o.Name, Url: o.URL})
+	}
+	if len(orbesSocii) > 0 {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("%d orbes socii still live in region %q, new orbes socii can not be launched there", len(orbesSocii), code))
+		fxlogger.FromContext(ctx, h.logger).Warn("region with orbes socii is disabled", zap.String("region", code), zap.Int("orbes_socii", len(orbesSocii)))
+	}
+	_ = writer.WriteSuccess(ctx, data) //nolint:errcheck
+}
+
+func (h *handler) ListCountries(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	countries, err := h.service.ListCountries(ctx)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list countries", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.Country, 0, len(countries))
+	for _, c := range countries {
+		out = append(out, toCountry(c))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) CreateCountry(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.CreateCountryRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	country, err := h.service.CreateCountry(ctx, request.Code, valueOrDefault(request.Enabled, true), request.Regions)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toCountry(country)) //nolint:errcheck
+}
+
+func (h *handler) UpdateCountry(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.UpdateCountryRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	country, err := h.service.UpdateCountryRegions(ctx, code, request.Regions)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if country == nil {
+		_ = writer.WriteFail(ctx, "country not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.CountryNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toCountry(country)) //nolint:errcheck
+}
+
+func (h *handler) DeleteCountry(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	found, err := h.service.DeleteCountry(ctx, code)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if !found {
+		_ = writer.WriteFail(ctx, "country not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.CountryNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, nil, f.WithHTTPStatusCode(http.StatusNoContent))
+}
+
+func (h *handler) EnableCountry(rw http.ResponseWriter, r *http.Request, code string) {
+	h.setCountryEnabled(rw, r, code, true)
+}
+
+func (h *handler) DisableCountry(rw http.ResponseWriter, r *http.Request, code string) {
+	h.setCountryEnabled(rw, r, code, false)
+}
+
+func (h *handler) setCountryEnabled(rw http.ResponseWriter, r *http.Request, code string, enabled bool) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	country, err := h.service.SetCountryEnabled(ctx, code, enabled)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if country == nil {
+		_ = writer.WriteFail(ctx, "country not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.CountryNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toCountry(country)) //nolint:errcheck
+}
+
+func (h *handler) ListLanguages(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	languages, err := h.service.ListLanguages(ctx)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list languages", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.Language, 0, len(languages))
+	for _, l := range languages {
+		out = append(out, toLanguage(l))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) CreateLanguage(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.CreateLanguageRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	language, err := h.service.CreateLanguage(ctx, request.Code, valueOrDefault(request.Enabled, true))
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toLanguage(language)) //nolint:errcheck
+}
+
+func (h *handler) DeleteLanguage(rw http.ResponseWriter, r *http.Request, code string) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	found, err := h.service.DeleteLanguage(ctx, code)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if !found {
+		_ = writer.WriteFail(ctx, "language not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.LanguageNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, nil, f.WithHTTPStatusCode(http.StatusNoContent))
+}
+
+func (h *handler) EnableLanguage(rw http.ResponseWriter, r *http.Request, code string) {
+	h.setLanguageEnabled(rw, r, code, true)
+}
+
+func (h *handler) DisableLanguage(rw http.ResponseWriter, r *http.Request, code string) {
+	h.setLanguageEnabled(rw, r, code, false)
+}
+
+func (h *handler) setLanguageEnabled(rw http.ResponseWriter, r *http.Request, code string, enabled bool) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	language, err := h.service.SetLanguageEnabled(ctx, code, enabled)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	if language == nil {
+		_ = writer.WriteFail(ctx, "language not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.LanguageNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toLanguage(language)) //nolint:errcheck
+}
+
+func toAppSettingsStatus(s *appsettings.Status) gen.AppSettingsStatus {
+	out := gen.AppSettingsStatus{
+		Version:  s.Version,
+		LoadedAt: s.LoadedAt,
+		Source:   s.Source,
+	}
+	if s.LastError != "" {
+		out.LastError = &s.LastError
+		out.LastErrorAt = &s.LastErrorAt
+	}
+
+	return out
+}
+
+func toRegion(r *models.Region) gen.Region {
+	return gen.Region{
+		Code:               r.Code,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+		Enabled:            r.Enabled,
+		DisplayName:        r.DisplayName,
+		DefaultTimezone:    r.DefaultTimezone,
+		DataResidencyNotes: r.DataResidencyNotes,
+	}
+}
+
+func toCountry(c *models.Country) gen.Country {
+	return gen.Country{
+		Code:      c.Code,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+		Enabled:   c.Enabled,
+		Regions:   c.Regions,
+	}
+}
+
+func toLanguage(l *models.Language) gen.Language {
+	return gen.Language{
+		Code:      l.Code,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+		Enabled:   l.Enabled,
+	}
+}
+
+func valueOrDefault[T any](v *T, def T) T {
+	if v == nil {
+		return def
+	}
+
+	return *v
+}
@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultComptiLimit = 50
+	maxComptiLimit     = 500
+)
+
+func (h *handler) ListCompti(rw http.ResponseWriter, r *http.Request, params gen.ListComptiParams) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	limit := valueOrDefault(params.Limit, defaultComptiLimit)
+	offset := valueOrDefault(params.Offset, 0)
+	var fields []apierrors.FieldError
+	if limit < 1 || limit > maxComptiLimit {
+		fields = append(fields, apierrors.Field("query.limit", apierrors.FieldInvalid, "limit must be between 1 and 500"))
+	}
+	if offset < 0 {
+		fields = append(fields, apierrors.Field("query.offset", apierrors.FieldInvalid, "offset can not be negative"))
+	}
+	if len(fields) > 0 {
+		_ = writer.WriteAPIError(ctx, apierrors.Validation(fields...)) //nolint:errcheck
+		return
+	}
+	compti, err := h.service.ListCompti(ctx, valueOrDefault(params.Email, ""), limit, offset)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list compti", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.Comptus, 0, len(compti))
+	for _, c := range compti {
+		out = append(out, toComptus(c))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) GetComptus(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	c, err := h.service.GetComptusByID(ctx, id)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not get comptus", err) //nolint:errcheck
+		return
+	}
+	if c == nil {
+		_ = writer.WriteFail(ctx, "comptus not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.ComptusNotFound)) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toComptus(c)) //nolint:errcheck
+}
+
+func (h *handler) DeleteComptus(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	found, err := h.service.DeleteComptus(ctx, id)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not delete comptus", err) //nolint:errcheck
+		return
+	}
+	if !found {
+		_ = writer.WriteFail(ctx, "comptus not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.ComptusNotFound)) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("comptus is deleted by admin", zap.Int64("comptus_id", id))
+	_ = writer.WriteSuccess(ctx, nil, f.WithHTTPStatusCode(http.StatusNoContent))
+}
+
+func (h *handler) SuspendComptus(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	c, err := h.service.SuspendComptus(ctx, id)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not suspend comptus", err) //nolint:errcheck
+		return
+	}
+	if c == nil {
+		_ = writer.WriteFail(ctx, "comptus not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.ComptusNotFound)) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("comptus is suspended by admin", zap.Int64("comptus_id", id))
+	_ = writer.WriteSuccess(ctx, toComptus(c)) //nolint:errcheck
+}
+
+func (h *handler) UnsuspendComptus(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	c, err := h.service.UnsuspendComptus(ctx, id)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not unsuspend comptus", err) //nolint:errcheck
+		return
+	}
+	if c == nil {
+		_ = writer.WriteFail(ctx, "comptus not found", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.ComptusNotFound)) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("comptus suspension is lifted by admin", zap.Int64("comptus_id", id))
+	_ = writer.WriteSuccess(ctx, toComptus(c)) //nolint:errcheck
+}
+
+func toComptus(c *models.Comptus) gen.Comptus {
+	out := gen.Comptus{
+		Id:        c.ID,
+		Email:     c.Email,
+		Country:   c.Patria,
+		Language:  c.Lingua,
+		CreatedAt: c.CreatedAt,
+	}
+	if c.SuspendedAt.Valid {
+		out.SuspendedAt = &c.SuspendedAt.Time
+	}
+
+	return out
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	"github.com/ecumenos/ecumenos/internal/docs"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
 	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
 	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
 	"github.com/ecumenos/ecumenos/internal/openapi"
 	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
@@ -49,20 +52,20 @@ func (h *handler) auth(rw http.ResponseWriter, r *http.Request) context.Context
 	token, err := httputils.ExtractJWTBearerToken(r)
 	if err != nil {
 		_ = writer.WriteFail(ctx, nil, f.WithHTTPStatusCode(http.StatusUnauthorized), //nolint:errcheck
-			f.WithCause(err), f.WithMessage("failed to get token"))
-		h.logger.Error("can not extract JWT token from request", zap.Error(err))
+			f.WithCause(err), f.WithMessage("failed to get token"), f.WithCode(apierrors.AuthMissingToken))
+		fxlogger.FromContext(ctx, h.logger).Error("can not extract JWT token from request", zap.Error(err))
 		return nil
 	}
 
 	adminID, sessionID, err := h.service.AuthorizeAdmin(ctx, token)
 	if err != nil {
-		_ = writer.WriteFail(ctx, nil, f.WithHTTPStatusCode(http.StatusUnauthorized), //nolint:errcheck
-			f.WithCause(err), f.WithMessage("failed to authorize"))
-		h.logger.Error("can not authorize", zap.Error(err))
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		fxlogger.FromContext(ctx, h.logger).Error("can not authorize", zap.Error(err))
 		return nil
 	}
 	ctx = contextutils.SetAdminID(ctx, adminID)
 	ctx = contextutils.SetAdminSessionID(ctx, sessionID)
+	fxlogger.AddFields(ctx)
 
 	return ctx
 }
@@ -83,9 +86,10 @@ func (h *handler) GetInfo(rw http.ResponseWriter, r *http.Request) {
 	deps := h.service.PingServices(ctx)
 	writer := h.responseFactory.NewWriter(rw)
 	_ = writer.WriteSuccess(ctx, gen.GetInfoData{ //nolint:errcheck
-		Name:    string(config.ServiceName),
-		Version: string(config.ServiceVersion),
-		Deps:    deps,
+		Name:            string(config.ServiceName),
+		Version:         string(config.ServiceVersion),
+		ProtocolVersion: int(fxtypes.CurrentProtocolVersion),
+		Deps:            deps,
 	})
 }
 
@@ -102,12 +106,12 @@ func (h *handler) RefreshSession(rw http.ResponseWriter, r *http.Request) {
 
 	request, err := httputils.DecodeBody[gen.RefreshSessionRequest](h.logger, r)
 	if err != nil {
-		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
 		return
 	}
 	session, err := h.service.RefreshAdminSession(ctx, request.RefreshToken)
 	if err != nil {
-		_ = writer.WriteError(ctx, "failed refresh admin session", err) //nolint:errcheck
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
 		return
 	}
 
@@ -124,11 +128,11 @@ func (h *handler) SignIn(rw http.ResponseWriter, r *http.Request) {
 
 	request, err := httputils.DecodeBody[gen.SignInRequest](h.logger, r)
 	if err != nil {
-		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
 		return
 	}
 	if err := h.service.ValidateAdminCredentials(ctx, string(request.Email), request.Password); err != nil {
-		_ = writer.WriteFail(ctx, "invalid email or password", f.WithCause(err), f.WithHTTPStatusCode(http.StatusUnauthorized)) //nolint:errcheck
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
 		return
 	}
 
@@ -138,7 +142,7 @@ func (h *handler) SignIn(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if a == nil {
-		_ = writer.WriteFail(ctx, "invalid email", f.WithHTTPStatusCode(http.StatusNotFound)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid email", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.AdminNotFound)) //nolint:errcheck
 		return
 	}
 	session, err := h.service.CreateAdminSession(ctx, a.ID)
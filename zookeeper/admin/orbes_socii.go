@@ -0,0 +1,255 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"go.uber.org/zap"
+)
+
+func (h *handler) ListOrbesSocii(rw http.ResponseWriter, r *http.Request, params gen.ListOrbesSociiParams) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	orbesSocii, err := h.service.ListOrbesSocii(ctx, valueOrDefault(params.Region, ""))
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list orbes socii", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.OrbisSocius, 0, len(orbesSocii))
+	for _, o := range orbesSocii {
+		out = append(out, toOrbisSocius(o))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) GetOrbisSociusHealth(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	os, stats, err := h.service.GetOrbisSociusHealth(ctx, id)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	data := gen.OrbisSociusHealth{
+		OrbisSocius: toOrbisSocius(os),
+		Stats:       make([]gen.OrbisSociusStat, 0, len(stats)),
+	}
+	var alive int
+	for _, s := range stats {
+		data.Stats = append(data.Stats, toOrbisSociusStat(s))
+		if s.Alive {
+			alive++
+		}
+	}
+	if len(stats) > 0 {
+		data.Availability = float64(alive) / float64(len(stats))
+	}
+	_ = writer.WriteSuccess(ctx, data) //nolint:errcheck
+}
+
+func (h *handler) RotateOrbisSociusAPIKey(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	apiKey, err := h.service.RotateOrbisSociusAPIKey(ctx, id)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("orbis socius api key is rotated by admin", zap.Int64("orbis_socius_id", id))
+	_ = writer.WriteSuccess(ctx, gen.RotateOrbisSociusAPIKeyResponseData{Id: id, ApiKey: apiKey}) //nolint:errcheck
+}
+
+func (h *handler) ListOrbisSociusLaunchRequests(rw http.ResponseWriter, r *http.Request, params gen.ListOrbisSociusLaunchRequestsParams) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	var status *models.OrbisSociusLaunchRequestStatus
+	if params.Status != nil {
+		s, ok := fromLaunchRequestStatus(*params.Status)
+		if !ok {
+			_ = writer.WriteAPIError(ctx, apierrors.Validation(apierrors.Field("query.status", apierrors.FieldInvalid, "status is invalid"))) //nolint:errcheck
+			return
+		}
+		status = &s
+	}
+	requests, err := h.service.ListOrbisSociusLaunchRequests(ctx, status)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list launch requests", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.OrbisSociusLaunchRequest, 0, len(requests))
+	for _, req := range requests {
+		out = append(out, toLaunchRequest(req))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) GetOrbisSociusLaunchRequest(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	req, err := h.service.ViewOrbisSociusLaunchRequest(ctx, id)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, toLaunchRequest(req)) //nolint:errcheck
+}
+
+func (h *handler) ApproveOrbisSociusLaunchRequest(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	adminID, _ := contextutils.GetAdminID(ctx)
+	req, invite, err := h.service.ApproveOrbisSociusLaunchRequest(ctx, id, adminID)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("launch request is approved by admin", zap.Int64("launch_request_id", id))
+	_ = writer.WriteSuccess(ctx, gen.ApproveOrbisSociusLaunchRequestResponseData{ //nolint:errcheck
+		LaunchRequest: toLaunchRequest(req),
+		Invite: gen.OrbisSociusLaunchInvite{
+			Id:        invite.ID,
+			Code:      invite.Code,
+			ApiKey:    invite.APIKey,
+			ExpiredAt: invite.ExpiredAt,
+		},
+	})
+}
+
+func (h *handler) RejectOrbisSociusLaunchRequest(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	req, err := h.service.RejectOrbisSociusLaunchRequest(ctx, id)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("launch request is rejected by admin", zap.Int64("launch_request_id", id))
+	_ = writer.WriteSuccess(ctx, toLaunchRequest(req)) //nolint:errcheck
+}
+
+func toOrbisSocius(o *models.OrbisSocius) gen.OrbisSocius {
+	out := gen.OrbisSocius{
+		Id:               o.ID,
+		Name:             o.Name,
+		Description:      o.Description,
+		Url:              o.URL,
+		Region:           o.Region,
+		OwnerComptusId:   o.OwnerComptusID,
+		Alive:            o.Alive,
+		RobustnessStatus: toRobustnessStatus(o.RobustnessStatus),
+		Compliant:        o.Compliant,
+		CreatedAt:        o.CreatedAt,
+		UpdatedAt:        o.UpdatedAt,
+	}
+	if o.ApproverAdminID.Valid {
+		out.ApproverAdminId = &o.ApproverAdminID.Int64
+	}
+	if o.LastPingedAt.Valid {
+		out.LastPingedAt = &o.LastPingedAt.Time
+	}
+	if o.Version.Valid {
+		out.Version = &o.Version.String
+	}
+	if o.ProtocolVersion.Valid {
+		v := int(o.ProtocolVersion.Int64)
+		out.ProtocolVersion = &v
+	}
+
+	return out
+}
+
+func toOrbisSociusStat(s *models.OrbisSociusStat) gen.OrbisSociusStat {
+	out := gen.OrbisSociusStat{CreatedAt: s.CreatedAt, Alive: s.Alive}
+	if s.Version.Valid {
+		out.Version = &s.Version.String
+	}
+	if s.ProtocolVersion.Valid {
+		v := int(s.ProtocolVersion.Int64)
+		out.ProtocolVersion = &v
+	}
+	if s.Members.Valid {
+		out.Members = &s.Members.Int64
+	}
+	if s.Capacity.Valid {
+		out.Capacity = &s.Capacity.Int64
+	}
+
+	return out
+}
+
+func toRobustnessStatus(s models.RobustnessStatus) gen.RobustnessStatus {
+	switch s {
+	case models.Fragile:
+		return gen.Fragile
+	case models.Resilient:
+		return gen.Resilient
+	case models.Adaptable:
+		return gen.Adaptable
+	case models.Antifragile:
+		return gen.Antifragile
+	default:
+		return gen.Vulnerable
+	}
+}
+
+var launchRequestStatuses = map[models.OrbisSociusLaunchRequestStatus]gen.OrbisSociusLaunchRequestStatus{
+	models.PendingOrbisSociusLaunchRequest:  gen.Pending,
+	models.ViewedOrbisSociusLaunchRequest:   gen.Viewed,
+	models.ApprovedOrbisSociusLaunchRequest: gen.Approved,
+	models.RejectedOrbisSociusLaunchRequest: gen.Rejected,
+}
+
+func fromLaunchRequestStatus(s gen.OrbisSociusLaunchRequestStatus) (models.OrbisSociusLaunchRequestStatus, bool) {
+	for status, name := range launchRequestStatuses {
+		if name == s {
+			return status, true
+		}
+	}
+
+	return 0, false
+}
+
+func toLaunchRequest(r *models.OrbisSociusLaunchRequest) gen.OrbisSociusLaunchRequest {
+	return gen.OrbisSociusLaunchRequest{
+		Id:          r.ID,
+		ComptusId:   r.ComptusID,
+		Region:      r.Region,
+		Name:        r.OrbisSociusName,
+		Description: r.OrbisSociusDescription,
+		Url:         r.OrbisSociusURL,
+		Status:      launchRequestStatuses[r.Status],
+		CreatedAt:   r.CreatedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	"go.uber.org/zap"
+)
+
+func (h *handler) GetOrbisSociusMinProtocolVersion(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	out, err := h.getOrbisSociusMinProtocolVersion(ctx)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) SetOrbisSociusMinProtocolVersion(rw http.ResponseWriter, r *http.Request) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.SetOrbisSociusMinProtocolVersionRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	adminID, _ := contextutils.GetAdminID(ctx)
+	if err := h.service.SetOrbesSociiMinProtocolVersion(ctx, adminID, request.MinProtocolVersion); err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("orbes socii min protocol version is set by admin", zap.Int("min_protocol_version", request.MinProtocolVersion))
+	out, err := h.getOrbisSociusMinProtocolVersion(ctx)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) getOrbisSociusMinProtocolVersion(ctx context.Context) (*gen.OrbisSociusMinProtocolVersion, error) {
+	minProtocolVersion, err := h.service.GetOrbesSociiMinProtocolVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonCompliant, err := h.service.CountNonCompliantOrbesSocii(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.OrbisSociusMinProtocolVersion{
+		MinProtocolVersion:     minProtocolVersion,
+		CurrentProtocolVersion: int(fxtypes.CurrentProtocolVersion),
+		NonCompliant:           int64(nonCompliant),
+	}, nil
+}
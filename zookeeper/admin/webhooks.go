@@ -0,0 +1,158 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeperadmin"
+	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"go.uber.org/zap"
+)
+
+func (h *handler) SetOrbisSociusWebhook(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	request, err := httputils.DecodeBody[gen.SetOrbisSociusWebhookRequest](h.logger, r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	webhook, err := h.service.SetOrbisSociusWebhook(ctx, id, request.Url)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("orbis socius webhook is set by admin", zap.Int64("orbis_socius_id", id))
+	_ = writer.WriteSuccess(ctx, gen.OrbisSociusWebhook{ //nolint:errcheck
+		OrbisSociusId: webhook.OrbisSociusID,
+		Url:           webhook.URL,
+		Secret:        webhook.Secret,
+	})
+}
+
+func (h *handler) DeleteOrbisSociusWebhook(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	if err := h.service.DeleteOrbisSociusWebhook(ctx, id); err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("orbis socius webhook is deleted by admin", zap.Int64("orbis_socius_id", id))
+	_ = writer.WriteSuccess(ctx, nil, f.WithHTTPStatusCode(http.StatusNoContent)) //nolint:errcheck
+}
+
+func (h *handler) DelistOrbisSocius(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	os, err := h.service.DelistOrbisSocius(ctx, id)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("orbis socius is delisted by admin", zap.Int64("orbis_socius_id", id))
+	_ = writer.WriteSuccess(ctx, toOrbisSocius(os)) //nolint:errcheck
+}
+
+func (h *handler) ListWebhookDeliveries(rw http.ResponseWriter, r *http.Request, id int64, params gen.ListWebhookDeliveriesParams) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	var status *models.WebhookDeliveryStatus
+	if params.Status != nil {
+		s, ok := fromWebhookDeliveryStatus(*params.Status)
+		if !ok {
+			_ = writer.WriteAPIError(ctx, apierrors.Validation(apierrors.Field("query.status", apierrors.FieldInvalid, "status is invalid"))) //nolint:errcheck
+			return
+		}
+		status = &s
+	}
+	deliveries, err := h.service.ListWebhookDeliveries(ctx, id, status)
+	if err != nil {
+		_ = writer.WriteError(ctx, "can not list webhook deliveries", err) //nolint:errcheck
+		return
+	}
+	out := make([]gen.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		out = append(out, toWebhookDelivery(d))
+	}
+	_ = writer.WriteSuccess(ctx, out) //nolint:errcheck
+}
+
+func (h *handler) RedeliverWebhook(rw http.ResponseWriter, r *http.Request, id int64) {
+	ctx := h.auth(rw, r)
+	if ctx == nil {
+		return
+	}
+
+	writer := h.responseFactory.NewWriter(rw)
+	d, err := h.service.RedeliverWebhook(ctx, id)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("webhook is redelivered by admin", zap.Int64("webhook_delivery_id", id))
+	_ = writer.WriteSuccess(ctx, toWebhookDelivery(d)) //nolint:errcheck
+}
+
+var webhookDeliveryStatuses = map[models.WebhookDeliveryStatus]gen.WebhookDeliveryStatus{
+	models.ScheduledWebhookDelivery: gen.Scheduled,
+	models.DeliveredWebhookDelivery: gen.Delivered,
+	models.FailedWebhookDelivery:    gen.Failed,
+}
+
+func fromWebhookDeliveryStatus(s gen.WebhookDeliveryStatus) (models.WebhookDeliveryStatus, bool) {
+	for status, name := range webhookDeliveryStatuses {
+		if name == s {
+			return status, true
+		}
+	}
+
+	return 0, false
+}
+
+func toWebhookDelivery(d *models.WebhookDelivery) gen.WebhookDelivery {
+	out := gen.WebhookDelivery{
+		Id:            d.ID,
+		OrbisSociusId: d.OrbisSociusID,
+		Event:         gen.WebhookEventType(d.Event),
+		Status:        webhookDeliveryStatuses[d.Status],
+		Attempts:      d.Attempts,
+		CreatedAt:     d.CreatedAt,
+	}
+	if d.NextAttemptAt.Valid {
+		out.NextAttemptAt = &d.NextAttemptAt.Time
+	}
+	if d.LastAttemptAt.Valid {
+		out.LastAttemptAt = &d.LastAttemptAt.Time
+	}
+	if d.LastStatusCode.Valid {
+		code := int(d.LastStatusCode.Int32)
+		out.LastStatusCode = &code
+	}
+	if d.LastError.Valid {
+		out.LastError = &d.LastError.String
+	}
+	if d.DeliveredAt.Valid {
+		out.DeliveredAt = &d.DeliveredAt.Time
+	}
+
+	return out
+}
@@ -2,22 +2,30 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	"github.com/ecumenos/ecumenos/internal/docs"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
 	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
 	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeper"
+	"github.com/ecumenos/ecumenos/internal/localenames"
 	"github.com/ecumenos/ecumenos/internal/openapi"
 	"github.com/ecumenos/ecumenos/internal/toolkit/contextutils"
 	"github.com/ecumenos/ecumenos/internal/toolkit/httputils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/webhooks"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
 	"github.com/ecumenos/ecumenos/zookeeper/config"
 	"github.com/ecumenos/ecumenos/zookeeper/service"
 	"github.com/oapi-codegen/runtime/types"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 )
 
 type handler struct {
@@ -51,20 +59,20 @@ func (h *handler) auth(rw http.ResponseWriter, r *http.Request) context.Context
 	token, err := httputils.ExtractJWTBearerToken(r)
 	if err != nil {
 		_ = writer.WriteFail(ctx, nil, f.WithHTTPStatusCode(http.StatusUnauthorized), //nolint:errcheck
-			f.WithCause(err), f.WithMessage("failed to get token"))
-		h.logger.Error("can not extract JWT token from request", zap.Error(err))
+			f.WithCause(err), f.WithMessage("failed to get token"), f.WithCode(apierrors.AuthMissingToken))
+		fxlogger.FromContext(ctx, h.logger).Error("can not extract JWT token from request", zap.Error(err))
 		return nil
 	}
 
 	comptusID, sessionID, err := h.service.AuthorizeComptus(ctx, token)
 	if err != nil {
-		_ = writer.WriteFail(ctx, nil, f.WithHTTPStatusCode(http.StatusUnauthorized), //nolint:errcheck
-			f.WithCause(err), f.WithMessage("failed to authorize"))
-		h.logger.Error("can not authorize", zap.Error(err))
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		fxlogger.FromContext(ctx, h.logger).Error("can not authorize", zap.Error(err))
 		return nil
 	}
 	ctx = contextutils.SetComptusID(ctx, comptusID)
 	ctx = contextutils.SetComptusSessionID(ctx, sessionID)
+	fxlogger.AddFields(ctx, zap.Int64("comptus_id", comptusID))
 
 	return ctx
 }
@@ -85,9 +93,10 @@ func (h *handler) GetInfo(rw http.ResponseWriter, r *http.Request) {
 	deps := h.service.PingServices(ctx)
 	writer := h.responseFactory.NewWriter(rw)
 	_ = writer.WriteSuccess(ctx, gen.GetInfoData{ //nolint:errcheck
-		Name:    string(config.ServiceName),
-		Version: string(config.ServiceVersion),
-		Deps:    deps,
+		Name:            string(config.ServiceName),
+		Version:         string(config.ServiceVersion),
+		ProtocolVersion: int(fxtypes.CurrentProtocolVersion),
+		Deps:            deps,
 	})
 }
 
@@ -107,6 +116,19 @@ func mapModelComptusToGenComptus(v *models.Comptus) gen.Comptus {
 	}
 }
 
+func mapLocalizedNamesToGen(names []localenames.Name) []gen.LocalizedName {
+	out := make([]gen.LocalizedName, 0, len(names))
+	for _, n := range names {
+		out = append(out, gen.LocalizedName{
+			Code:       n.Code,
+			Name:       n.Name,
+			NativeName: n.NativeName,
+		})
+	}
+
+	return out
+}
+
 func (h *handler) GetMe(rw http.ResponseWriter, r *http.Request) {
 	ctx := h.auth(rw, r)
 	if ctx == nil {
@@ -134,12 +156,12 @@ func (h *handler) RefreshSession(rw http.ResponseWriter, r *http.Request) {
 
 	request, err := httputils.DecodeBody[gen.RefreshSessionRequest](h.logger, r)
 	if err != nil {
-		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
 		return
 	}
 	session, err := h.service.RefreshComptusSession(ctx, request.RefreshToken)
 	if err != nil {
-		_ = writer.WriteError(ctx, "failed refresh comptus session", err) //nolint:errcheck
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
 		return
 	}
 
@@ -165,13 +187,13 @@ func (h *handler) SignUp(rw http.ResponseWriter, r *http.Request) {
 
 	request, err := httputils.DecodeBody[gen.SignUpRequest](h.logger, r)
 	if err != nil {
-		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
 		return
 	}
 
 	c, err := h.service.CreateComptus(ctx, string(request.Email), request.Password, string(request.Country), string(request.Language))
 	if err != nil {
-		_ = writer.WriteError(ctx, "can not create comptus", err) //nolint:errcheck
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
 		return
 	}
 	session, err := h.service.CreateComptusSession(ctx, c.ID)
@@ -215,11 +237,11 @@ func (h *handler) SignIn(rw http.ResponseWriter, r *http.Request) {
 
 	request, err := httputils.DecodeBody[gen.SignInRequest](h.logger, r)
 	if err != nil {
-		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
 		return
 	}
 	if err := h.service.ValidateComptusCredentials(ctx, string(request.Email), request.Password); err != nil {
-		_ = writer.WriteFail(ctx, "invalid email or password", f.WithCause(err), f.WithHTTPStatusCode(http.StatusUnauthorized)) //nolint:errcheck
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
 		return
 	}
 
@@ -229,7 +251,7 @@ func (h *handler) SignIn(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if c == nil {
-		_ = writer.WriteFail(ctx, "invalid email", f.WithHTTPStatusCode(http.StatusNotFound)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid email", f.WithHTTPStatusCode(http.StatusNotFound), f.WithCode(apierrors.ComptusNotFound)) //nolint:errcheck
 		return
 	}
 	session, err := h.service.CreateComptusSession(ctx, c.ID)
@@ -248,40 +270,68 @@ func (h *handler) SignIn(rw http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (h *handler) GetCountries(rw http.ResponseWriter, r *http.Request) {
+func (h *handler) GetCountries(rw http.ResponseWriter, r *http.Request, params gen.GetCountriesParams) {
 	ctx := h.auth(rw, r)
 	if ctx == nil {
 		return
 	}
 
 	writer := h.responseFactory.NewWriter(rw)
-	countries := h.service.GetOrbisSociusCountries()
+	prefs, err := h.localePreferences(ctx, params.AcceptLanguage)
+	if err != nil {
+		_ = writer.WriteError(ctx, "failed get locale preferences", err) //nolint:errcheck
+		return
+	}
+	countries := h.service.GetOrbisSociusCountries(prefs)
 
-	_ = writer.WriteSuccess(ctx, gen.CountriesResponseData(countries)) //nolint:errcheck
+	_ = writer.WriteSuccess(ctx, gen.CountriesResponseData(mapLocalizedNamesToGen(countries))) //nolint:errcheck
 }
 
-func (h *handler) GetCountryRegions(rw http.ResponseWriter, r *http.Request, countryCode string) {
+func (h *handler) GetCountryRegions(rw http.ResponseWriter, r *http.Request, countryCode string, params gen.GetCountryRegionsParams) {
 	ctx := h.auth(rw, r)
 	if ctx == nil {
 		return
 	}
 
 	writer := h.responseFactory.NewWriter(rw)
-	regions := h.service.GetOrbisSociusRegions(countryCode)
+	prefs, err := h.localePreferences(ctx, params.AcceptLanguage)
+	if err != nil {
+		_ = writer.WriteError(ctx, "failed get locale preferences", err) //nolint:errcheck
+		return
+	}
+	regions := h.service.GetOrbisSociusRegions(countryCode, prefs)
 
-	_ = writer.WriteSuccess(ctx, gen.CountryRegionsResponseData(regions)) //nolint:errcheck
+	_ = writer.WriteSuccess(ctx, gen.CountryRegionsResponseData(mapLocalizedNamesToGen(regions))) //nolint:errcheck
 }
 
-func (h *handler) GetLanguages(rw http.ResponseWriter, r *http.Request) {
+func (h *handler) GetLanguages(rw http.ResponseWriter, r *http.Request, params gen.GetLanguagesParams) {
 	ctx := h.auth(rw, r)
 	if ctx == nil {
 		return
 	}
 
 	writer := h.responseFactory.NewWriter(rw)
-	languages := h.service.GetOrbisSociusLanguages()
+	prefs, err := h.localePreferences(ctx, params.AcceptLanguage)
+	if err != nil {
+		_ = writer.WriteError(ctx, "failed get locale preferences", err) //nolint:errcheck
+		return
+	}
+	languages := h.service.GetOrbisSociusLanguages(prefs)
+
+	_ = writer.WriteSuccess(ctx, gen.LanguagesResponseData(mapLocalizedNamesToGen(languages))) //nolint:errcheck
+}
+
+func (h *handler) localePreferences(ctx context.Context, acceptLanguage *string) ([]language.Tag, error) {
+	comptusID, ok := contextutils.GetComptusID(ctx)
+	if !ok {
+		return nil, errors.New("can not get comptus id from context")
+	}
+	var header string
+	if acceptLanguage != nil {
+		header = *acceptLanguage
+	}
 
-	_ = writer.WriteSuccess(ctx, gen.LanguagesResponseData(languages)) //nolint:errcheck
+	return h.service.LocalePreferences(ctx, comptusID, header)
 }
 
 func (h *handler) ActivateOrbisSocius(rw http.ResponseWriter, r *http.Request) {
@@ -308,12 +358,12 @@ func (h *handler) RequestOrbisSocius(rw http.ResponseWriter, r *http.Request) {
 
 	request, err := httputils.DecodeBody[gen.RequestOrbisSociusRequest](h.logger, r)
 	if err != nil {
-		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err)) //nolint:errcheck
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
 		return
 	}
 
 	if _, err := h.service.MakeCreateOrbisSociusLaunchRequest(ctx, comptusID, request.Region, request.Name, request.Description, request.Url); err != nil {
-		_ = writer.WriteFail(ctx, "can not create orbis socius launch request", f.WithCause(err)) //nolint:errcheck
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
 		return
 	}
 
@@ -321,3 +371,70 @@ func (h *handler) RequestOrbisSocius(rw http.ResponseWriter, r *http.Request) {
 		Ok: true,
 	})
 }
+
+func (h *handler) RegisterOrbisSocius(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	writer := h.responseFactory.NewWriter(rw)
+	apiKey, err := httputils.ExtractJWTBearerToken(r)
+	if err != nil {
+		_ = writer.WriteFail(ctx, nil, f.WithHTTPStatusCode(http.StatusUnauthorized), //nolint:errcheck
+			f.WithCause(err), f.WithMessage("failed to get API key"), f.WithCode(apierrors.AuthMissingToken))
+		return
+	}
+
+	os, err := h.service.RegisterOrbisSocius(ctx, apiKey)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	minProtocolVersion, err := h.service.GetOrbesSociiMinProtocolVersion(ctx)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	fxlogger.FromContext(ctx, h.logger).Info("orbis socius is registered", zap.Int64("orbis_socius_id", os.ID))
+	_ = writer.WriteSuccess(ctx, gen.OrbisSociusRegistration{ //nolint:errcheck
+		Id:                 os.ID,
+		Name:               os.Name,
+		Region:             os.Region,
+		Url:                os.URL,
+		HeartbeatInterval:  int(h.service.HeartbeatInterval().Seconds()),
+		MinProtocolVersion: minProtocolVersion,
+	})
+}
+
+func (h *handler) OrbisSociusHeartbeat(rw http.ResponseWriter, r *http.Request, params gen.OrbisSociusHeartbeatParams) {
+	ctx := r.Context()
+	writer := h.responseFactory.NewWriter(rw)
+	body, err := io.ReadAll(io.LimitReader(r.Body, webhooks.MaxBodySize))
+	if err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	if _, err := h.service.AuthorizeOrbisSocius(ctx, params.EcumenosOrbisSocius, params.EcumenosSignature, body); err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		fxlogger.FromContext(ctx, h.logger).Warn("can not authorize orbis socius", zap.Int64("orbis_socius_id", params.EcumenosOrbisSocius), zap.Error(err))
+		return
+	}
+	fxlogger.AddFields(ctx, zap.Int64("orbis_socius_id", params.EcumenosOrbisSocius))
+
+	var request gen.OrbisSociusHeartbeatRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		_ = writer.WriteFail(ctx, "invalid body", f.WithCause(err), f.WithCode(apierrors.InvalidBody)) //nolint:errcheck
+		return
+	}
+	heartbeat := &models.OrbisSociusHeartbeat{
+		Version:  request.Version,
+		Members:  request.Members,
+		Capacity: request.Capacity,
+	}
+	if request.ProtocolVersion != nil {
+		heartbeat.ProtocolVersion = *request.ProtocolVersion
+	}
+	err = h.service.RecordOrbisSociusHeartbeat(ctx, params.EcumenosOrbisSocius, heartbeat)
+	if err != nil {
+		_ = writer.WriteAPIError(ctx, err) //nolint:errcheck
+		return
+	}
+	_ = writer.WriteSuccess(ctx, nil, f.WithHTTPStatusCode(http.StatusNoContent)) //nolint:errcheck
+}
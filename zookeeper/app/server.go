@@ -2,12 +2,19 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
 	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
 	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeper"
 	"github.com/ecumenos/ecumenos/internal/httputils"
+	"github.com/ecumenos/ecumenos/internal/openapi"
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	"github.com/ecumenos/ecumenos/internal/zerodowntime"
 	"github.com/ecumenos/ecumenos/zookeeper/config"
 	"github.com/gorilla/mux"
 	"go.uber.org/fx"
@@ -18,49 +25,143 @@ type Server struct {
 	server          *http.Server
 	logger          *zap.Logger
 	responseFactory f.Factory
+	health          *fxhealth.Health
+	drainTimeout    time.Duration
+	tlsConfig       *tls.Config
 }
 
 type serverParams struct {
 	fx.In
-	Config    *config.Config
-	Logger    *zap.Logger
-	ServerInt gen.ServerInterface
+	Config      *config.Config
+	Logger      *zap.Logger
+	ServerInt   gen.ServerInterface
+	Metrics     *fxmetrics.HTTPMetrics
+	Health      *fxhealth.Health
+	Idempotency httputils.IdempotencyStore
 }
 
-func NewServer(params serverParams) *Server {
+func NewServer(params serverParams) (*Server, error) {
 	responseFactory := f.NewFactory(params.Logger, &f.Config{WriteLogs: !params.Config.Prod}, config.ServiceVersion)
 	s := &Server{
 		logger:          params.Logger,
 		responseFactory: responseFactory,
+		health:          params.Health,
+		drainTimeout:    params.Config.AppDrainTimeout,
 	}
 
+	tlsConfig, err := httputils.NewTLSConfig(httputils.TLSConfig{
+		CertFile:     params.Config.TLSCertFile,
+		KeyFile:      params.Config.TLSKeyFile,
+		ClientCAFile: params.Config.AppClientCAFile,
+	}, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+	s.tlsConfig = tlsConfig
+
 	router := mux.NewRouter()
-	enrichContext := httputils.NewEnrichContextMiddleware(params.Logger, responseFactory)
+	router.Use(mux.MiddlewareFunc(httputils.NewMetricsMiddleware(params.Metrics, "app")))
+	ips, err := netutils.NewClientIPExtractor(params.Config.TrustedProxies...)
+	if err != nil {
+		return nil, err
+	}
+	enrichContext := httputils.NewEnrichContextMiddleware(params.Logger, responseFactory, ips)
 	recovery := httputils.NewRecoverMiddleware(params.Logger, responseFactory)
 	router.Use(mux.MiddlewareFunc(enrichContext))
+	router.Use(mux.MiddlewareFunc(httputils.NewTracingMiddleware("app")))
+	router.Use(mux.MiddlewareFunc(httputils.NewAccessLogMiddleware(params.Logger, httputils.AccessLogConfig{
+		SampleRatio: params.Config.AccessLogSampleRatio,
+	})))
+	router.Use(mux.MiddlewareFunc(httputils.NewClientIdentityMiddleware()))
+	if params.Config.ValidateRequests {
+		validation, err := httputils.NewOpenAPIValidationMiddleware(params.Logger, responseFactory, httputils.OpenAPIValidationConfig{
+			Spec:              openapi.ZookeeperSpec(""),
+			ValidateResponses: !params.Config.Prod,
+			LogDrift:          !params.Config.Prod,
+		})
+		if err != nil {
+			return nil, err
+		}
+		router.Use(mux.MiddlewareFunc(validation))
+	}
+	router.Use(mux.MiddlewareFunc(httputils.NewIdempotencyMiddleware(params.Logger, responseFactory, httputils.IdempotencyConfig{
+		Store: params.Idempotency,
+		TTL:   params.Config.IdempotencyKeyTTL,
+	})))
+	errorHandler := httputils.DefaultErrorHandlerFactory(responseFactory)
+	if params.Config.RequireServiceClientCert {
+		mountServiceRoutes(router, params.ServerInt, httputils.NewRequireClientCertMiddleware(params.Logger, responseFactory), errorHandler)
+	}
 	router = gen.HandlerWithOptions(params.ServerInt, gen.GorillaServerOptions{
 		BaseRouter:       router,
-		ErrorHandlerFunc: httputils.DefaultErrorHandlerFactory(responseFactory),
+		ErrorHandlerFunc: errorHandler,
 	}).(*mux.Router)
-	router.Use(mux.CORSMethodMiddleware(router))
 	router.Use(mux.MiddlewareFunc(recovery))
+	root := http.NewServeMux()
+	params.Health.Mount(root)
+	cors := httputils.NewCORSMiddleware(httputils.CORSConfig{
+		AllowedOrigins:   params.Config.AppCORSAllowedOrigins,
+		AllowCredentials: params.Config.AppCORSAllowCredentials,
+		MaxAge:           params.Config.CORSMaxAge,
+	})
+	root.Handle("/", cors(router))
 	s.server = &http.Server{
 		Addr:         params.Config.AppAddr,
 		WriteTimeout: 15 * time.Second,
 		ReadTimeout:  15 * time.Second,
 		IdleTimeout:  15 * time.Second,
-		Handler:      http.TimeoutHandler(router, 30*time.Second, "something went wrong"),
+		Handler:      http.TimeoutHandler(root, 30*time.Second, "something went wrong"),
 	}
 
-	return s
+	return s, nil
+}
+
+// serviceRoutes are called by orbes socii, not by users.
+var serviceRoutes = map[string]bool{
+	"/orbes_socii/register":  true,
+	"/orbes_socii/heartbeat": true,
 }
 
+// mountServiceRoutes serves service routes by subrouter with middleware, e.g.
+// the one which requires client certificate. It must be called before other
+// routes are added to router, so service routes are matched by subrouter.
+func mountServiceRoutes(router *mux.Router, si gen.ServerInterface, mw func(http.Handler) http.Handler, errorHandler func(http.ResponseWriter, *http.Request, error)) {
+	services := router.MatcherFunc(func(r *http.Request, _ *mux.RouteMatch) bool {
+		return serviceRoutes[r.URL.Path]
+	}).Subrouter()
+	services.Use(mux.MiddlewareFunc(mw))
+	gen.HandlerWithOptions(si, gen.GorillaServerOptions{
+		BaseRouter:       services,
+		ErrorHandlerFunc: errorHandler,
+	})
+}
+
+// Start starts listening and serves requests in background. Listener is
+// inherited from parent process during graceful restart.
 func (s *Server) Start(ctx context.Context) error {
-	return s.server.ListenAndServe()
+	ln, err := zerodowntime.Listen(s.server.Addr)
+	if err != nil {
+		return err
+	}
+	if s.tlsConfig != nil {
+		ln = tls.NewListener(ln, s.tlsConfig)
+	}
+	go func() {
+		if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("http server run error", zap.Error(err))
+		}
+	}()
+	s.logger.Info("http server is started", zap.String("addr", s.server.Addr))
+
+	return nil
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
-	if err := s.server.Shutdown(ctx); err != nil {
+	s.health.Drain(ctx)
+	drained, err := zerodowntime.Drain(ctx, s.server, s.drainTimeout)
+	if !drained {
+		s.logger.Warn("http server was not drained in time, connections are closed",
+			zap.Duration("drain_timeout", s.drainTimeout))
 		return err
 	}
 	s.logger.Info("http server was shutted down")
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	f "github.com/ecumenos/ecumenos/internal/fxresponsefactory"
+	gen "github.com/ecumenos/ecumenos/internal/generated/zookeeper"
+	"github.com/ecumenos/ecumenos/internal/httputils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeServer struct {
+	gen.ServerInterface
+	registered int
+}
+
+func (s *fakeServer) RegisterOrbisSocius(rw http.ResponseWriter, _ *http.Request) {
+	s.registered++
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (s *fakeServer) GetInfo(rw http.ResponseWriter, _ *http.Request) {
+	rw.WriteHeader(http.StatusOK)
+}
+
+func newServiceRoutesRouter(t *testing.T, si gen.ServerInterface) http.Handler {
+	rf := f.NewFactory(zap.NewNop(), &f.Config{}, config.ServiceVersion)
+	errorHandler := httputils.DefaultErrorHandlerFactory(rf)
+	ips, err := netutils.NewClientIPExtractor()
+	require.NoError(t, err)
+	router := mux.NewRouter()
+	router.Use(mux.MiddlewareFunc(httputils.NewEnrichContextMiddleware(zap.NewNop(), rf, ips)))
+	router.Use(mux.MiddlewareFunc(httputils.NewClientIdentityMiddleware()))
+	mountServiceRoutes(router, si, httputils.NewRequireClientCertMiddleware(zap.NewNop(), rf), errorHandler)
+
+	return gen.HandlerWithOptions(si, gen.GorillaServerOptions{
+		BaseRouter:       router,
+		ErrorHandlerFunc: errorHandler,
+	})
+}
+
+func TestServiceRoutesRejectRequestsWithoutClientCert(t *testing.T) {
+	si := &fakeServer{}
+	router := newServiceRoutesRouter(t, si)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orbes_socii/register", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), string(apierrors.AuthMissingClientCert))
+	assert.Zero(t, si.registered)
+
+	// routes for users don't require certificate.
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/info", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServiceRoutesAcceptVerifiedClientCert(t *testing.T) {
+	si := &fakeServer{}
+	router := newServiceRoutesRouter(t, si)
+
+	req := httptest.NewRequest(http.MethodPost, "/orbes_socii/register", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+		{Subject: pkix.Name{CommonName: "orbis-socius"}},
+	}}}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, si.registered)
+}
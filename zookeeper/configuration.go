@@ -0,0 +1,51 @@
+package zookeeper
+
+import (
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxappsettings"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"go.uber.org/fx"
+)
+
+// Configuration supplies configuration of service and configurations of
+// shared modules derived from it.
+type Configuration struct {
+	fx.Out
+
+	Config            *config.Config
+	LoggerConfig      *fxlogger.Config
+	MetricsConfig     *fxmetrics.Config
+	TracingConfig     *fxtracing.Config
+	HealthConfig      *fxhealth.Config
+	AppSettingsConfig *fxappsettings.Config
+}
+
+func NewConfiguration(cfg *config.Config) Configuration {
+	var refresh time.Duration
+	if cfg.AppSettingsSource == config.PostgresAppSettingsSource {
+		refresh = cfg.AppSettingsRefresh
+	}
+
+	return Configuration{
+		Config:        cfg,
+		LoggerConfig:  &fxlogger.Config{Prod: cfg.Prod},
+		MetricsConfig: &fxmetrics.Config{Addr: cfg.MetricsAddr},
+		TracingConfig: &fxtracing.Config{
+			Exporter:    cfg.TracingExporter,
+			FilePath:    cfg.TracingFile,
+			SampleRatio: cfg.TracingSampleRatio,
+		},
+		HealthConfig: &fxhealth.Config{DrainDelay: cfg.ReadinessDrainDelay},
+		AppSettingsConfig: &fxappsettings.Config{
+			LocalesPath:     cfg.LocalesPath,
+			RegionsPath:     cfg.RegionsPath,
+			Watch:           cfg.WatchAppSettings,
+			RefreshInterval: refresh,
+		},
+	}
+}
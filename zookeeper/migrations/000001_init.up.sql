@@ -1,6 +1,6 @@
 begin;
 
-create table public.admins
+create table admins
 (
   id            bigint primary key,
   created_at    timestamp(0) with time zone default current_timestamp not null,
@@ -12,7 +12,7 @@ create table public.admins
 );
 create unique index admins_email_uindex on admins (email);
 
-create table public.admin_roles
+create table admin_roles
 (
   id               bigint primary key,
   created_at       timestamp(0) with time zone default current_timestamp not null,
@@ -25,7 +25,7 @@ create table public.admin_roles
 );
 create unique index admin_roles_name_uindex on admin_roles (name);
 
-create table public.admins_admin_roles_relations
+create table admins_admin_roles_relations
 (
   receiver_admin_id bigint references admins (id) not null,
   granter_admin_id  bigint references admins (id),
@@ -33,7 +33,7 @@ create table public.admins_admin_roles_relations
   granted_at        timestamp(0) with time zone default current_timestamp not null
 );
 
-create table public.admin_sessions
+create table admin_sessions
 (
   id               bigint primary key,
   created_at       timestamp(0) with time zone default current_timestamp not null,
@@ -46,7 +46,7 @@ create table public.admin_sessions
   refresh_token    text not null
 );
 
-create table public.compti
+create table compti
 (
   id               bigint primary key,
   created_at       timestamp(0) with time zone default current_timestamp not null,
@@ -60,7 +60,7 @@ create table public.compti
 );
 create unique index compti_email_uindex on compti (email);
 
-create table public.comptus_sessions
+create table comptus_sessions
 (
   id               bigint primary key,
   created_at       timestamp(0) with time zone default current_timestamp not null,
@@ -73,7 +73,7 @@ create table public.comptus_sessions
   refresh_token    text not null
 );
 
-create table public.orbes_socii
+create table orbes_socii
 (
   id                bigint primary key,
   created_at        timestamp(0) with time zone default current_timestamp not null,
@@ -92,7 +92,7 @@ create table public.orbes_socii
   api_key           text not null
 );
 
-create table public.orbes_socii_stats
+create table orbes_socii_stats
 (
   id              bigint primary key,
   created_at      timestamp(0) with time zone default current_timestamp not null,
@@ -100,7 +100,7 @@ create table public.orbes_socii_stats
   alive           boolean not null default false
 );
 
-create table public.orbes_socii_launch_requests
+create table orbes_socii_launch_requests
 (
   id                       bigint primary key,
   created_at               timestamp(0) with time zone default current_timestamp not null,
@@ -112,7 +112,7 @@ create table public.orbes_socii_launch_requests
   status                   integer check (status >= 0) not null
 );
 
-create table public.orbes_socii_launch_invites
+create table orbes_socii_launch_invites
 (
   id                              bigint primary key,
   created_at                      timestamp(0) with time zone default current_timestamp not null,
@@ -0,0 +1,8 @@
+begin;
+
+drop table if exists countries_regions_relations cascade;
+drop table if exists countries cascade;
+drop table if exists regions cascade;
+drop table if exists languages cascade;
+
+commit;
@@ -0,0 +1,37 @@
+begin;
+
+create table regions
+(
+  code                 text primary key,
+  created_at           timestamp(0) with time zone default current_timestamp not null,
+  updated_at           timestamp(0) with time zone default current_timestamp not null,
+  enabled              boolean not null default true,
+  display_name         text not null,
+  default_timezone     text not null,
+  data_residency_notes text not null default ''
+);
+
+create table countries
+(
+  code       text primary key,
+  created_at timestamp(0) with time zone default current_timestamp not null,
+  updated_at timestamp(0) with time zone default current_timestamp not null,
+  enabled    boolean not null default true
+);
+
+create table countries_regions_relations
+(
+  country_code text references countries (code) on delete cascade not null,
+  region_code  text references regions (code) not null,
+  primary key (country_code, region_code)
+);
+
+create table languages
+(
+  code       text primary key,
+  created_at timestamp(0) with time zone default current_timestamp not null,
+  updated_at timestamp(0) with time zone default current_timestamp not null,
+  enabled    boolean not null default true
+);
+
+commit;
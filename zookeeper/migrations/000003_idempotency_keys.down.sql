@@ -0,0 +1,5 @@
+begin;
+
+drop table if exists idempotency_keys cascade;
+
+commit;
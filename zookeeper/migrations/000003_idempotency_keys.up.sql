@@ -0,0 +1,19 @@
+begin;
+
+create table idempotency_keys
+(
+  scope       text not null,
+  key         text not null,
+  fingerprint text not null,
+  created_at  timestamp(0) with time zone default current_timestamp not null,
+  expires_at  timestamp(0) with time zone not null,
+  completed   boolean not null default false,
+  status_code integer not null default 0,
+  header      jsonb,
+  body        bytea,
+  primary key (scope, key)
+);
+
+create index idempotency_keys_expires_at_idx on idempotency_keys (expires_at);
+
+commit;
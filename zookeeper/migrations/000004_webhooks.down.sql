@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists webhook_deliveries cascade;
+drop table if exists orbes_socii_webhooks cascade;
+
+commit;
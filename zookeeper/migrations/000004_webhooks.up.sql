@@ -0,0 +1,31 @@
+begin;
+
+create table orbes_socii_webhooks
+(
+  orbis_socius_id bigint primary key references orbes_socii (id),
+  created_at      timestamp(0) with time zone default current_timestamp not null,
+  updated_at      timestamp(0) with time zone default current_timestamp not null,
+  url             text not null,
+  secret          text not null
+);
+
+create table webhook_deliveries
+(
+  id               bigint primary key,
+  created_at       timestamp(0) with time zone default current_timestamp not null,
+  orbis_socius_id  bigint references orbes_socii (id) not null,
+  event            text not null,
+  data             jsonb not null,
+  status           integer check (status >= 0) not null,
+  attempts         integer not null default 0,
+  next_attempt_at  timestamp(0) with time zone,
+  last_attempt_at  timestamp(0) with time zone,
+  last_status_code integer,
+  last_error       text,
+  delivered_at     timestamp(0) with time zone
+);
+
+create index webhook_deliveries_scheduled_idx on webhook_deliveries (next_attempt_at) where status = 0;
+create index webhook_deliveries_orbis_socius_id_idx on webhook_deliveries (orbis_socius_id, id);
+
+commit;
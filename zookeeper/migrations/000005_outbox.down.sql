@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists outbox_consumer_offsets cascade;
+drop table if exists outbox_events cascade;
+
+commit;
@@ -0,0 +1,23 @@
+begin;
+
+-- id is sequential, so consumers keep their position as offset. Events are
+-- inserted under advisory lock, so they are committed in order of their ids.
+create table outbox_events
+(
+  id           bigserial primary key,
+  created_at   timestamp(0) with time zone default current_timestamp not null,
+  type         text not null,
+  aggregate_id bigint not null,
+  data         jsonb not null
+);
+
+create index outbox_events_created_at_idx on outbox_events (created_at);
+
+create table outbox_consumer_offsets
+(
+  consumer      text primary key,
+  updated_at    timestamp(0) with time zone default current_timestamp not null,
+  last_event_id bigint not null default 0
+);
+
+commit;
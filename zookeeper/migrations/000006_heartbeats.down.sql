@@ -0,0 +1,12 @@
+begin;
+
+drop index if exists orbes_socii_alive_idx;
+drop index if exists orbes_socii_launch_invites_api_key_idx;
+drop index if exists orbes_socii_api_key_idx;
+
+alter table orbes_socii_stats
+  drop column if exists capacity,
+  drop column if exists members,
+  drop column if exists version;
+
+commit;
@@ -0,0 +1,12 @@
+begin;
+
+alter table orbes_socii_stats
+  add column version  text,
+  add column members  bigint,
+  add column capacity bigint;
+
+create index orbes_socii_api_key_idx on orbes_socii (api_key);
+create index orbes_socii_launch_invites_api_key_idx on orbes_socii_launch_invites (api_key);
+create index orbes_socii_alive_idx on orbes_socii (last_pinged_at) where alive;
+
+commit;
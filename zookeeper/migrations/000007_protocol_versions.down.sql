@@ -0,0 +1,13 @@
+begin;
+
+drop table if exists min_protocol_versions cascade;
+
+alter table orbes_socii_stats
+  drop column if exists protocol_version;
+
+alter table orbes_socii
+  drop column if exists compliant,
+  drop column if exists protocol_version,
+  drop column if exists version;
+
+commit;
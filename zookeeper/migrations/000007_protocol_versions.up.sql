@@ -0,0 +1,19 @@
+begin;
+
+alter table orbes_socii
+  add column version          text,
+  add column protocol_version integer,
+  add column compliant        boolean not null default true;
+
+alter table orbes_socii_stats
+  add column protocol_version integer;
+
+create table min_protocol_versions
+(
+  service              text primary key,
+  updated_at           timestamp(0) with time zone default current_timestamp not null,
+  min_protocol_version integer not null,
+  admin_id             bigint references admins (id)
+);
+
+commit;
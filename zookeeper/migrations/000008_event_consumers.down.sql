@@ -0,0 +1,13 @@
+begin;
+
+drop table if exists audit_log cascade;
+drop table if exists outbox_dead_letters cascade;
+
+alter table outbox_consumer_offsets
+  drop column if exists last_error,
+  drop column if exists attempts;
+
+alter table compti
+  drop column if exists suspended_at;
+
+commit;
@@ -0,0 +1,41 @@
+begin;
+
+alter table compti
+  add column suspended_at timestamp(0) with time zone;
+
+-- attempts and last_error belong to the event after last_event_id which
+-- consumer fails to handle.
+alter table outbox_consumer_offsets
+  add column attempts   integer not null default 0,
+  add column last_error text;
+
+-- events which consumers skipped after max attempts. Event is copied, so it
+-- is kept after outbox cleanup.
+create table outbox_dead_letters
+(
+  id           bigserial primary key,
+  created_at   timestamp(0) with time zone default current_timestamp not null,
+  consumer     text not null,
+  event_id     bigint not null,
+  type         text not null,
+  aggregate_id bigint not null,
+  data         jsonb not null,
+  attempts     integer not null,
+  error        text not null
+);
+create unique index outbox_dead_letters_consumer_event_id_uindex on outbox_dead_letters (consumer, event_id);
+
+create table audit_log
+(
+  id           bigserial primary key,
+  created_at   timestamp(0) with time zone default current_timestamp not null,
+  event_id     bigint not null,
+  occurred_at  timestamp(0) with time zone not null,
+  type         text not null,
+  aggregate_id bigint not null,
+  data         jsonb not null
+);
+create unique index audit_log_event_id_uindex on audit_log (event_id);
+create index audit_log_aggregate_idx on audit_log (type, aggregate_id);
+
+commit;
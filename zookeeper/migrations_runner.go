@@ -3,6 +3,7 @@ package zookeeper
 import (
 	"github.com/ecumenos/ecumenos/internal/fxpostgres/migrations"
 	"github.com/ecumenos/ecumenos/zookeeper/config"
+	svcmigrations "github.com/ecumenos/ecumenos/zookeeper/migrations"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -31,7 +32,7 @@ func (r *MigrationsRunner) MigrateUp() error {
 			zap.String("db_url", r.postgresURL),
 			zap.String("source_path", r.postgresMigrationsPath))
 	}
-	return fn(r.postgresMigrationsPath, r.postgresURL+"?sslmode=disable", r.logger, r.shutdowner)
+	return fn(r.postgresMigrationsPath, svcmigrations.FS, r.postgresURL+"?sslmode=disable", r.logger, r.shutdowner)
 }
 
 func (r *MigrationsRunner) MigrateDown() error {
@@ -41,5 +42,5 @@ func (r *MigrationsRunner) MigrateDown() error {
 			zap.String("db_url", r.postgresURL),
 			zap.String("source_path", r.postgresMigrationsPath))
 	}
-	return fn(r.postgresMigrationsPath, r.postgresURL+"?sslmode=disable", r.logger, r.shutdowner)
+	return fn(r.postgresMigrationsPath, svcmigrations.FS, r.postgresURL+"?sslmode=disable", r.logger, r.shutdowner)
 }
@@ -30,11 +30,11 @@ func (r *Repository) InsertAdminRole(ctx context.Context, name string, permissio
 	updatedAt := time.Now()
 	tombstoned := false
 
-	query := `insert into public.admin_roles
+	query := `insert into admin_roles
   (id, created_at, updated_at, tombstoned, name, permissions, creator_admin_id)
   values ($1, $2, $3, $4, $5, $6, $7);`
 	params := []interface{}{id, createdAt, updatedAt, tombstoned, name, permissions, creatorID}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -53,7 +53,7 @@ func (r *Repository) GetAdminRoleByID(ctx context.Context, id int64) (*models.Ad
 	q := `
   select
     id, created_at, updated_at, deleted_at, tombstoned, name, permissions, creator_admin_id
-  from public.admin_roles
+  from admin_roles
   where id=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -86,7 +86,7 @@ func (r *Repository) GetAdminRoleByName(ctx context.Context, name string) (*mode
 	q := `
   select
     id, created_at, updated_at, deleted_at, tombstoned, name, permissions, creator_admin_id
-  from public.admin_roles
+  from admin_roles
   where name=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, name)
 	if err != nil {
@@ -114,3 +114,41 @@ func (r *Repository) GetAdminRoleByName(ctx context.Context, name string) (*mode
 
 	return nil, err
 }
+
+func (r *Repository) SetAdminRolePermissionsByID(ctx context.Context, id int64, permissions models.AdminRolePermissions) error {
+	return r.driver.ExecuteQuery(ctx, "update admin_roles set updated_at = $2, permissions = $3 where id=$1", id, time.Now(), permissions)
+}
+
+func (r *Repository) GetAdminRoles(ctx context.Context) ([]*models.AdminRole, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, name, permissions, creator_admin_id
+  from admin_roles
+  where tombstoned=false
+  order by name;`
+	rows, err := r.driver.QueryRows(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*models.AdminRole
+	for rows.Next() {
+		var role models.AdminRole
+		if err := rows.Scan(
+			&role.ID,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+			&role.DeletedAt,
+			&role.Tombstoned,
+			&role.Name,
+			&role.Permissions,
+			&role.CreatorAdminID,
+		); err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+
+	return roles, rows.Err()
+}
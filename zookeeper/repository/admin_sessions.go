@@ -24,11 +24,11 @@ func (r *Repository) InsertAdminSession(ctx context.Context, adminID int64, t, r
 		return nil, fmt.Errorf("expired at can not be before created at (expired at = %v, created at = %v)", timeutils.TimeToString(expiredAt), timeutils.TimeToString(createdAt))
 	}
 
-	query := `insert into public.admin_sessions
+	query := `insert into admin_sessions
   (id, created_at, updated_at, expired_at, tombstoned, admin_id, token, refresh_token)
   values ($1, $2, $3, $4, $5, $6, $7, $8);`
 	params := []interface{}{id, createdAt, updatedAt, expiredAt, tombstoned, adminID, t, rt}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -72,7 +72,7 @@ func (r *Repository) GetAdminSessionByID(ctx context.Context, id int64) (*models
 	q := `
   select
     id, created_at, updated_at, expired_at, deleted_at, tombstoned, admin_id, token, refresh_token
-  from public.admin_sessions
+  from admin_sessions
   where id=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -86,7 +86,7 @@ func (r *Repository) GetAdminSessionByAdminIDAndToken(ctx context.Context, admin
 	q := `
   select
     id, created_at, updated_at, expired_at, deleted_at, tombstoned, admin_id, token, refresh_token
-  from public.admin_sessions
+  from admin_sessions
   where admin_id=$1 and token=$2 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, adminID, token)
 	if err != nil {
@@ -100,7 +100,7 @@ func (r *Repository) GetAdminSessionByAdminIDAndRefreshToken(ctx context.Context
 	q := `
   select
     id, created_at, updated_at, expired_at, deleted_at, tombstoned, admin_id, token, refresh_token
-  from public.admin_sessions
+  from admin_sessions
   where admin_id=$1 and refresh_token=$2 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, adminID, refreshToken)
 	if err != nil {
@@ -111,9 +111,9 @@ func (r *Repository) GetAdminSessionByAdminIDAndRefreshToken(ctx context.Context
 }
 
 func (r *Repository) SetAdminSessionTombstonedByID(ctx context.Context, id int64) error {
-	return r.driver.ExecuteQuery(ctx, "update public.admin_sessions set tombstoned = true where id=$1", id)
+	return r.driver.ExecuteQuery(ctx, "update admin_sessions set tombstoned = true where id=$1", id)
 }
 
 func (r *Repository) SetAdminSessionTokensByID(ctx context.Context, id int64, t string, rt string, expiredAt time.Time) error {
-	return r.driver.ExecuteQuery(ctx, "update public.admin_sessions set updated_at = $2, expired_at = $3, token = $4, refresh_token = $5 where id=$1", id, time.Now(), expiredAt, t, rt)
+	return r.driver.ExecuteQuery(ctx, "update admin_sessions set updated_at = $2, expired_at = $3, token = $4, refresh_token = $5 where id=$1", id, time.Now(), expiredAt, t, rt)
 }
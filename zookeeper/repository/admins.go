@@ -32,11 +32,11 @@ func (r *Repository) InsertAdmin(ctx context.Context, email, passwordHash string
 	updatedAt := time.Now()
 	tombstoned := false
 
-	query := `insert into public.admins
+	query := `insert into admins
   (id, created_at, updated_at, tombstoned, email, password_hash)
   values ($1, $2, $3, $4, $5, $6);`
 	params := []interface{}{id, createdAt, updatedAt, tombstoned, email, passwordHash}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -54,7 +54,7 @@ func (r *Repository) GetAdminByID(ctx context.Context, id int64) (*models.Admin,
 	q := `
   select
     id, created_at, updated_at, deleted_at, tombstoned, email, password_hash
-  from public.admins
+  from admins
   where id=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -86,7 +86,7 @@ func (r *Repository) GetAdminByEmail(ctx context.Context, email string) (*models
 	q := `
   select
     id, created_at, updated_at, deleted_at, tombstoned, email, password_hash
-  from public.admins
+  from admins
   where email=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, email)
 	if err != nil {
@@ -122,11 +122,11 @@ func (r *Repository) AssignRoleForAdmin(ctx context.Context, receiverAdminID, ad
 	}
 	grantedAt := time.Now()
 
-	query := `insert into public.admins_admin_roles_relations
+	query := `insert into admins_admin_roles_relations
   (receiver_admin_id, granter_admin_id, role_id, granted_at)
   values ($1, $2, $3, $4);`
 	params := []interface{}{receiverAdminID, granterAdminID, adminRoleID, grantedAt}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -137,3 +137,100 @@ func (r *Repository) AssignRoleForAdmin(ctx context.Context, receiverAdminID, ad
 		GrantedAt:      grantedAt,
 	}, nil
 }
+
+func (r *Repository) SetAdminPasswordHashByID(ctx context.Context, id int64, passwordHash string) error {
+	return r.driver.ExecuteQuery(ctx, "update admins set updated_at = $2, password_hash = $3 where id=$1", id, time.Now(), passwordHash)
+}
+
+func (r *Repository) GetAdminsAdminRolesRelation(ctx context.Context, receiverAdminID, adminRoleID int64) (*models.AdminsAdminRolesRelation, error) {
+	q := `
+  select
+    receiver_admin_id, granter_admin_id, role_id, granted_at
+  from admins_admin_roles_relations
+  where receiver_admin_id=$1 and role_id=$2;`
+	row, err := r.driver.QueryRow(ctx, q, receiverAdminID, adminRoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rel models.AdminsAdminRolesRelation
+	err = row.Scan(
+		&rel.ReciverAdminID,
+		&rel.GranterAdminID,
+		&rel.RoleID,
+		&rel.GrantedAt,
+	)
+	if err == nil {
+		return &rel, nil
+	}
+
+	if errorsutils.Equals(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func (r *Repository) GetAdmins(ctx context.Context) ([]*models.Admin, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, email, password_hash
+  from admins
+  where tombstoned=false
+  order by id;`
+	rows, err := r.driver.QueryRows(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var admins []*models.Admin
+	for rows.Next() {
+		var a models.Admin
+		if err := rows.Scan(
+			&a.ID,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+			&a.DeletedAt,
+			&a.Tombstoned,
+			&a.Email,
+			&a.PasswordHash,
+		); err != nil {
+			return nil, err
+		}
+		admins = append(admins, &a)
+	}
+
+	return admins, rows.Err()
+}
+
+func (r *Repository) GetAdminRoleNamesByAdminID(ctx context.Context, adminID int64) ([]string, error) {
+	q := `
+  select
+    ar.name
+  from admins_admin_roles_relations rel
+  join admin_roles ar on ar.id = rel.role_id
+  where rel.receiver_admin_id=$1 and ar.tombstoned=false
+  order by ar.name;`
+	rows, err := r.driver.QueryRows(ctx, q, adminID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+func (r *Repository) DeleteAdminsAdminRolesRelation(ctx context.Context, receiverAdminID, adminRoleID int64) error {
+	q := `delete from admins_admin_roles_relations where receiver_admin_id=$1 and role_id=$2;`
+	return r.driver.ExecuteQuery(ctx, q, receiverAdminID, adminRoleID)
+}
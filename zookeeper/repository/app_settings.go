@@ -0,0 +1,333 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/errorsutils"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repository) InsertRegion(ctx context.Context, code, displayName, defaultTimezone, dataResidencyNotes string, enabled bool) (*models.Region, error) {
+	createdAt := time.Now()
+	updatedAt := time.Now()
+
+	query := `insert into regions
+  (code, created_at, updated_at, enabled, display_name, default_timezone, data_residency_notes)
+  values ($1, $2, $3, $4, $5, $6, $7);`
+	params := []interface{}{code, createdAt, updatedAt, enabled, displayName, defaultTimezone, dataResidencyNotes}
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
+		return nil, err
+	}
+
+	return &models.Region{
+		Code:               code,
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
+		Enabled:            enabled,
+		DisplayName:        displayName,
+		DefaultTimezone:    defaultTimezone,
+		DataResidencyNotes: dataResidencyNotes,
+	}, nil
+}
+
+func scanRowRegion(row pgx.Row) (*models.Region, error) {
+	var r models.Region
+	err := row.Scan(
+		&r.Code,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.Enabled,
+		&r.DisplayName,
+		&r.DefaultTimezone,
+		&r.DataResidencyNotes,
+	)
+	if err == nil {
+		return &r, nil
+	}
+
+	if errorsutils.Equals(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func (r *Repository) GetRegionByCode(ctx context.Context, code string) (*models.Region, error) {
+	q := `
+  select
+    code, created_at, updated_at, enabled, display_name, default_timezone, data_residency_notes
+  from regions
+  where code=$1;`
+	row, err := r.driver.QueryRow(ctx, q, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowRegion(row)
+}
+
+func (r *Repository) GetRegions(ctx context.Context) ([]*models.Region, error) {
+	q := `
+  select
+    code, created_at, updated_at, enabled, display_name, default_timezone, data_residency_notes
+  from regions
+  order by code;`
+	rows, err := r.driver.QueryRows(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var regions []*models.Region
+	for rows.Next() {
+		region, err := scanRowRegion(rows)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, region)
+	}
+
+	return regions, rows.Err()
+}
+
+func (r *Repository) UpdateRegion(ctx context.Context, code, displayName, defaultTimezone, dataResidencyNotes string) error {
+	q := `update regions
+  set updated_at = $2, display_name = $3, default_timezone = $4, data_residency_notes = $5
+  where code=$1;`
+	return r.driver.ExecuteQuery(ctx, q, code, time.Now(), displayName, defaultTimezone, dataResidencyNotes)
+}
+
+func (r *Repository) SetRegionEnabled(ctx context.Context, code string, enabled bool) error {
+	q := `update regions set updated_at = $2, enabled = $3 where code=$1;`
+	return r.driver.ExecuteQuery(ctx, q, code, time.Now(), enabled)
+}
+
+func (r *Repository) DeleteRegion(ctx context.Context, code string) error {
+	q := `delete from regions where code=$1;`
+	return r.driver.ExecuteQuery(ctx, q, code)
+}
+
+func (r *Repository) CountCountriesByRegion(ctx context.Context, regionCode string) (int, error) {
+	q := `select count(*) from countries_regions_relations where region_code=$1;`
+	return r.driver.CountRows(ctx, q, regionCode)
+}
+
+func (r *Repository) InsertCountry(ctx context.Context, code string, enabled bool, regions []string) (*models.Country, error) {
+	createdAt := time.Now()
+	updatedAt := time.Now()
+
+	err := r.InTx(ctx, func(ctx context.Context) error {
+		query := `insert into countries
+  (code, created_at, updated_at, enabled)
+  values ($1, $2, $3, $4);`
+		if err := r.driver.ExecuteQuery(ctx, query, code, createdAt, updatedAt, enabled); err != nil {
+			return err
+		}
+
+		return r.SetCountryRegions(ctx, code, regions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Country{
+		Code:      code,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		Enabled:   enabled,
+		Regions:   regions,
+	}, nil
+}
+
+func scanRowCountry(row pgx.Row) (*models.Country, error) {
+	var c models.Country
+	err := row.Scan(
+		&c.Code,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.Enabled,
+		&c.Regions,
+	)
+	if err == nil {
+		return &c, nil
+	}
+
+	if errorsutils.Equals(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+const selectCountriesQuery = `
+  select
+    c.code, c.created_at, c.updated_at, c.enabled,
+    coalesce(array_agg(crr.region_code order by crr.region_code) filter (where crr.region_code is not null), '{}')
+  from countries c
+  left join countries_regions_relations crr on crr.country_code = c.code`
+
+func (r *Repository) GetCountryByCode(ctx context.Context, code string) (*models.Country, error) {
+	q := selectCountriesQuery + `
+  where c.code=$1
+  group by c.code;`
+	row, err := r.driver.QueryRow(ctx, q, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowCountry(row)
+}
+
+func (r *Repository) GetCountries(ctx context.Context) ([]*models.Country, error) {
+	q := selectCountriesQuery + `
+  group by c.code
+  order by c.code;`
+	rows, err := r.driver.QueryRows(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var countries []*models.Country
+	for rows.Next() {
+		c, err := scanRowCountry(rows)
+		if err != nil {
+			return nil, err
+		}
+		countries = append(countries, c)
+	}
+
+	return countries, rows.Err()
+}
+
+// SetCountryRegions replaces regions of the country.
+// SetCountryRegions replaces regions of country in one transaction.
+func (r *Repository) SetCountryRegions(ctx context.Context, code string, regions []string) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		q := `delete from countries_regions_relations where country_code=$1 and region_code <> all($2::text[]);`
+		if err := r.driver.ExecuteQuery(ctx, q, code, regions); err != nil {
+			return err
+		}
+		q = `insert into countries_regions_relations (country_code, region_code)
+  select $1, unnest($2::text[])
+  on conflict do nothing;`
+		if err := r.driver.ExecuteQuery(ctx, q, code, regions); err != nil {
+			return err
+		}
+
+		return r.driver.ExecuteQuery(ctx, `update countries set updated_at = $2 where code=$1;`, code, time.Now())
+	})
+}
+
+func (r *Repository) SetCountryEnabled(ctx context.Context, code string, enabled bool) error {
+	q := `update countries set updated_at = $2, enabled = $3 where code=$1;`
+	return r.driver.ExecuteQuery(ctx, q, code, time.Now(), enabled)
+}
+
+func (r *Repository) DeleteCountry(ctx context.Context, code string) error {
+	q := `delete from countries where code=$1;`
+	return r.driver.ExecuteQuery(ctx, q, code)
+}
+
+func (r *Repository) InsertLanguage(ctx context.Context, code string, enabled bool) (*models.Language, error) {
+	createdAt := time.Now()
+	updatedAt := time.Now()
+
+	query := `insert into languages
+  (code, created_at, updated_at, enabled)
+  values ($1, $2, $3, $4);`
+	if err := r.driver.ExecuteQuery(ctx, query, code, createdAt, updatedAt, enabled); err != nil {
+		return nil, err
+	}
+
+	return &models.Language{
+		Code:      code,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		Enabled:   enabled,
+	}, nil
+}
+
+func scanRowLanguage(row pgx.Row) (*models.Language, error) {
+	var l models.Language
+	err := row.Scan(
+		&l.Code,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+		&l.Enabled,
+	)
+	if err == nil {
+		return &l, nil
+	}
+
+	if errorsutils.Equals(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func (r *Repository) GetLanguageByCode(ctx context.Context, code string) (*models.Language, error) {
+	q := `
+  select
+    code, created_at, updated_at, enabled
+  from languages
+  where code=$1;`
+	row, err := r.driver.QueryRow(ctx, q, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowLanguage(row)
+}
+
+func (r *Repository) GetLanguages(ctx context.Context) ([]*models.Language, error) {
+	q := `
+  select
+    code, created_at, updated_at, enabled
+  from languages
+  order by code;`
+	rows, err := r.driver.QueryRows(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var languages []*models.Language
+	for rows.Next() {
+		l, err := scanRowLanguage(rows)
+		if err != nil {
+			return nil, err
+		}
+		languages = append(languages, l)
+	}
+
+	return languages, rows.Err()
+}
+
+func (r *Repository) SetLanguageEnabled(ctx context.Context, code string, enabled bool) error {
+	q := `update languages set updated_at = $2, enabled = $3 where code=$1;`
+	return r.driver.ExecuteQuery(ctx, q, code, time.Now(), enabled)
+}
+
+func (r *Repository) DeleteLanguage(ctx context.Context, code string) error {
+	q := `delete from languages where code=$1;`
+	return r.driver.ExecuteQuery(ctx, q, code)
+}
+
+// AppSettingsChannel is channel of postgres notifications which are sent when
+// app settings are changed or reloaded by admin, so every zookeeper process
+// reloads them.
+const AppSettingsChannel = "app_settings"
+
+func (r *Repository) NotifyAppSettingsChanged(ctx context.Context) error {
+	return r.driver.ExecuteQuery(ctx, "select pg_notify($1, '');", AppSettingsChannel)
+}
+
+// ListenAppSettingsChanged calls handle for every notification about changed
+// app settings until ctx is done or connection fails.
+func (r *Repository) ListenAppSettingsChanged(ctx context.Context, handle func()) error {
+	return r.driver.Listen(ctx, AppSettingsChannel, func(string) { handle() })
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"go.uber.org/zap"
+)
+
+// AppSettingsSource serves countries, regions and languages from database.
+// If database has no settings yet, they are imported from initial source
+// (YAML files) on the first load.
+type AppSettingsSource struct {
+	repo    *Repository
+	initial appsettings.Source
+}
+
+func NewAppSettingsSource(repo *Repository, initial appsettings.Source) *AppSettingsSource {
+	return &AppSettingsSource{repo: repo, initial: initial}
+}
+
+func (s *AppSettingsSource) String() string {
+	return "postgres"
+}
+
+func (s *AppSettingsSource) Load(ctx context.Context) (*appsettings.Settings, error) {
+	settings, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(settings.Countries) > 0 || len(settings.Languages) > 0 || s.initial == nil {
+		return settings, nil
+	}
+
+	initial, err := s.initial.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can not load initial app settings (source = %v): %w", s.initial, err)
+	}
+	if err := initial.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid initial app settings (source = %v): %w", s.initial, err)
+	}
+	if err := s.repo.ImportAppSettings(ctx, initial); err != nil {
+		return nil, err
+	}
+	fxlogger.FromContext(ctx, s.repo.logger).Info("app settings are imported to database", zap.Stringer("source", s.initial))
+
+	return s.load(ctx)
+}
+
+func (s *AppSettingsSource) load(ctx context.Context) (*appsettings.Settings, error) {
+	regions, err := s.repo.GetRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	countries, err := s.repo.GetCountries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.repo.GetLanguages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &appsettings.Settings{
+		Countries: make([]*appsettings.Country, 0, len(countries)),
+		Regions:   make([]*appsettings.Region, 0, len(regions)),
+		Languages: make([]*appsettings.Language, 0, len(languages)),
+	}
+	for _, r := range regions {
+		settings.Regions = append(settings.Regions, &appsettings.Region{
+			RegionCode:         r.Code,
+			Enabled:            r.Enabled,
+			DisplayName:        r.DisplayName,
+			DefaultTimezone:    r.DefaultTimezone,
+			DataResidencyNotes: r.DataResidencyNotes,
+		})
+	}
+	for _, c := range countries {
+		settings.Countries = append(settings.Countries, &appsettings.Country{
+			CountryCode: c.Code,
+			Enabled:     c.Enabled,
+			Regions:     c.Regions,
+		})
+	}
+	for _, l := range languages {
+		settings.Languages = append(settings.Languages, &appsettings.Language{
+			LangaugeCode: l.Code,
+			Enabled:      l.Enabled,
+		})
+	}
+
+	return settings, nil
+}
+
+// ImportAppSettings inserts settings which are not in database yet. Existing
+// rows are left untouched. Regions without metadata get region code as
+// display name and UTC as default timezone. Settings are imported in one
+// transaction, so failed import leaves no partial settings.
+func (r *Repository) ImportAppSettings(ctx context.Context, settings *appsettings.Settings) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		return r.importAppSettings(ctx, settings)
+	})
+}
+
+func (r *Repository) importAppSettings(ctx context.Context, settings *appsettings.Settings) error {
+	regions := map[string]*appsettings.Region{}
+	for _, region := range settings.Regions {
+		regions[region.RegionCode] = region
+	}
+	for _, c := range settings.Countries {
+		for _, code := range c.Regions {
+			if _, ok := regions[code]; !ok {
+				regions[code] = &appsettings.Region{RegionCode: code, Enabled: true, DisplayName: code, DefaultTimezone: "UTC"}
+			}
+		}
+	}
+
+	for _, region := range regions {
+		q := `insert into regions
+  (code, enabled, display_name, default_timezone, data_residency_notes)
+  values ($1, $2, $3, $4, $5)
+  on conflict do nothing;`
+		if err := r.driver.ExecuteQuery(ctx, q, region.RegionCode, region.Enabled, region.DisplayName, region.DefaultTimezone, region.DataResidencyNotes); err != nil {
+			return fmt.Errorf("can not import region (region code = %v): %w", region.RegionCode, err)
+		}
+	}
+	for _, c := range settings.Countries {
+		q := `insert into countries (code, enabled) values ($1, $2) on conflict do nothing;`
+		if err := r.driver.ExecuteQuery(ctx, q, c.CountryCode, c.Enabled); err != nil {
+			return fmt.Errorf("can not import country (country code = %v): %w", c.CountryCode, err)
+		}
+		q = `insert into countries_regions_relations (country_code, region_code)
+  select $1, unnest($2::text[])
+  on conflict do nothing;`
+		if err := r.driver.ExecuteQuery(ctx, q, c.CountryCode, c.Regions); err != nil {
+			return fmt.Errorf("can not import country regions (country code = %v): %w", c.CountryCode, err)
+		}
+	}
+	for _, l := range settings.Languages {
+		q := `insert into languages (code, enabled) values ($1, $2) on conflict do nothing;`
+		if err := r.driver.ExecuteQuery(ctx, q, l.LangaugeCode, l.Enabled); err != nil {
+			return fmt.Errorf("can not import language (language code = %v): %w", l.LangaugeCode, err)
+		}
+	}
+
+	return nil
+}
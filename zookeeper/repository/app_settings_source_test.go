@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type staticSource struct {
+	settings *appsettings.Settings
+}
+
+func (s *staticSource) Load(context.Context) (*appsettings.Settings, error) {
+	return s.settings, nil
+}
+
+func (s *staticSource) String() string {
+	return "static"
+}
+
+func testSettings() *appsettings.Settings {
+	return &appsettings.Settings{
+		Countries: []*appsettings.Country{{CountryCode: "ukr", Enabled: true, Regions: []string{"eu_east"}}},
+		Regions:   []*appsettings.Region{{RegionCode: "eu_east", Enabled: true, DisplayName: "Eastern Europe", DefaultTimezone: "Europe/Kyiv"}},
+		Languages: []*appsettings.Language{{LangaugeCode: "ukr", Enabled: true}},
+	}
+}
+
+func TestImportAppSettingsIsAtomic(t *testing.T) {
+	failed := errors.New("connection is lost")
+	driver := &pgxtest.Driver{
+		OnExec: func(_ context.Context, query string, _ ...interface{}) error {
+			if strings.Contains(query, "languages") {
+				return failed
+			}
+			return nil
+		},
+	}
+	repo := NewWithDriver(driver, zap.NewNop())
+
+	err := repo.ImportAppSettings(context.Background(), testSettings())
+	require.ErrorIs(t, err, failed)
+
+	queries := driver.Queries()
+	require.NotEmpty(t, queries)
+	for _, q := range queries {
+		assert.NotZero(t, q.Tx, "query is executed outside of transaction: %v", q.SQL)
+	}
+	assert.Equal(t, 1, driver.RolledBack())
+	assert.Empty(t, driver.Committed(), "partial settings are imported")
+}
+
+// settingsDriver serves settings stored by import, like database would.
+func settingsDriver() *pgxtest.Driver {
+	now := time.Now()
+	var regions, countries, languages [][]interface{}
+	d := &pgxtest.Driver{}
+	d.OnExec = func(_ context.Context, query string, args ...interface{}) error {
+		switch {
+		case strings.Contains(query, "insert into regions"):
+			regions = append(regions, []interface{}{args[0], now, now, args[1], args[2], args[3], args[4]})
+		case strings.Contains(query, "insert into countries ("):
+			countries = append(countries, []interface{}{args[0], now, now, args[1], []string{"eu_east"}})
+		case strings.Contains(query, "insert into languages"):
+			languages = append(languages, []interface{}{args[0], now, now, args[1]})
+		}
+		return nil
+	}
+	d.OnQueryRows = func(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+		switch {
+		case strings.Contains(query, "from regions"):
+			return pgxtest.Rows(regions...), nil
+		case strings.Contains(query, "from countries"):
+			return pgxtest.Rows(countries...), nil
+		case strings.Contains(query, "from languages"):
+			return pgxtest.Rows(languages...), nil
+		}
+		return pgxtest.Rows(), nil
+	}
+
+	return d
+}
+
+func TestAppSettingsSourceImportsInitialSettings(t *testing.T) {
+	driver := settingsDriver()
+	source := NewAppSettingsSource(NewWithDriver(driver, zap.NewNop()), &staticSource{settings: testSettings()})
+
+	settings, err := source.Load(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, settings.Validate())
+	require.Len(t, settings.Countries, 1)
+	assert.Equal(t, "ukr", settings.Countries[0].CountryCode)
+	require.Len(t, settings.Regions, 1)
+	assert.Equal(t, "Eastern Europe", settings.Regions[0].DisplayName)
+	require.Len(t, settings.Languages, 1)
+
+	// settings are in database now, so they are not imported again.
+	imported := len(driver.Queries())
+	_, err = source.Load(context.Background())
+	require.NoError(t, err)
+	for _, q := range driver.Queries()[imported:] {
+		assert.NotContains(t, q.SQL, "insert into", "settings are imported again")
+	}
+}
+
+func TestAppSettingsSourceRejectsInvalidInitialSettings(t *testing.T) {
+	driver := settingsDriver()
+	invalid := testSettings()
+	invalid.Languages = nil
+	source := NewAppSettingsSource(NewWithDriver(driver, zap.NewNop()), &staticSource{settings: invalid})
+
+	_, err := source.Load(context.Background())
+	require.Error(t, err)
+	for _, q := range driver.Queries() {
+		assert.NotContains(t, q.SQL, "insert into", "invalid settings are imported")
+	}
+}
+
+func TestAppSettingsSourceReload(t *testing.T) {
+	driver := settingsDriver()
+	m := appsettings.New(NewAppSettingsSource(NewWithDriver(driver, zap.NewNop()), &staticSource{settings: testSettings()}))
+
+	status, err := m.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), status.Version)
+	assert.Equal(t, "postgres", status.Source)
+
+	// admin adds language in other process.
+	require.NoError(t, driver.ExecuteQuery(context.Background(), "insert into languages (code, enabled) values ($1, $2)", "eng", true))
+	status, err = m.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), status.Version)
+	assert.NoError(t, m.ValidateLanguageCode("eng"))
+
+	// nothing is changed, version is kept.
+	status, err = m.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), status.Version)
+}
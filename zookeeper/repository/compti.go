@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -31,11 +32,11 @@ func (r *Repository) InsertComptus(ctx context.Context, email, passwordHash, pat
 	updatedAt := time.Now()
 	tombstoned := false
 
-	query := `insert into public.compti
+	query := `insert into compti
   (id, created_at, updated_at, tombstoned, email, password_hash, patria, lingua)
   values ($1, $2, $3, $4, $5, $6, $7, $8);`
 	params := []interface{}{id, createdAt, updatedAt, tombstoned, email, passwordHash, patria, lingua}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -54,8 +55,8 @@ func (r *Repository) InsertComptus(ctx context.Context, email, passwordHash, pat
 func (r *Repository) GetComptusByID(ctx context.Context, id int64) (*models.Comptus, error) {
 	q := `
   select
-    id, created_at, updated_at, deleted_at, tombstoned, email, password_hash, patria, lingua
-  from public.compti
+    id, created_at, updated_at, deleted_at, tombstoned, email, password_hash, patria, lingua, suspended_at
+  from compti
   where id=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -73,6 +74,7 @@ func (r *Repository) GetComptusByID(ctx context.Context, id int64) (*models.Comp
 		&c.PasswordHash,
 		&c.Patria,
 		&c.Lingua,
+		&c.SuspendedAt,
 	)
 	if err == nil {
 		return &c, nil
@@ -88,8 +90,8 @@ func (r *Repository) GetComptusByID(ctx context.Context, id int64) (*models.Comp
 func (r *Repository) GetComptusByEmail(ctx context.Context, email string) (*models.Comptus, error) {
 	q := `
   select
-    id, created_at, updated_at, deleted_at, tombstoned, email, password_hash, patria, lingua
-  from public.compti
+    id, created_at, updated_at, deleted_at, tombstoned, email, password_hash, patria, lingua, suspended_at
+  from compti
   where email=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, email)
 	if err != nil {
@@ -107,6 +109,7 @@ func (r *Repository) GetComptusByEmail(ctx context.Context, email string) (*mode
 		&c.PasswordHash,
 		&c.Patria,
 		&c.Lingua,
+		&c.SuspendedAt,
 	)
 	if err == nil {
 		return &c, nil
@@ -118,3 +121,65 @@ func (r *Repository) GetComptusByEmail(ctx context.Context, email string) (*mode
 
 	return nil, err
 }
+
+func (r *Repository) SetComptusPasswordHashByID(ctx context.Context, id int64, passwordHash string) error {
+	return r.driver.ExecuteQuery(ctx, "update compti set updated_at = $2, password_hash = $3 where id=$1", id, time.Now(), passwordHash)
+}
+
+func (r *Repository) CountComptiByPatria(ctx context.Context, patria string) (int, error) {
+	q := `select count(*) from compti where patria=$1 and tombstoned=false;`
+	return r.driver.CountRows(ctx, q, patria)
+}
+
+func (r *Repository) CountComptiByLingua(ctx context.Context, lingua string) (int, error) {
+	q := `select count(*) from compti where lingua=$1 and tombstoned=false;`
+	return r.driver.CountRows(ctx, q, lingua)
+}
+
+// GetCompti returns page of compti, the newest first. Empty email matches
+// all compti.
+func (r *Repository) GetCompti(ctx context.Context, email string, limit, offset int) ([]*models.Comptus, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, email, password_hash, patria, lingua, suspended_at
+  from compti
+  where tombstoned=false and ($1='' or email=$1)
+  order by created_at desc, id desc
+  limit $2 offset $3;`
+	rows, err := r.driver.QueryRows(ctx, q, email, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var compti []*models.Comptus
+	for rows.Next() {
+		var c models.Comptus
+		if err := rows.Scan(
+			&c.ID,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+			&c.DeletedAt,
+			&c.Tombstoned,
+			&c.Email,
+			&c.PasswordHash,
+			&c.Patria,
+			&c.Lingua,
+			&c.SuspendedAt,
+		); err != nil {
+			return nil, err
+		}
+		compti = append(compti, &c)
+	}
+
+	return compti, rows.Err()
+}
+
+// SetComptusSuspendedAtByID suspends comptus, null time lifts suspension.
+func (r *Repository) SetComptusSuspendedAtByID(ctx context.Context, id int64, suspendedAt sql.NullTime) error {
+	return r.driver.ExecuteQuery(ctx, "update compti set updated_at = $2, suspended_at = $3 where id=$1", id, time.Now(), suspendedAt)
+}
+
+func (r *Repository) SetComptusTombstonedByID(ctx context.Context, id int64) error {
+	return r.driver.ExecuteQuery(ctx, "update compti set updated_at = $2, deleted_at = $2, tombstoned = true where id=$1", id, time.Now())
+}
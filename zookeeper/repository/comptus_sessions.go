@@ -24,11 +24,11 @@ func (r *Repository) InsertComptusSession(ctx context.Context, comptusID int64,
 		return nil, fmt.Errorf("expired at can not be before created at (expired at = %v, created at = %v)", timeutils.TimeToString(expiredAt), timeutils.TimeToString(createdAt))
 	}
 
-	query := `insert into public.comptus_sessions
+	query := `insert into comptus_sessions
   (id, created_at, updated_at, expired_at, tombstoned, comptus_id, token, refresh_token)
   values ($1, $2, $3, $4, $5, $6, $7, $8);`
 	params := []interface{}{id, createdAt, updatedAt, expiredAt, tombstoned, comptusID, t, rt}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -72,7 +72,7 @@ func (r *Repository) GetComptusSessionByID(ctx context.Context, id int64) (*mode
 	q := `
   select
     id, created_at, updated_at, expired_at, deleted_at, tombstoned, comptus_id, token, refresh_token
-  from public.comptus_sessions
+  from comptus_sessions
   where id=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -86,7 +86,7 @@ func (r *Repository) GetComptusSessionByComptusIDAndToken(ctx context.Context, c
 	q := `
   select
     id, created_at, updated_at, expired_at, deleted_at, tombstoned, comptus_id, token, refresh_token
-  from public.comptus_sessions
+  from comptus_sessions
   where comptus_id=$1 and token=$2 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, comptusID, token)
 	if err != nil {
@@ -100,7 +100,7 @@ func (r *Repository) GetComptusSessionByComptusIDAndRefreshToken(ctx context.Con
 	q := `
   select
     id, created_at, updated_at, expired_at, deleted_at, tombstoned, comptus_id, token, refresh_token
-  from public.comptus_sessions
+  from comptus_sessions
   where comptus_id=$1 and refresh_token=$2 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, comptusID, refreshToken)
 	if err != nil {
@@ -111,9 +111,13 @@ func (r *Repository) GetComptusSessionByComptusIDAndRefreshToken(ctx context.Con
 }
 
 func (r *Repository) SetComptusSessionTombstonedByID(ctx context.Context, id int64) error {
-	return r.driver.ExecuteQuery(ctx, "update public.comptus_sessions set tombstoned = true where id=$1", id)
+	return r.driver.ExecuteQuery(ctx, "update comptus_sessions set tombstoned = true where id=$1", id)
 }
 
 func (r *Repository) SetComptusSessionTokensByID(ctx context.Context, id int64, t string, rt string, expiredAt time.Time) error {
-	return r.driver.ExecuteQuery(ctx, "update public.comptus_sessions set updated_at = $2, expired_at = $3, token = $4, refresh_token = $5 where id=$1", id, time.Now(), expiredAt, t, rt)
+	return r.driver.ExecuteQuery(ctx, "update comptus_sessions set updated_at = $2, expired_at = $3, token = $4, refresh_token = $5 where id=$1", id, time.Now(), expiredAt, t, rt)
+}
+
+func (r *Repository) SetComptusSessionsTombstonedByComptusID(ctx context.Context, comptusID int64) error {
+	return r.driver.ExecuteQuery(ctx, "update comptus_sessions set tombstoned = true where comptus_id=$1", comptusID)
 }
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/errorsutils"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/jackc/pgx/v4"
+)
+
+// EventsChannel is channel of postgres notifications which are sent when
+// events are inserted to outbox. Payload is ID of event.
+const EventsChannel = "outbox_events"
+
+// outboxLockID is key of advisory lock which serializes inserts to outbox.
+const outboxLockID = 7710001
+
+// InTx calls fn in transaction, repository methods called with context passed
+// to fn are executed in it.
+func (r *Repository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.driver.InTx(ctx, fn)
+}
+
+// InsertEvent writes event to outbox. It should be called with context of
+// transaction of the change which produced event, otherwise event is
+// written in its own transaction.
+func (r *Repository) InsertEvent(ctx context.Context, typ models.EventType, aggregateID int64, data []byte) (*models.Event, error) {
+	e := &models.Event{
+		CreatedAt:   time.Now(),
+		Type:        typ,
+		AggregateID: aggregateID,
+		Data:        data,
+	}
+	err := r.driver.InTx(ctx, func(ctx context.Context) error {
+		// lock is held until commit, so events become visible in order of
+		// their ids and consumer offsets never skip events.
+		if err := r.driver.ExecuteQuery(ctx, "select pg_advisory_xact_lock($1);", outboxLockID); err != nil {
+			return err
+		}
+		query := `insert into outbox_events
+  (created_at, type, aggregate_id, data)
+  values ($1, $2, $3, $4)
+  returning id;`
+		row, err := r.driver.QueryRow(ctx, query, e.CreatedAt, typ, aggregateID, string(data))
+		if err != nil {
+			return err
+		}
+		if err := row.Scan(&e.ID); err != nil {
+			return err
+		}
+
+		// notification is sent on commit.
+		return r.driver.ExecuteQuery(ctx, "select pg_notify($1, $2);", EventsChannel, fmt.Sprint(e.ID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (r *Repository) GetEventsAfter(ctx context.Context, afterID int64, limit int) ([]*models.Event, error) {
+	q := `
+  select
+    id, created_at, type, aggregate_id, data
+  from outbox_events
+  where id > $1
+  order by id
+  limit $2;`
+	rows, err := r.driver.QueryRows(ctx, q, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		var e models.Event
+		if err := rows.Scan(&e.ID, &e.CreatedAt, &e.Type, &e.AggregateID, &e.Data); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+
+	return events, rows.Err()
+}
+
+// LockConsumerOffset returns offset of consumer and locks it until end of
+// transaction. It returns nil if offset is locked by another replica. New
+// consumer starts from the newest event of outbox if fromNewest is true,
+// otherwise from the oldest one.
+func (r *Repository) LockConsumerOffset(ctx context.Context, consumer string, fromNewest bool) (*models.ConsumerOffset, error) {
+	query := `insert into outbox_consumer_offsets
+  (consumer, updated_at, last_event_id)
+  values ($1, $2, case when $3 then (select coalesce(max(id), 0) from outbox_events) else 0 end)
+  on conflict (consumer) do nothing;`
+	if err := r.driver.ExecuteQuery(ctx, query, consumer, time.Now(), fromNewest); err != nil {
+		return nil, err
+	}
+
+	q := `
+  select last_event_id, attempts
+  from outbox_consumer_offsets
+  where consumer=$1
+  for update skip locked;`
+	row, err := r.driver.QueryRow(ctx, q, consumer)
+	if err != nil {
+		return nil, err
+	}
+	var offset models.ConsumerOffset
+	if err := row.Scan(&offset.LastEventID, &offset.Attempts); err != nil {
+		if errorsutils.Equals(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &offset, nil
+}
+
+// SetConsumerOffset moves consumer past event and resets its failed
+// attempts.
+func (r *Repository) SetConsumerOffset(ctx context.Context, consumer string, lastEventID int64) error {
+	query := `update outbox_consumer_offsets
+  set updated_at=$2, last_event_id=$3, attempts=0, last_error=null
+  where consumer=$1;`
+
+	return r.driver.ExecuteQuery(ctx, query, consumer, time.Now(), lastEventID)
+}
+
+// SetConsumerFailure stores failed attempts to handle the next event of
+// consumer.
+func (r *Repository) SetConsumerFailure(ctx context.Context, consumer string, attempts int, lastError string) error {
+	query := `update outbox_consumer_offsets
+  set updated_at=$2, attempts=$3, last_error=$4
+  where consumer=$1;`
+
+	return r.driver.ExecuteQuery(ctx, query, consumer, time.Now(), attempts, lastError)
+}
+
+// InsertDeadLetter stores copy of event which consumer failed to handle.
+func (r *Repository) InsertDeadLetter(ctx context.Context, consumer string, e *models.Event, attempts int, lastError string) error {
+	query := `insert into outbox_dead_letters
+  (created_at, consumer, event_id, type, aggregate_id, data, attempts, error)
+  values ($1, $2, $3, $4, $5, $6, $7, $8)
+  on conflict (consumer, event_id) do nothing;`
+	params := []interface{}{time.Now(), consumer, e.ID, e.Type, e.AggregateID, string(e.Data), attempts, lastError}
+
+	return r.driver.ExecuteQuery(ctx, query, params...)
+}
+
+// InsertAuditRecord stores event in audit log. Repeated event is ignored.
+func (r *Repository) InsertAuditRecord(ctx context.Context, e *models.Event) error {
+	query := `insert into audit_log
+  (created_at, event_id, occurred_at, type, aggregate_id, data)
+  values ($1, $2, $3, $4, $5, $6)
+  on conflict (event_id) do nothing;`
+	params := []interface{}{time.Now(), e.ID, e.CreatedAt, e.Type, e.AggregateID, string(e.Data)}
+
+	return r.driver.ExecuteQuery(ctx, query, params...)
+}
+
+// DeleteConsumedEvents deletes events created before given time which are
+// handled by all consumers. It returns number of deleted events.
+func (r *Repository) DeleteConsumedEvents(ctx context.Context, before time.Time) (int64, error) {
+	q := `
+  with deleted as (
+    delete from outbox_events
+    where created_at < $1
+      and id <= (select coalesce(min(last_event_id), 0) from outbox_consumer_offsets)
+    returning 1
+  )
+  select count(*) from deleted;`
+	row, err := r.driver.QueryRow(ctx, q, before)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListenEvents calls handle for every event inserted to outbox until ctx is
+// done or connection fails.
+func (r *Repository) ListenEvents(ctx context.Context, handle func(id string)) error {
+	return r.driver.Listen(ctx, EventsChannel, handle)
+}
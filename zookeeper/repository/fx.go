@@ -1,7 +1,32 @@
 package repository
 
-import "go.uber.org/fx"
+import (
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/httputils"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"go.uber.org/fx"
+)
 
 var Module = fx.Options(
 	fx.Provide(New),
+	fx.Provide(
+		fxhealth.AsCheck(newPostgresCheck),
+		fxhealth.AsCheck(newMigrationsCheck),
+	),
+	fx.Provide(newAppSettingsSource),
+	fx.Provide(func(r *Repository) httputils.IdempotencyStore { return r }),
 )
+
+// newAppSettingsSource returns nil if app settings are served from files, in
+// that case fxappsettings falls back to its file source.
+func newAppSettingsSource(cfg *config.Config, repo *Repository) appsettings.Source {
+	if cfg.AppSettingsSource != config.PostgresAppSettingsSource {
+		return nil
+	}
+
+	return NewAppSettingsSource(repo, &appsettings.FileSource{
+		LocalesPath: cfg.LocalesPath,
+		RegionsPath: cfg.RegionsPath,
+	})
+}
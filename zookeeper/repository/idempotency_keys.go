@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/httputils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/errorsutils"
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repository) ReserveIdempotencyKey(ctx context.Context, scope, key, fingerprint string, expiresAt, staleBefore time.Time) (*httputils.IdempotencyRecord, error) {
+	query := `insert into idempotency_keys
+  (scope, key, fingerprint, created_at, expires_at)
+  values ($1, $2, $3, $4, $5)
+  on conflict (scope, key) do update
+  set fingerprint=excluded.fingerprint, created_at=excluded.created_at, expires_at=excluded.expires_at,
+    completed=false, status_code=0, header=null, body=null
+  where idempotency_keys.expires_at < excluded.created_at
+    or (not idempotency_keys.completed and idempotency_keys.created_at < $6)
+  returning key;`
+	params := []interface{}{scope, key, fingerprint, time.Now(), expiresAt, staleBefore}
+	row, err := r.driver.QueryRow(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	var reserved string
+	err = row.Scan(&reserved)
+	if err == nil {
+		return nil, nil
+	}
+	if !errorsutils.Equals(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return r.getIdempotencyKey(ctx, scope, key)
+}
+
+func (r *Repository) getIdempotencyKey(ctx context.Context, scope, key string) (*httputils.IdempotencyRecord, error) {
+	q := `
+  select
+    fingerprint, completed, status_code, header, body
+  from idempotency_keys
+  where scope=$1 and key=$2;`
+	row, err := r.driver.QueryRow(ctx, q, scope, key)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		rec       httputils.IdempotencyRecord
+		completed bool
+		resp      httputils.IdempotencyResponse
+		header    []byte
+	)
+	if err := row.Scan(&rec.Fingerprint, &completed, &resp.StatusCode, &header, &resp.Body); err != nil {
+		// key was released or removed after it was reserved by another
+		// request, it is treated as in progress, so client retries later.
+		if errorsutils.Equals(err, pgx.ErrNoRows) {
+			return &rec, nil
+		}
+		return nil, err
+	}
+	if !completed {
+		return &rec, nil
+	}
+	resp.Header = http.Header{}
+	if len(header) > 0 {
+		if err := json.Unmarshal(header, &resp.Header); err != nil {
+			return nil, err
+		}
+	}
+	rec.Response = &resp
+
+	return &rec, nil
+}
+
+func (r *Repository) CompleteIdempotencyKey(ctx context.Context, scope, key string, resp *httputils.IdempotencyResponse) error {
+	header, err := json.Marshal(resp.Header)
+	if err != nil {
+		return err
+	}
+
+	query := `update idempotency_keys
+  set completed=true, status_code=$3, header=$4, body=$5
+  where scope=$1 and key=$2;`
+	params := []interface{}{scope, key, resp.StatusCode, string(header), resp.Body}
+
+	return r.driver.ExecuteQuery(ctx, query, params...)
+}
+
+func (r *Repository) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	query := `delete from idempotency_keys where scope=$1 and key=$2 and not completed;`
+
+	return r.driver.ExecuteQuery(ctx, query, scope, key)
+}
+
+// DeleteExpiredIdempotencyKeys returns number of deleted keys.
+func (r *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	q := `
+  with deleted as (
+    delete from idempotency_keys where expires_at < $1 returning 1
+  )
+  select count(*) from deleted;`
+	row, err := r.driver.QueryRow(ctx, q, now)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -24,11 +24,11 @@ func (r *Repository) InsertOrbisSociusLaunchInvite(ctx context.Context, comptusI
 	}
 	used := false
 
-	query := `insert into public.orbes_socii_launch_invites
+	query := `insert into orbes_socii_launch_invites
   (id, created_at, comptus_id, admin_id, orbis_socius_id, code, api_key, used, orbis_socius_launch_request_id, expired_at)
   values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`
 	params := []interface{}{id, createdAt, comptusID, adminID, orbisSociusID, code, apiKey, used, osLaunchReID, expiredAt}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -90,7 +90,7 @@ func (r *Repository) GetOrbisSociusLaunchInviteByID(ctx context.Context, id int6
 	q := `
   select
     id, created_at, comptus_id, admin_id, orbis_socius_id, code, api_key, used, orbis_socius_launch_request_id, expired_at
-  from public.orbes_socii_launch_invites
+  from orbes_socii_launch_invites
   where id=$1;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -107,11 +107,11 @@ func (r *Repository) InsertOrbisSociusLaunchRequest(ctx context.Context, comptus
 	}
 	createdAt := time.Now()
 
-	query := `insert into public.orbes_socii_launch_requests
+	query := `insert into orbes_socii_launch_requests
   (id, created_at, comptus_id, region, orbis_socius_name, orbis_socius_description, orbis_socius_url, status)
   values ($1, $2, $3, $4, $5, $6, $7, $8);`
 	params := []interface{}{id, createdAt, comptusID, region, name, desc, url, status}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -154,7 +154,7 @@ func (r *Repository) GetOrbisSociusLaunchRequestByID(ctx context.Context, id int
 	q := `
   select
     id, created_at, comptus_id, region, orbis_socius_name, orbis_socius_description, orbis_socius_url, status
-  from public.orbes_socii_launch_requests
+  from orbes_socii_launch_requests
   where id=$1;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -164,20 +164,29 @@ func (r *Repository) GetOrbisSociusLaunchRequestByID(ctx context.Context, id int
 	return scanRowOrbisSociusLaunchRequest(row)
 }
 
-func (r *Repository) InsertOrbisSociusStats(ctx context.Context, orbisSociusID *int64, alive bool) (*models.OrbisSociusStat, error) {
-	id, err := random.GetSnowflakeID[models.OrbisSociusStat](ctx, 0, r.GetOrbisSociusStatsByID)
+// LockOrbisSociusLaunchRequestByID returns launch request and locks it until
+// the end of transaction, so it is reviewed only once.
+func (r *Repository) LockOrbisSociusLaunchRequestByID(ctx context.Context, id int64) (*models.OrbisSociusLaunchRequest, error) {
+	q := `
+  select
+    id, created_at, comptus_id, region, orbis_socius_name, orbis_socius_description, orbis_socius_url, status
+  from orbes_socii_launch_requests
+  where id=$1
+  for update;`
+	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
 		return nil, err
 	}
-	createdAt := time.Now()
 
-	query := `insert into public.orbes_socii_stats
-  (id, created_at, orbis_socius_id, alive)
-  values ($1, $2, $3, $4);`
-	params := []interface{}{id, createdAt, orbisSociusID, alive}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	return scanRowOrbisSociusLaunchRequest(row)
+}
+
+func (r *Repository) InsertOrbisSociusStats(ctx context.Context, orbisSociusID *int64, alive bool, heartbeat *models.OrbisSociusHeartbeat) (*models.OrbisSociusStat, error) {
+	id, err := random.GetSnowflakeID[models.OrbisSociusStat](ctx, 0, r.GetOrbisSociusStatsByID)
+	if err != nil {
 		return nil, err
 	}
+	createdAt := time.Now()
 
 	var sqlOrbisSociusID sql.NullInt64
 	if orbisSociusID != nil {
@@ -186,13 +195,28 @@ func (r *Repository) InsertOrbisSociusStats(ctx context.Context, orbisSociusID *
 			Int64: *orbisSociusID,
 		}
 	}
-
-	return &models.OrbisSociusStat{
+	s := &models.OrbisSociusStat{
 		ID:            id,
 		CreatedAt:     createdAt,
 		OrbisSociusID: sqlOrbisSociusID,
 		Alive:         alive,
-	}, nil
+	}
+	if heartbeat != nil {
+		s.Version = sql.NullString{String: heartbeat.Version, Valid: true}
+		s.ProtocolVersion = sql.NullInt64{Int64: int64(heartbeat.ProtocolVersion), Valid: heartbeat.ProtocolVersion > 0}
+		s.Members = sql.NullInt64{Int64: heartbeat.Members, Valid: true}
+		s.Capacity = sql.NullInt64{Int64: heartbeat.Capacity, Valid: true}
+	}
+
+	query := `insert into orbes_socii_stats
+  (id, created_at, orbis_socius_id, alive, version, protocol_version, members, capacity)
+  values ($1, $2, $3, $4, $5, $6, $7, $8);`
+	params := []interface{}{id, createdAt, orbisSociusID, alive, s.Version, s.ProtocolVersion, s.Members, s.Capacity}
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
+		return nil, err
+	}
+
+	return s, nil
 }
 
 func scanRowOrbisSociusStats(row pgx.Row) (*models.OrbisSociusStat, error) {
@@ -202,6 +226,10 @@ func scanRowOrbisSociusStats(row pgx.Row) (*models.OrbisSociusStat, error) {
 		&s.CreatedAt,
 		&s.OrbisSociusID,
 		&s.Alive,
+		&s.Version,
+		&s.ProtocolVersion,
+		&s.Members,
+		&s.Capacity,
 	)
 	if err == nil {
 		return &s, nil
@@ -217,8 +245,8 @@ func scanRowOrbisSociusStats(row pgx.Row) (*models.OrbisSociusStat, error) {
 func (r *Repository) GetOrbisSociusStatsByID(ctx context.Context, id int64) (*models.OrbisSociusStat, error) {
 	q := `
   select
-    id, created_at, orbis_socius_id, alive
-  from public.orbes_socii_stats
+    id, created_at, orbis_socius_id, alive, version, protocol_version, members, capacity
+  from orbes_socii_stats
   where id=$1;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -236,12 +264,13 @@ func (r *Repository) InsertOrbisSocius(ctx context.Context, ownerComptusID int64
 	createdAt := time.Now()
 	updatedAt := time.Now()
 	tombstoned := false
+	robustnessStatus := models.Vulnerable
 
-	query := `insert into public.orbes_socii
-  (id, created_at, updated_at, tombstoned, owner_comptus_id, approver_admin_id, region, name, description, url, api_key)
-  values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`
-	params := []interface{}{id, createdAt, updatedAt, tombstoned, ownerComptusID, approverAdminID, region, name, desc, url, apiKey}
-	if _, err := r.driver.QueryRow(ctx, query, params...); err != nil {
+	query := `insert into orbes_socii
+  (id, created_at, updated_at, tombstoned, owner_comptus_id, approver_admin_id, robustness_status, region, name, description, url, api_key)
+  values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);`
+	params := []interface{}{id, createdAt, updatedAt, tombstoned, ownerComptusID, approverAdminID, robustnessStatus, region, name, desc, url, apiKey}
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
 		return nil, err
 	}
 
@@ -254,17 +283,19 @@ func (r *Repository) InsertOrbisSocius(ctx context.Context, ownerComptusID int64
 	}
 
 	return &models.OrbisSocius{
-		ID:              id,
-		CreatedAt:       createdAt,
-		UpdatedAt:       updatedAt,
-		Tombstoned:      tombstoned,
-		OwnerComptusID:  ownerComptusID,
-		ApproverAdminID: sqlApproverAdminID,
-		Region:          region,
-		Name:            name,
-		Description:     desc,
-		URL:             url,
-		APIKey:          apiKey,
+		ID:               id,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+		Tombstoned:       tombstoned,
+		OwnerComptusID:   ownerComptusID,
+		ApproverAdminID:  sqlApproverAdminID,
+		RobustnessStatus: robustnessStatus,
+		Region:           region,
+		Name:             name,
+		Description:      desc,
+		URL:              url,
+		APIKey:           apiKey,
+		Compliant:        true,
 	}, nil
 }
 
@@ -286,6 +317,9 @@ func scanRowOrbisSocius(row pgx.Row) (*models.OrbisSocius, error) {
 		&os.Description,
 		&os.URL,
 		&os.APIKey,
+		&os.Version,
+		&os.ProtocolVersion,
+		&os.Compliant,
 	)
 	if err == nil {
 		return &os, nil
@@ -301,8 +335,8 @@ func scanRowOrbisSocius(row pgx.Row) (*models.OrbisSocius, error) {
 func (r *Repository) GetOrbisSociusByID(ctx context.Context, id int64) (*models.OrbisSocius, error) {
 	q := `
   select
-    id, created_at, updated_at, deleted_at, tombstoned, owner_comptus_id, approver_admin_id, alive, robustness_status, last_pinged_at, region, name, description, url, api_key
-  from public.orbes_socii
+    id, created_at, updated_at, deleted_at, tombstoned, owner_comptus_id, approver_admin_id, alive, robustness_status, last_pinged_at, region, name, description, url, api_key, version, protocol_version, compliant
+  from orbes_socii
   where id=$1 and tombstoned=false;`
 	row, err := r.driver.QueryRow(ctx, q, id)
 	if err != nil {
@@ -311,3 +345,240 @@ func (r *Repository) GetOrbisSociusByID(ctx context.Context, id int64) (*models.
 
 	return scanRowOrbisSocius(row)
 }
+
+func (r *Repository) GetOrbisSociusByURL(ctx context.Context, url string) (*models.OrbisSocius, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, owner_comptus_id, approver_admin_id, alive, robustness_status, last_pinged_at, region, name, description, url, api_key, version, protocol_version, compliant
+  from orbes_socii
+  where url=$1 and tombstoned=false;`
+	row, err := r.driver.QueryRow(ctx, q, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowOrbisSocius(row)
+}
+
+func (r *Repository) GetOrbesSociiByRegion(ctx context.Context, region string) ([]*models.OrbisSocius, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, owner_comptus_id, approver_admin_id, alive, robustness_status, last_pinged_at, region, name, description, url, api_key, version, protocol_version, compliant
+  from orbes_socii
+  where region=$1 and tombstoned=false
+  order by id;`
+	rows, err := r.driver.QueryRows(ctx, q, region)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orbesSocii []*models.OrbisSocius
+	for rows.Next() {
+		o, err := scanRowOrbisSocius(rows)
+		if err != nil {
+			return nil, err
+		}
+		orbesSocii = append(orbesSocii, o)
+	}
+
+	return orbesSocii, rows.Err()
+}
+
+func (r *Repository) GetOrbisSociusLaunchRequests(ctx context.Context, status *models.OrbisSociusLaunchRequestStatus) ([]*models.OrbisSociusLaunchRequest, error) {
+	q := `
+  select
+    id, created_at, comptus_id, region, orbis_socius_name, orbis_socius_description, orbis_socius_url, status
+  from orbes_socii_launch_requests
+  where $1::integer is null or status=$1
+  order by created_at desc, id desc;`
+	rows, err := r.driver.QueryRows(ctx, q, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*models.OrbisSociusLaunchRequest
+	for rows.Next() {
+		req, err := scanRowOrbisSociusLaunchRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+
+	return requests, rows.Err()
+}
+
+func (r *Repository) SetOrbisSociusLaunchRequestStatusByID(ctx context.Context, id int64, status models.OrbisSociusLaunchRequestStatus) error {
+	return r.driver.ExecuteQuery(ctx, "update orbes_socii_launch_requests set status = $2 where id=$1", id, status)
+}
+
+func (r *Repository) GetOrbisSociusStatsByOrbisSociusID(ctx context.Context, orbisSociusID int64, limit int) ([]*models.OrbisSociusStat, error) {
+	q := `
+  select
+    id, created_at, orbis_socius_id, alive, version, protocol_version, members, capacity
+  from orbes_socii_stats
+  where orbis_socius_id=$1
+  order by created_at desc, id desc
+  limit $2;`
+	rows, err := r.driver.QueryRows(ctx, q, orbisSociusID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*models.OrbisSociusStat
+	for rows.Next() {
+		s, err := scanRowOrbisSociusStats(rows)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *Repository) GetOrbesSocii(ctx context.Context) ([]*models.OrbisSocius, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, owner_comptus_id, approver_admin_id, alive, robustness_status, last_pinged_at, region, name, description, url, api_key, version, protocol_version, compliant
+  from orbes_socii
+  where tombstoned=false
+  order by id;`
+	rows, err := r.driver.QueryRows(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orbesSocii []*models.OrbisSocius
+	for rows.Next() {
+		o, err := scanRowOrbisSocius(rows)
+		if err != nil {
+			return nil, err
+		}
+		orbesSocii = append(orbesSocii, o)
+	}
+
+	return orbesSocii, rows.Err()
+}
+
+func (r *Repository) UpdateOrbisSociusByID(ctx context.Context, id, ownerComptusID int64, approverAdminID *int64, region, name, desc string) error {
+	query := `update orbes_socii
+  set updated_at = $2, owner_comptus_id = $3, approver_admin_id = $4, region = $5, name = $6, description = $7
+  where id=$1`
+
+	return r.driver.ExecuteQuery(ctx, query, id, time.Now(), ownerComptusID, approverAdminID, region, name, desc)
+}
+
+func (r *Repository) SetOrbisSociusAPIKeyByID(ctx context.Context, id int64, apiKey string) error {
+	return r.driver.ExecuteQuery(ctx, "update orbes_socii set updated_at = $2, api_key = $3 where id=$1", id, time.Now(), apiKey)
+}
+
+func (r *Repository) SetOrbisSociusTombstonedByID(ctx context.Context, id int64) error {
+	now := time.Now()
+	return r.driver.ExecuteQuery(ctx, "update orbes_socii set updated_at = $2, deleted_at = $2, tombstoned = true where id=$1", id, now)
+}
+
+func (r *Repository) GetOrbisSociusLaunchInviteByAPIKey(ctx context.Context, apiKey string) (*models.OrbisSociusLaunchInvite, error) {
+	q := `
+  select
+    id, created_at, comptus_id, admin_id, orbis_socius_id, code, api_key, used, orbis_socius_launch_request_id, expired_at
+  from orbes_socii_launch_invites
+  where api_key=$1;`
+	row, err := r.driver.QueryRow(ctx, q, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowOrbisSociusLaunchInvite(row)
+}
+
+// SetOrbisSociusLaunchInviteUsedByID returns false if invite is already used.
+func (r *Repository) SetOrbisSociusLaunchInviteUsedByID(ctx context.Context, id, orbisSociusID int64) (bool, error) {
+	q := `
+  with updated as (
+    update orbes_socii_launch_invites
+    set used = true, orbis_socius_id = $2
+    where id=$1 and used=false
+    returning 1
+  )
+  select count(*) from updated;`
+
+	count, err := r.driver.CountRows(ctx, q, id, orbisSociusID)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *Repository) GetOrbisSociusByAPIKey(ctx context.Context, apiKey string) (*models.OrbisSocius, error) {
+	q := `
+  select
+    id, created_at, updated_at, deleted_at, tombstoned, owner_comptus_id, approver_admin_id, alive, robustness_status, last_pinged_at, region, name, description, url, api_key, version, protocol_version, compliant
+  from orbes_socii
+  where api_key=$1 and tombstoned=false;`
+	row, err := r.driver.QueryRow(ctx, q, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowOrbisSocius(row)
+}
+
+// SetOrbisSociusPingedByID marks orbis socius as alive. It returns whether
+// orbis socius was alive before.
+func (r *Repository) SetOrbisSociusPingedByID(ctx context.Context, id int64, pingedAt time.Time) (bool, error) {
+	q := `
+  with prev as (
+    select alive from orbes_socii where id=$1 for update
+  )
+  update orbes_socii
+  set alive = true, last_pinged_at = $2
+  where id=$1
+  returning (select alive from prev);`
+	row, err := r.driver.QueryRow(ctx, q, id, pingedAt)
+	if err != nil {
+		return false, err
+	}
+	var wasAlive bool
+	if err := row.Scan(&wasAlive); err != nil {
+		return false, err
+	}
+
+	return wasAlive, nil
+}
+
+func (r *Repository) CountAliveOrbesSocii(ctx context.Context) (int, error) {
+	q := `select count(*) from orbes_socii where tombstoned=false and alive;`
+	return r.driver.CountRows(ctx, q)
+}
+
+// SetStaleOrbesSociiDead marks orbes socii which have not pinged since given
+// time as not alive. It returns IDs of marked orbes socii.
+func (r *Repository) SetStaleOrbesSociiDead(ctx context.Context, pingedBefore time.Time) ([]int64, error) {
+	q := `
+  update orbes_socii
+  set alive = false
+  where alive and tombstoned=false and (last_pinged_at is null or last_pinged_at < $1)
+  returning id;`
+	rows, err := r.driver.QueryRows(ctx, q, pingedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
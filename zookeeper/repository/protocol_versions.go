@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/errorsutils"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/jackc/pgx/v4"
+)
+
+// orbisSociusProtocolService is key of minimal protocol version of orbes
+// socii.
+const orbisSociusProtocolService = "orbis-socius"
+
+// GetOrbesSociiMinProtocolVersion returns 0 if minimal protocol version is
+// not set.
+func (r *Repository) GetOrbesSociiMinProtocolVersion(ctx context.Context) (int, error) {
+	q := `select min_protocol_version from min_protocol_versions where service=$1;`
+	row, err := r.driver.QueryRow(ctx, q, orbisSociusProtocolService)
+	if err != nil {
+		return 0, err
+	}
+	var v int
+	if err := row.Scan(&v); err != nil {
+		if errorsutils.Equals(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return v, nil
+}
+
+func (r *Repository) SetOrbesSociiMinProtocolVersion(ctx context.Context, minProtocolVersion int, adminID int64) error {
+	query := `insert into min_protocol_versions
+  (service, updated_at, min_protocol_version, admin_id)
+  values ($1, $2, $3, $4)
+  on conflict (service) do update
+  set updated_at = excluded.updated_at, min_protocol_version = excluded.min_protocol_version, admin_id = excluded.admin_id;`
+
+	return r.driver.ExecuteQuery(ctx, query, orbisSociusProtocolService, time.Now(), minProtocolVersion, adminID)
+}
+
+// SetOrbisSociusProtocolByID stores versions reported by orbis socius and
+// checks its compliance. Protocol version 0 means orbis socius didn't report
+// it. It returns compliance before and after the update.
+func (r *Repository) SetOrbisSociusProtocolByID(ctx context.Context, id int64, version string, protocolVersion int) (bool, bool, error) {
+	q := `
+  with prev as (
+    select compliant from orbes_socii where id=$1 for update
+  )
+  update orbes_socii
+  set version = $2, protocol_version = nullif($3, 0),
+    compliant = $3 >= coalesce((select min_protocol_version from min_protocol_versions where service=$4), 0)
+  where id=$1
+  returning (select compliant from prev), compliant;`
+	row, err := r.driver.QueryRow(ctx, q, id, version, protocolVersion, orbisSociusProtocolService)
+	if err != nil {
+		return false, false, err
+	}
+	var wasCompliant, compliant bool
+	if err := row.Scan(&wasCompliant, &compliant); err != nil {
+		return false, false, err
+	}
+
+	return wasCompliant, compliant, nil
+}
+
+// UpdateOrbesSociiCompliance checks compliance of orbes socii against minimal
+// protocol version. It returns orbes socii which compliance is changed.
+func (r *Repository) UpdateOrbesSociiCompliance(ctx context.Context, minProtocolVersion int) ([]*models.OrbisSociusCompliance, error) {
+	q := `
+  update orbes_socii
+  set compliant = not compliant
+  where tombstoned=false and compliant <> (coalesce(protocol_version, 0) >= $1)
+  returning id, compliant, protocol_version;`
+	rows, err := r.driver.QueryRows(ctx, q, minProtocolVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changed []*models.OrbisSociusCompliance
+	for rows.Next() {
+		var c models.OrbisSociusCompliance
+		if err := rows.Scan(&c.OrbisSociusID, &c.Compliant, &c.ProtocolVersion); err != nil {
+			return nil, err
+		}
+		changed = append(changed, &c)
+	}
+
+	return changed, rows.Err()
+}
+
+func (r *Repository) CountNonCompliantOrbesSocii(ctx context.Context) (int, error) {
+	q := `select count(*) from orbes_socii where tombstoned=false and compliant=false;`
+	return r.driver.CountRows(ctx, q)
+}
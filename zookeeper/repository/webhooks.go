@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/toolkit/errorsutils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/random"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repository) UpsertOrbisSociusWebhook(ctx context.Context, orbisSociusID int64, url, secret string) (*models.OrbisSociusWebhook, error) {
+	createdAt := time.Now()
+	updatedAt := time.Now()
+
+	query := `insert into orbes_socii_webhooks
+  (orbis_socius_id, created_at, updated_at, url, secret)
+  values ($1, $2, $3, $4, $5)
+  on conflict (orbis_socius_id) do update
+  set updated_at=excluded.updated_at, url=excluded.url, secret=excluded.secret
+  returning orbis_socius_id, created_at, updated_at, url, secret;`
+	params := []interface{}{orbisSociusID, createdAt, updatedAt, url, secret}
+	row, err := r.driver.QueryRow(ctx, query, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowOrbisSociusWebhook(row)
+}
+
+func scanRowOrbisSociusWebhook(row pgx.Row) (*models.OrbisSociusWebhook, error) {
+	var w models.OrbisSociusWebhook
+	err := row.Scan(
+		&w.OrbisSociusID,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+		&w.URL,
+		&w.Secret,
+	)
+	if err == nil {
+		return &w, nil
+	}
+
+	if errorsutils.Equals(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func (r *Repository) GetOrbisSociusWebhook(ctx context.Context, orbisSociusID int64) (*models.OrbisSociusWebhook, error) {
+	q := `
+  select
+    orbis_socius_id, created_at, updated_at, url, secret
+  from orbes_socii_webhooks
+  where orbis_socius_id=$1;`
+	row, err := r.driver.QueryRow(ctx, q, orbisSociusID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowOrbisSociusWebhook(row)
+}
+
+func (r *Repository) DeleteOrbisSociusWebhook(ctx context.Context, orbisSociusID int64) error {
+	return r.driver.ExecuteQuery(ctx, "delete from orbes_socii_webhooks where orbis_socius_id=$1", orbisSociusID)
+}
+
+func (r *Repository) InsertWebhookDelivery(ctx context.Context, orbisSociusID int64, event models.WebhookEvent, data []byte) (*models.WebhookDelivery, error) {
+	id, err := random.GetSnowflakeID[models.WebhookDelivery](ctx, 0, r.GetWebhookDeliveryByID)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := time.Now()
+	status := models.ScheduledWebhookDelivery
+
+	query := `insert into webhook_deliveries
+  (id, created_at, orbis_socius_id, event, data, status, next_attempt_at)
+  values ($1, $2, $3, $4, $5, $6, $7);`
+	params := []interface{}{id, createdAt, orbisSociusID, event, string(data), status, createdAt}
+	if err := r.driver.ExecuteQuery(ctx, query, params...); err != nil {
+		return nil, err
+	}
+
+	d := &models.WebhookDelivery{
+		ID:            id,
+		CreatedAt:     createdAt,
+		OrbisSociusID: orbisSociusID,
+		Event:         event,
+		Data:          data,
+		Status:        status,
+	}
+	d.NextAttemptAt.Time, d.NextAttemptAt.Valid = createdAt, true
+
+	return d, nil
+}
+
+const webhookDeliveryColumns = `id, created_at, orbis_socius_id, event, data, status, attempts, next_attempt_at, last_attempt_at, last_status_code, last_error, delivered_at`
+
+func scanRowWebhookDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := row.Scan(
+		&d.ID,
+		&d.CreatedAt,
+		&d.OrbisSociusID,
+		&d.Event,
+		&d.Data,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastAttemptAt,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.DeliveredAt,
+	)
+	if err == nil {
+		return &d, nil
+	}
+
+	if errorsutils.Equals(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func (r *Repository) GetWebhookDeliveryByID(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	q := `select ` + webhookDeliveryColumns + ` from webhook_deliveries where id=$1;`
+	row, err := r.driver.QueryRow(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRowWebhookDelivery(row)
+}
+
+func (r *Repository) GetWebhookDeliveries(ctx context.Context, orbisSociusID int64, status *models.WebhookDeliveryStatus, limit int) ([]*models.WebhookDelivery, error) {
+	q := `select ` + webhookDeliveryColumns + `
+  from webhook_deliveries
+  where orbis_socius_id=$1 and ($2::integer is null or status=$2)
+  order by id desc
+  limit $3;`
+	rows, err := r.driver.QueryRows(ctx, q, orbisSociusID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		d, err := scanRowWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// ClaimDueWebhookDeliveries returns scheduled deliveries whose attempt is due
+// and postpones their next attempt to leaseUntil, so other replicas don't
+// send them concurrently.
+func (r *Repository) ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	q := `
+  update webhook_deliveries d
+  set next_attempt_at=$2
+  from (
+    select id from webhook_deliveries
+    where status=$3 and next_attempt_at <= $1
+    order by next_attempt_at
+    limit $4
+    for update skip locked
+  ) due
+  where d.id = due.id
+  returning d.id, d.created_at, d.orbis_socius_id, d.event, d.data, d.status, d.attempts,
+    d.next_attempt_at, d.last_attempt_at, d.last_status_code, d.last_error, d.delivered_at;`
+	rows, err := r.driver.QueryRows(ctx, q, now, leaseUntil, models.ScheduledWebhookDelivery, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		d, err := scanRowWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// UpdateWebhookDeliveryAttempt stores result of delivery attempt.
+func (r *Repository) UpdateWebhookDeliveryAttempt(ctx context.Context, d *models.WebhookDelivery) error {
+	query := `update webhook_deliveries
+  set status=$2, attempts=$3, next_attempt_at=$4, last_attempt_at=$5, last_status_code=$6, last_error=$7, delivered_at=$8
+  where id=$1;`
+	params := []interface{}{d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastAttemptAt, d.LastStatusCode, d.LastError, d.DeliveredAt}
+
+	return r.driver.ExecuteQuery(ctx, query, params...)
+}
+
+// ScheduleWebhookDelivery schedules delivery to be sent again from the first
+// attempt.
+func (r *Repository) ScheduleWebhookDelivery(ctx context.Context, id int64, at time.Time) error {
+	query := `update webhook_deliveries
+  set status=$2, attempts=0, next_attempt_at=$3
+  where id=$1;`
+
+	return r.driver.ExecuteQuery(ctx, query, id, models.ScheduledWebhookDelivery, at)
+}
+
+// DeleteFinishedWebhookDeliveries deletes delivered and failed deliveries
+// created before given time. It returns number of deleted deliveries.
+func (r *Repository) DeleteFinishedWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	q := `
+  with deleted as (
+    delete from webhook_deliveries
+    where created_at < $1 and status <> $2
+    returning 1
+  )
+  select count(*) from deleted;`
+	row, err := r.driver.QueryRow(ctx, q, before, models.ScheduledWebhookDelivery)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
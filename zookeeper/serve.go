@@ -0,0 +1,58 @@
+package zookeeper
+
+import (
+	"github.com/ecumenos/ecumenos/internal/fxappsettings"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	"github.com/ecumenos/ecumenos/internal/fxtracing"
+	"github.com/ecumenos/ecumenos/internal/zerodowntime"
+	"github.com/ecumenos/ecumenos/zookeeper/admin"
+	"github.com/ecumenos/ecumenos/zookeeper/app"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/ecumenos/ecumenos/zookeeper/service"
+	"go.uber.org/fx"
+)
+
+// ServerOptions builds fx app serving zookeeper. Servers are started by
+// ServeApp, ServeAdmin or ServeAll. Every process listens for changes of app
+// settings, other background jobs run only in the process which serves app,
+// so api and admin processes don't both dispatch webhooks and relay events.
+func ServerOptions(cfg *config.Config) fx.Option {
+	return fx.Options(
+		fx.Provide(func() Configuration {
+			return NewConfiguration(cfg)
+		}),
+		Module,
+		fxlogger.Module,
+		fxmetrics.Module,
+		fxtracing.Module,
+		fxhealth.Module,
+		fxappsettings.Module,
+		fx.Invoke(service.RunAppSettingsListener),
+		fx.StopTimeout(cfg.StopTimeout()),
+	)
+}
+
+// appJobs are background jobs of the process which serves app.
+var appJobs = fx.Options(
+	fx.Invoke(service.RunIdempotencyKeysCleanup),
+	fx.Invoke(service.RunWebhookDispatcher),
+	fx.Invoke(service.RunWebhookDeliveriesCleanup),
+	fx.Invoke(service.RunEventRelay),
+	fx.Invoke(service.RunOrbesSociiLivenessCheck),
+)
+
+var (
+	ServeApp = fx.Options(appJobs, fx.Invoke(func(lc fx.Lifecycle, s *app.Server) {
+		zerodowntime.Serve(lc, s)
+	}))
+	ServeAdmin = fx.Invoke(func(lc fx.Lifecycle, s *admin.Server) {
+		zerodowntime.Serve(lc, s)
+	})
+	// ServeAll runs both servers in one process, so they share repository
+	// and its connection pool.
+	ServeAll = fx.Options(appJobs, fx.Invoke(func(lc fx.Lifecycle, appServer *app.Server, adminServer *admin.Server) {
+		zerodowntime.Serve(lc, appServer, adminServer)
+	}))
+)
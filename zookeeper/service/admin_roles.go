@@ -3,9 +3,33 @@ package service
 import (
 	"context"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
 )
 
 func (s *Service) CreateAdminRole(ctx context.Context, name string, permissions models.AdminRolePermissions, creatorID int64) (*models.AdminRole, error) {
+	if !models.AdminRoleNameRegex.MatchString(name) {
+		return nil, apierrors.Validation(apierrors.Field("name", apierrors.FieldInvalid, "role name is invalid"))
+	}
+	existing, err := s.repo.GetAdminRoleByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apierrors.New(apierrors.AdminRoleAlreadyExists, "admin role with the name already exists")
+	}
+
 	return s.repo.InsertAdminRole(ctx, name, permissions, creatorID)
 }
+
+func (s *Service) GetAdminRoleByName(ctx context.Context, name string) (*models.AdminRole, error) {
+	return s.repo.GetAdminRoleByName(ctx, name)
+}
+
+func (s *Service) UpdateAdminRolePermissions(ctx context.Context, roleID int64, permissions models.AdminRolePermissions) error {
+	return s.repo.SetAdminRolePermissionsByID(ctx, roleID, permissions)
+}
+
+func (s *Service) ListAdminRoles(ctx context.Context) ([]*models.AdminRole, error) {
+	return s.repo.GetAdminRoles(ctx)
+}
@@ -2,8 +2,8 @@ package service
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
 )
 
@@ -13,11 +13,11 @@ func (s *Service) CreateAdminSession(ctx context.Context, adminID int64) (*model
 		return nil, err
 	}
 	if a == nil {
-		return nil, fmt.Errorf("failed create session for not existing admin (id = %v)", adminID)
+		return nil, apierrors.Newf(apierrors.AdminNotFound, "failed create session for not existing admin (id = %v)", adminID)
 	}
 
-	tokExp, refTokExp := s.auth.GetExpiredAt()
-	token, refreshToken, err := s.auth.CreateTokens(ctx, adminID, tokExp, refTokExp)
+	tokExp, refTokExp := s.adminAuth.GetExpiredAt()
+	token, refreshToken, err := s.adminAuth.CreateTokens(ctx, adminID, tokExp, refTokExp)
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +35,8 @@ func (s *Service) RefreshAdminSession(ctx context.Context, refreshToken string)
 		return nil, err
 	}
 
-	tokExp, refTokExp := s.auth.GetExpiredAt()
-	token, refreshToken, err := s.auth.CreateTokens(ctx, adminID, tokExp, refTokExp)
+	tokExp, refTokExp := s.adminAuth.GetExpiredAt()
+	token, refreshToken, err := s.adminAuth.CreateTokens(ctx, adminID, tokExp, refTokExp)
 	if err != nil {
 		return nil, err
 	}
@@ -2,14 +2,25 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	"github.com/ecumenos/ecumenos/models/common"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
 )
 
 func (s *Service) CreateAdmin(ctx context.Context, email, password string) (*models.Admin, error) {
+	if !common.EmailRegex.MatchString(email) {
+		return nil, apierrors.Validation(apierrors.Field("email", apierrors.FieldInvalid, "email is invalid"))
+	}
+	existing, err := s.repo.GetAdminByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apierrors.New(apierrors.AdminAlreadyExists, "admin with the email already exists")
+	}
+
 	passwordHash, err := hashPassword(password)
 	if err != nil {
 		return nil, err
@@ -20,17 +31,18 @@ func (s *Service) CreateAdmin(ctx context.Context, email, password string) (*mod
 
 func (s *Service) ValidateAdminCredentials(ctx context.Context, email, password string) error {
 	if !common.EmailRegex.MatchString(email) {
-		return fmt.Errorf("invalid email. it doesn't fulfill validation (email = %v)", email)
+		return apierrors.Validation(apierrors.Field("email", apierrors.FieldInvalid,
+			fmt.Sprintf("invalid email. it doesn't fulfill validation (email = %v)", email)))
 	}
 	a, err := s.repo.GetAdminByEmail(ctx, email)
 	if err != nil {
 		return err
 	}
 	if a == nil {
-		return errors.New("email is invalid")
+		return ErrInvalidCredentials
 	}
 	if ok := checkPasswordHash(password, a.PasswordHash); !ok {
-		return errors.New("password is invalid")
+		return ErrInvalidCredentials
 	}
 
 	return nil
@@ -44,3 +56,82 @@ func (s *Service) AssignRoleForAdmin(ctx context.Context, adminID, roleID int64,
 func (s *Service) GetAdminByEmail(ctx context.Context, email string) (*models.Admin, error) {
 	return s.repo.GetAdminByEmail(ctx, email)
 }
+
+func (s *Service) UpdateAdminPassword(ctx context.Context, adminID int64, password string) error {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SetAdminPasswordHashByID(ctx, adminID, passwordHash)
+}
+
+func (s *Service) IsRoleAssignedToAdmin(ctx context.Context, adminID, roleID int64) (bool, error) {
+	rel, err := s.repo.GetAdminsAdminRolesRelation(ctx, adminID, roleID)
+	if err != nil {
+		return false, err
+	}
+
+	return rel != nil, nil
+}
+
+func (s *Service) GetAdminByID(ctx context.Context, id int64) (*models.Admin, error) {
+	return s.repo.GetAdminByID(ctx, id)
+}
+
+func (s *Service) ListAdmins(ctx context.Context) ([]*models.Admin, error) {
+	return s.repo.GetAdmins(ctx)
+}
+
+func (s *Service) GetAdminRoleNames(ctx context.Context, adminID int64) ([]string, error) {
+	return s.repo.GetAdminRoleNamesByAdminID(ctx, adminID)
+}
+
+// AssignAdminRoleByName assigns role to admin. Assigning of already assigned
+// role does nothing.
+func (s *Service) AssignAdminRoleByName(ctx context.Context, adminID int64, roleName string, granterID *int64) (*models.Admin, error) {
+	a, role, err := s.getAdminAndRole(ctx, adminID, roleName)
+	if err != nil {
+		return nil, err
+	}
+	assigned, err := s.IsRoleAssignedToAdmin(ctx, adminID, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	if assigned {
+		return a, nil
+	}
+	if err := s.AssignRoleForAdmin(ctx, adminID, role.ID, granterID); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *Service) RevokeAdminRoleByName(ctx context.Context, adminID int64, roleName string) error {
+	_, role, err := s.getAdminAndRole(ctx, adminID, roleName)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteAdminsAdminRolesRelation(ctx, adminID, role.ID)
+}
+
+func (s *Service) getAdminAndRole(ctx context.Context, adminID int64, roleName string) (*models.Admin, *models.AdminRole, error) {
+	a, err := s.repo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a == nil {
+		return nil, nil, apierrors.Newf(apierrors.AdminNotFound, "admin is not found (id = %v)", adminID)
+	}
+	role, err := s.repo.GetAdminRoleByName(ctx, roleName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if role == nil {
+		return nil, nil, apierrors.Newf(apierrors.AdminRoleNotFound, "admin role is not found (name = %v)", roleName)
+	}
+
+	return a, role, nil
+}
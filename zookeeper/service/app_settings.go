@@ -0,0 +1,486 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/ecumenos/ecumenos/zookeeper/repository"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// appSettingsListenRetry is delay before listener of app settings
+// notifications reconnects.
+const appSettingsListenRetry = 5 * time.Second
+
+var (
+	regionCodeRegex  = regexp.MustCompile(`^[a-z][a-z0-9_]{1,63}$`)
+	alpha3CodeRegex  = regexp.MustCompile(`^[a-z]{3}$`)
+	errSettingsFiles = apierrors.Newf(apierrors.AppSettingsReadOnly, "app settings are managed by files, set app_settings_source to %q to manage them", config.PostgresAppSettingsSource)
+)
+
+func (s *Service) GetAppSettingsStatus() *appsettings.Status {
+	return s.settings.GetStatus()
+}
+
+// ReloadAppSettings reloads app settings of this process and notifies other
+// zookeeper processes sharing the database, so they reload theirs too.
+// Returned status describes this process only.
+func (s *Service) ReloadAppSettings(ctx context.Context) (*appsettings.Status, error) {
+	status, err := s.settings.Reload(ctx)
+	if err != nil {
+		return status, err
+	}
+	s.notifyAppSettingsChanged(ctx)
+
+	return status, nil
+}
+
+// notifyAppSettingsChanged asks other processes to reload app settings. They
+// also reload them periodically, so failed notification is not fatal.
+func (s *Service) notifyAppSettingsChanged(ctx context.Context) {
+	if err := s.repo.NotifyAppSettingsChanged(ctx); err != nil {
+		fxlogger.FromContext(ctx, s.logger).Warn("can not notify other processes about changed app settings", zap.Error(err))
+	}
+}
+
+// RunAppSettingsListener reloads app settings when other process notifies
+// that they are changed. Notifications sent while listener is disconnected
+// are lost, so settings are reloaded after reconnect.
+func RunAppSettingsListener(lc fx.Lifecycle, s *Service, logger *zap.Logger) {
+	ctx, cancel := context.WithCancel(jobContext(logger, "app_settings_listener"))
+	stopped := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(stopped)
+				s.listenAppSettings(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-stopped:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func (s *Service) listenAppSettings(ctx context.Context) {
+	logger := fxlogger.FromContext(ctx, s.logger)
+	reload := func() {
+		status, err := s.settings.Reload(ctx)
+		if err != nil {
+			logger.Error("app settings reload is rejected, previous version is kept",
+				zap.String("reason", "notification"), zap.Int64("version", status.Version), zap.Error(err))
+			return
+		}
+		logger.Debug("app settings are reloaded", zap.String("reason", "notification"), zap.Int64("version", status.Version))
+	}
+	for {
+		err := s.repo.ListenAppSettingsChanged(ctx, reload)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warn("app settings listener is disconnected", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(appSettingsListenRetry):
+			reload()
+		}
+	}
+}
+
+func (s *Service) checkAppSettingsEditable() error {
+	if s.appSettingsSource != config.PostgresAppSettingsSource {
+		return errSettingsFiles
+	}
+
+	return nil
+}
+
+// writeAppSettings calls write and validates resulting app settings in the
+// same transaction. Write which makes app settings invalid is rolled back, so
+// invalid settings are never loaded by running or starting processes.
+func (s *Service) writeAppSettings(ctx context.Context, write func(ctx context.Context) error) error {
+	return s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		settings, err := repository.NewAppSettingsSource(s.repo, nil).Load(ctx)
+		if err != nil {
+			return err
+		}
+		if err := settings.Validate(); err != nil {
+			return apierrors.Newf(apierrors.AppSettingsInvalid, "change makes app settings invalid, it is not saved (reason = %v)", err)
+		}
+
+		return nil
+	})
+}
+
+// applyAppSettings makes changes in database visible to this process right
+// away and notifies other processes to reload app settings.
+func (s *Service) applyAppSettings(ctx context.Context) error {
+	if _, err := s.settings.Reload(ctx); err != nil {
+		return fmt.Errorf("changes are saved, but app settings are not reloaded: %w", err)
+	}
+	s.notifyAppSettingsChanged(ctx)
+
+	return nil
+}
+
+func (s *Service) ListRegions(ctx context.Context) ([]*models.Region, error) {
+	return s.repo.GetRegions(ctx)
+}
+
+func (s *Service) GetRegion(ctx context.Context, code string) (*models.Region, error) {
+	return s.repo.GetRegionByCode(ctx, code)
+}
+
+func validateRegionMetadata(displayName, defaultTimezone string) []apierrors.FieldError {
+	var fields []apierrors.FieldError
+	if displayName == "" {
+		fields = append(fields, apierrors.Field("display_name", apierrors.FieldRequired, "display name is required"))
+	}
+	if _, err := time.LoadLocation(defaultTimezone); err != nil || defaultTimezone == "" {
+		fields = append(fields, apierrors.Field("default_timezone", apierrors.FieldInvalid,
+			fmt.Sprintf("invalid default timezone, IANA time zone name is expected (timezone = %v)", defaultTimezone)))
+	}
+
+	return fields
+}
+
+func (s *Service) CreateRegion(ctx context.Context, code, displayName, defaultTimezone, dataResidencyNotes string, enabled bool) (*models.Region, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, err
+	}
+	fields := validateRegionMetadata(displayName, defaultTimezone)
+	if !regionCodeRegex.MatchString(code) {
+		fields = append(fields, apierrors.Field("code", apierrors.FieldInvalid, fmt.Sprintf("invalid region code (code = %v)", code)))
+	}
+	if len(fields) > 0 {
+		return nil, apierrors.Validation(fields...)
+	}
+	existing, err := s.repo.GetRegionByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apierrors.Newf(apierrors.RegionAlreadyExists, "region already exists (code = %v)", code)
+	}
+
+	var region *models.Region
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		region, err = s.repo.InsertRegion(ctx, code, displayName, defaultTimezone, dataResidencyNotes, enabled)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return region, s.applyAppSettings(ctx)
+}
+
+func (s *Service) UpdateRegion(ctx context.Context, code, displayName, defaultTimezone, dataResidencyNotes string) (*models.Region, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, err
+	}
+	if fields := validateRegionMetadata(displayName, defaultTimezone); len(fields) > 0 {
+		return nil, apierrors.Validation(fields...)
+	}
+	region, err := s.repo.GetRegionByCode(ctx, code)
+	if err != nil || region == nil {
+		return nil, err
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.UpdateRegion(ctx, code, displayName, defaultTimezone, dataResidencyNotes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyAppSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRegionByCode(ctx, code)
+}
+
+// SetRegionEnabled enables or disables region. On disabling it returns orbes
+// socii which still live in the region, they keep working but new ones can
+// not be launched there.
+func (s *Service) SetRegionEnabled(ctx context.Context, code string, enabled bool) (*models.Region, []*models.OrbisSocius, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, nil, err
+	}
+	region, err := s.repo.GetRegionByCode(ctx, code)
+	if err != nil || region == nil {
+		return nil, nil, err
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.SetRegionEnabled(ctx, code, enabled)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.applyAppSettings(ctx); err != nil {
+		return nil, nil, err
+	}
+	var orbesSocii []*models.OrbisSocius
+	if !enabled {
+		if orbesSocii, err = s.repo.GetOrbesSociiByRegion(ctx, code); err != nil {
+			return nil, nil, err
+		}
+	}
+	region, err = s.repo.GetRegionByCode(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return region, orbesSocii, nil
+}
+
+func (s *Service) DeleteRegion(ctx context.Context, code string) (bool, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return false, err
+	}
+	region, err := s.repo.GetRegionByCode(ctx, code)
+	if err != nil || region == nil {
+		return false, err
+	}
+	countries, err := s.repo.CountCountriesByRegion(ctx, code)
+	if err != nil {
+		return false, err
+	}
+	if countries > 0 {
+		return false, apierrors.Newf(apierrors.RegionInUse, "region is used by countries (code = %v, countries = %v)", code, countries)
+	}
+	orbesSocii, err := s.repo.GetOrbesSociiByRegion(ctx, code)
+	if err != nil {
+		return false, err
+	}
+	if len(orbesSocii) > 0 {
+		return false, apierrors.Newf(apierrors.RegionInUse, "region has orbes socii (code = %v, orbes socii = %v)", code, len(orbesSocii))
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.DeleteRegion(ctx, code)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.applyAppSettings(ctx)
+}
+
+func (s *Service) ListCountries(ctx context.Context) ([]*models.Country, error) {
+	return s.repo.GetCountries(ctx)
+}
+
+func (s *Service) validateCountryRegions(ctx context.Context, regions []string) error {
+	if len(regions) == 0 {
+		return apierrors.Validation(apierrors.Field("regions", apierrors.FieldRequired, "country must have at least one region"))
+	}
+	for _, code := range regions {
+		region, err := s.repo.GetRegionByCode(ctx, code)
+		if err != nil {
+			return err
+		}
+		if region == nil {
+			return apierrors.Validation(apierrors.Field("regions", apierrors.FieldInvalid, fmt.Sprintf("not found region (code = %v)", code)))
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) CreateCountry(ctx context.Context, code string, enabled bool, regions []string) (*models.Country, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, err
+	}
+	if !alpha3CodeRegex.MatchString(code) {
+		return nil, apierrors.Validation(apierrors.Field("code", apierrors.FieldInvalid,
+			fmt.Sprintf("invalid country code, ISO 3166-1 alpha-3 code is expected (code = %v)", code)))
+	}
+	if err := s.validateCountryRegions(ctx, regions); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetCountryByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apierrors.Newf(apierrors.CountryAlreadyExists, "country already exists (code = %v)", code)
+	}
+
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		_, err := s.repo.InsertCountry(ctx, code, enabled, regions)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyAppSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCountryByCode(ctx, code)
+}
+
+func (s *Service) UpdateCountryRegions(ctx context.Context, code string, regions []string) (*models.Country, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, err
+	}
+	if err := s.validateCountryRegions(ctx, regions); err != nil {
+		return nil, err
+	}
+	country, err := s.repo.GetCountryByCode(ctx, code)
+	if err != nil || country == nil {
+		return nil, err
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.SetCountryRegions(ctx, code, regions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyAppSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCountryByCode(ctx, code)
+}
+
+func (s *Service) SetCountryEnabled(ctx context.Context, code string, enabled bool) (*models.Country, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, err
+	}
+	country, err := s.repo.GetCountryByCode(ctx, code)
+	if err != nil || country == nil {
+		return nil, err
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.SetCountryEnabled(ctx, code, enabled)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyAppSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCountryByCode(ctx, code)
+}
+
+func (s *Service) DeleteCountry(ctx context.Context, code string) (bool, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return false, err
+	}
+	country, err := s.repo.GetCountryByCode(ctx, code)
+	if err != nil || country == nil {
+		return false, err
+	}
+	compti, err := s.repo.CountComptiByPatria(ctx, code)
+	if err != nil {
+		return false, err
+	}
+	if compti > 0 {
+		return false, apierrors.Newf(apierrors.CountryInUse, "country is patria of compti, disable it instead (code = %v, compti = %v)", code, compti)
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.DeleteCountry(ctx, code)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.applyAppSettings(ctx)
+}
+
+func (s *Service) ListLanguages(ctx context.Context) ([]*models.Language, error) {
+	return s.repo.GetLanguages(ctx)
+}
+
+func (s *Service) CreateLanguage(ctx context.Context, code string, enabled bool) (*models.Language, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, err
+	}
+	if !alpha3CodeRegex.MatchString(code) {
+		return nil, apierrors.Validation(apierrors.Field("code", apierrors.FieldInvalid,
+			fmt.Sprintf("invalid language code, ISO 639-3 code is expected (code = %v)", code)))
+	}
+	existing, err := s.repo.GetLanguageByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apierrors.Newf(apierrors.LanguageAlreadyExists, "language already exists (code = %v)", code)
+	}
+
+	var language *models.Language
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		language, err = s.repo.InsertLanguage(ctx, code, enabled)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return language, s.applyAppSettings(ctx)
+}
+
+func (s *Service) SetLanguageEnabled(ctx context.Context, code string, enabled bool) (*models.Language, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return nil, err
+	}
+	language, err := s.repo.GetLanguageByCode(ctx, code)
+	if err != nil || language == nil {
+		return nil, err
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.SetLanguageEnabled(ctx, code, enabled)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyAppSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetLanguageByCode(ctx, code)
+}
+
+func (s *Service) DeleteLanguage(ctx context.Context, code string) (bool, error) {
+	if err := s.checkAppSettingsEditable(); err != nil {
+		return false, err
+	}
+	language, err := s.repo.GetLanguageByCode(ctx, code)
+	if err != nil || language == nil {
+		return false, err
+	}
+	compti, err := s.repo.CountComptiByLingua(ctx, code)
+	if err != nil {
+		return false, err
+	}
+	if compti > 0 {
+		return false, apierrors.Newf(apierrors.LanguageInUse, "language is lingua of compti, disable it instead (code = %v, compti = %v)", code, compti)
+	}
+	err = s.writeAppSettings(ctx, func(ctx context.Context) error {
+		return s.repo.DeleteLanguage(ctx, code)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, s.applyAppSettings(ctx)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxappsettings"
+	"github.com/ecumenos/ecumenos/internal/fxappsettings/appsettings"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/ecumenos/ecumenos/zookeeper/repository"
+	"github.com/jackc/pgx/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeSettings struct {
+	fxappsettings.AppSettings
+	reloads int
+	err     error
+}
+
+func (s *fakeSettings) Reload(context.Context) (*appsettings.Status, error) {
+	s.reloads++
+	return &appsettings.Status{Version: int64(s.reloads)}, s.err
+}
+
+func newTestService(t *testing.T, driver *pgxtest.Driver, settings fxappsettings.AppSettings, source string) *Service {
+	m, err := newMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	return &Service{
+		repo:              repository.NewWithDriver(driver, zap.NewNop()),
+		settings:          settings,
+		metrics:           m,
+		logger:            zap.NewNop(),
+		appSettingsSource: source,
+	}
+}
+
+func notifications(driver *pgxtest.Driver) int {
+	var n int
+	for _, q := range driver.Queries() {
+		if strings.Contains(q.SQL, "pg_notify") && len(q.Args) > 0 && q.Args[0] == repository.AppSettingsChannel {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestReloadAppSettingsNotifiesOtherProcesses(t *testing.T) {
+	driver := &pgxtest.Driver{}
+	settings := &fakeSettings{}
+	s := newTestService(t, driver, settings, config.FileAppSettingsSource)
+
+	_, err := s.ReloadAppSettings(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, notifications(driver))
+
+	settings.err = errors.New("no countries in settings")
+	_, err = s.ReloadAppSettings(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 1, notifications(driver), "rejected settings must not be propagated")
+}
+
+func TestAppSettingsAreReadOnlyForFileSource(t *testing.T) {
+	driver := &pgxtest.Driver{}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	_, err := s.CreateLanguage(context.Background(), "eng", true)
+	require.ErrorIs(t, err, apierrors.New(apierrors.AppSettingsReadOnly, ""))
+	assert.Empty(t, driver.Queries())
+}
+
+func TestCreateCountryValidation(t *testing.T) {
+	driver := &pgxtest.Driver{}
+	settings := &fakeSettings{}
+	s := newTestService(t, driver, settings, config.PostgresAppSettingsSource)
+
+	_, err := s.CreateCountry(context.Background(), "ukraine", true, []string{"eu_east"})
+	require.ErrorIs(t, err, apierrors.New(apierrors.ValidationFailed, ""))
+
+	_, err = s.CreateCountry(context.Background(), "ukr", true, nil)
+	require.ErrorIs(t, err, apierrors.New(apierrors.ValidationFailed, ""))
+
+	// region is not found.
+	_, err = s.CreateCountry(context.Background(), "ukr", true, []string{"eu_east"})
+	require.ErrorIs(t, err, apierrors.New(apierrors.ValidationFailed, ""))
+
+	for _, q := range driver.Queries() {
+		assert.NotContains(t, q.SQL, "insert into", "invalid country is stored")
+	}
+	assert.Zero(t, settings.reloads)
+}
+
+// appSettingsDriver serves app settings rows which are left after deletion.
+func appSettingsDriver(countries, languages []string) *pgxtest.Driver {
+	return &pgxtest.Driver{
+		OnQueryRow: func(_ context.Context, query string, args ...interface{}) (pgx.Row, error) {
+			switch {
+			case strings.Contains(query, "from countries"):
+				return pgxtest.Row(args[0], time.Now(), time.Now(), true, []string{"eu_east"}), nil
+			case strings.Contains(query, "from languages"):
+				return pgxtest.Row(args[0], time.Now(), time.Now(), true), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+		OnQueryRows: func(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+			var rows [][]interface{}
+			switch {
+			case strings.Contains(query, "from countries"):
+				for _, code := range countries {
+					rows = append(rows, []interface{}{code, time.Now(), time.Now(), true, []string{"eu_east"}})
+				}
+			case strings.Contains(query, "from languages"):
+				for _, code := range languages {
+					rows = append(rows, []interface{}{code, time.Now(), time.Now(), true})
+				}
+			}
+			return pgxtest.Rows(rows...), nil
+		},
+	}
+}
+
+func TestDeleteAppSettingsKeepsSettingsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		delete    func(s *Service) (bool, error)
+		countries []string
+		languages []string
+		valid     bool
+	}{
+		{
+			name:      "country",
+			delete:    func(s *Service) (bool, error) { return s.DeleteCountry(context.Background(), "ukr") },
+			countries: []string{"pol"},
+			languages: []string{"ukr"},
+			valid:     true,
+		},
+		{
+			name:      "last country",
+			delete:    func(s *Service) (bool, error) { return s.DeleteCountry(context.Background(), "ukr") },
+			languages: []string{"ukr"},
+		},
+		{
+			name:      "language",
+			delete:    func(s *Service) (bool, error) { return s.DeleteLanguage(context.Background(), "ukr") },
+			countries: []string{"ukr"},
+			languages: []string{"eng"},
+			valid:     true,
+		},
+		{
+			name:      "last language",
+			delete:    func(s *Service) (bool, error) { return s.DeleteLanguage(context.Background(), "ukr") },
+			countries: []string{"ukr"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			driver := appSettingsDriver(tc.countries, tc.languages)
+			settings := &fakeSettings{}
+			s := newTestService(t, driver, settings, config.PostgresAppSettingsSource)
+
+			deleted, err := tc.delete(s)
+			deletes := queriesOf(driver.Queries(), "delete from")
+			require.Len(t, deletes, 1)
+			if tc.valid {
+				require.NoError(t, err)
+				assert.True(t, deleted)
+				assert.Len(t, queriesOf(driver.Committed(), "delete from"), 1)
+				assert.Equal(t, 1, settings.reloads)
+				return
+			}
+			require.ErrorIs(t, err, apierrors.New(apierrors.AppSettingsInvalid, ""))
+			assert.False(t, deleted)
+			assert.Empty(t, queriesOf(driver.Committed(), "delete from"), "deletion which makes app settings invalid is saved")
+			assert.Zero(t, settings.reloads)
+			assert.Zero(t, notifications(driver))
+		})
+	}
+}
@@ -2,20 +2,20 @@ package service
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	"github.com/ecumenos/ecumenos/internal/toolkit/primitives"
 )
 
 func (s *Service) AuthorizeAdmin(ctx context.Context, token string) (int64, int64, error) {
-	t, err := s.auth.DecodeToken(token)
+	t, err := s.adminAuth.DecodeToken(token)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, apierrors.Wrap(apierrors.AuthInvalidToken, err, ErrInvalidToken.Message)
 	}
 	subject := t.Subject()
 	adminID, err := primitives.StringToInt64(subject)
 	if err != nil {
-		return 0, 0, fmt.Errorf("token is corrupted (extracted subject = %v)", subject)
+		return 0, 0, apierrors.Newf(apierrors.AuthInvalidToken, "token is corrupted (extracted subject = %v)", subject)
 	}
 
 	session, err := s.repo.GetAdminSessionByAdminIDAndToken(ctx, adminID, token)
@@ -23,21 +23,21 @@ func (s *Service) AuthorizeAdmin(ctx context.Context, token string) (int64, int6
 		return 0, 0, err
 	}
 	if session == nil {
-		return 0, 0, fmt.Errorf("admin session is not found (admin id = %v)", adminID)
+		return 0, 0, apierrors.Newf(apierrors.AuthSessionNotFound, "admin session is not found (admin id = %v)", adminID)
 	}
 
 	return adminID, session.ID, nil
 }
 
 func (s *Service) AuthorizeAdminWithRefreshToken(ctx context.Context, refreshToken string) (int64, int64, error) {
-	t, err := s.auth.DecodeToken(refreshToken)
+	t, err := s.adminAuth.DecodeToken(refreshToken)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, apierrors.Wrap(apierrors.AuthInvalidToken, err, ErrInvalidToken.Message)
 	}
 	subject := t.Subject()
 	adminID, err := primitives.StringToInt64(subject)
 	if err != nil {
-		return 0, 0, fmt.Errorf("token is corrupted (extracted subject = %v)", subject)
+		return 0, 0, apierrors.Newf(apierrors.AuthInvalidToken, "token is corrupted (extracted subject = %v)", subject)
 	}
 
 	session, err := s.repo.GetAdminSessionByAdminIDAndRefreshToken(ctx, adminID, refreshToken)
@@ -45,7 +45,7 @@ func (s *Service) AuthorizeAdminWithRefreshToken(ctx context.Context, refreshTok
 		return 0, 0, err
 	}
 	if session == nil {
-		return 0, 0, fmt.Errorf("admin session is not found (admin id = %v)", adminID)
+		return 0, 0, apierrors.Newf(apierrors.AuthSessionNotFound, "admin session is not found (admin id = %v)", adminID)
 	}
 
 	return adminID, session.ID, nil
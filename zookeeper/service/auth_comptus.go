@@ -2,20 +2,20 @@ package service
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	"github.com/ecumenos/ecumenos/internal/toolkit/primitives"
 )
 
 func (s *Service) AuthorizeComptus(ctx context.Context, token string) (int64, int64, error) {
-	t, err := s.auth.DecodeToken(token)
+	t, err := s.comptusAuth.DecodeToken(token)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, apierrors.Wrap(apierrors.AuthInvalidToken, err, ErrInvalidToken.Message)
 	}
 	subject := t.Subject()
 	comptusID, err := primitives.StringToInt64(subject)
 	if err != nil {
-		return 0, 0, fmt.Errorf("token is corrupted (extracted subject = %v)", subject)
+		return 0, 0, apierrors.Newf(apierrors.AuthInvalidToken, "token is corrupted (extracted subject = %v)", subject)
 	}
 
 	session, err := s.repo.GetComptusSessionByComptusIDAndToken(ctx, comptusID, token)
@@ -23,21 +23,21 @@ func (s *Service) AuthorizeComptus(ctx context.Context, token string) (int64, in
 		return 0, 0, err
 	}
 	if session == nil {
-		return 0, 0, fmt.Errorf("comptus session is not found (comptus id = %v)", comptusID)
+		return 0, 0, apierrors.Newf(apierrors.AuthSessionNotFound, "comptus session is not found (comptus id = %v)", comptusID)
 	}
 
 	return comptusID, session.ID, nil
 }
 
 func (s *Service) AuthorizeComptusWithRefreshToken(ctx context.Context, refreshToken string) (int64, int64, error) {
-	t, err := s.auth.DecodeToken(refreshToken)
+	t, err := s.comptusAuth.DecodeToken(refreshToken)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, apierrors.Wrap(apierrors.AuthInvalidToken, err, ErrInvalidToken.Message)
 	}
 	subject := t.Subject()
 	comptusID, err := primitives.StringToInt64(subject)
 	if err != nil {
-		return 0, 0, fmt.Errorf("token is corrupted (extracted subject = %v)", subject)
+		return 0, 0, apierrors.Newf(apierrors.AuthInvalidToken, "token is corrupted (extracted subject = %v)", subject)
 	}
 
 	session, err := s.repo.GetComptusSessionByComptusIDAndRefreshToken(ctx, comptusID, refreshToken)
@@ -45,7 +45,7 @@ func (s *Service) AuthorizeComptusWithRefreshToken(ctx context.Context, refreshT
 		return 0, 0, err
 	}
 	if session == nil {
-		return 0, 0, fmt.Errorf("comptus session is not found (comptus id = %v)", comptusID)
+		return 0, 0, apierrors.Newf(apierrors.AuthSessionNotFound, "comptus session is not found (comptus id = %v)", comptusID)
 	}
 
 	return comptusID, session.ID, nil
@@ -2,42 +2,71 @@ package service
 
 import (
 	"context"
-	"errors"
+	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	"github.com/ecumenos/ecumenos/models/common"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
 )
 
 func (s *Service) CreateComptus(ctx context.Context, email, password, patria, lingua string) (*models.Comptus, error) {
-	passwordHash, err := hashPassword(password)
+	var fields []apierrors.FieldError
+	if !common.EmailRegex.MatchString(email) {
+		fields = append(fields, apierrors.Field("email", apierrors.FieldInvalid, "email is invalid"))
+	}
+	if password == "" {
+		fields = append(fields, apierrors.Field("password", apierrors.FieldRequired, "password is required"))
+	}
+	if err := s.settings.ValidateCountryCode(patria); err != nil {
+		fields = append(fields, apierrors.Field("country", apierrors.FieldInvalid, err.Error()))
+	}
+	if err := s.settings.ValidateLanguageCode(lingua); err != nil {
+		fields = append(fields, apierrors.Field("language", apierrors.FieldInvalid, err.Error()))
+	}
+	if len(fields) > 0 {
+		return nil, apierrors.Validation(fields...)
+	}
+	existing, err := s.repo.GetComptusByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
+	if existing != nil {
+		return nil, apierrors.New(apierrors.ComptusAlreadyExists, "comptus with the email already exists")
+	}
 
-	if err := s.settings.ValidateCountryCode(patria); err != nil {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
 		return nil, err
 	}
-	if err := s.settings.ValidateLanguageCode(lingua); err != nil {
+
+	c, err := s.repo.InsertComptus(ctx, email, passwordHash, patria, lingua)
+	if err != nil {
 		return nil, err
 	}
+	s.metrics.signUps.Inc()
 
-	return s.repo.InsertComptus(ctx, email, passwordHash, patria, lingua)
+	return c, nil
 }
 
 func (s *Service) ValidateComptusCredentials(ctx context.Context, email, password string) error {
 	if !common.EmailRegex.MatchString(email) {
-		return fmt.Errorf("invalid email. it doesn't fulfill validation (email = %v)", email)
+		return apierrors.Validation(apierrors.Field("email", apierrors.FieldInvalid,
+			fmt.Sprintf("invalid email. it doesn't fulfill validation (email = %v)", email)))
 	}
 	a, err := s.repo.GetComptusByEmail(ctx, email)
 	if err != nil {
 		return err
 	}
 	if a == nil {
-		return errors.New("email is invalid")
+		return ErrInvalidCredentials
 	}
 	if ok := checkPasswordHash(password, a.PasswordHash); !ok {
-		return errors.New("password is invalid")
+		return ErrInvalidCredentials
+	}
+	if a.SuspendedAt.Valid {
+		return ErrComptusSuspended
 	}
 
 	return nil
@@ -50,3 +79,85 @@ func (s *Service) GetComptusByEmail(ctx context.Context, email string) (*models.
 func (s *Service) GetComptusByID(ctx context.Context, id int64) (*models.Comptus, error) {
 	return s.repo.GetComptusByID(ctx, id)
 }
+
+func (s *Service) UpdateComptusPassword(ctx context.Context, comptusID int64, password string) error {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SetComptusPasswordHashByID(ctx, comptusID, passwordHash)
+}
+
+func (s *Service) ListCompti(ctx context.Context, email string, limit, offset int) ([]*models.Comptus, error) {
+	return s.repo.GetCompti(ctx, email, limit, offset)
+}
+
+// DeleteComptus tombstones comptus and all its sessions. It returns false if
+// comptus doesn't exist.
+func (s *Service) DeleteComptus(ctx context.Context, id int64) (bool, error) {
+	c, err := s.repo.GetComptusByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if c == nil {
+		return false, nil
+	}
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetComptusSessionsTombstonedByComptusID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.repo.SetComptusTombstonedByID(ctx, id); err != nil {
+			return err
+		}
+
+		return s.publishEvent(ctx, models.ComptusDeletedEvent, id, struct{}{})
+	})
+
+	return true, err
+}
+
+// SuspendComptus suspends comptus and signs out all its sessions, so it can
+// not sign in until suspension is lifted. It returns nil if comptus doesn't
+// exist.
+func (s *Service) SuspendComptus(ctx context.Context, id int64) (*models.Comptus, error) {
+	return s.setComptusSuspension(ctx, id, true)
+}
+
+// UnsuspendComptus lifts suspension of comptus. It returns nil if comptus
+// doesn't exist.
+func (s *Service) UnsuspendComptus(ctx context.Context, id int64) (*models.Comptus, error) {
+	return s.setComptusSuspension(ctx, id, false)
+}
+
+func (s *Service) setComptusSuspension(ctx context.Context, id int64, suspend bool) (*models.Comptus, error) {
+	var c *models.Comptus
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if c, err = s.repo.GetComptusByID(ctx, id); err != nil || c == nil {
+			return err
+		}
+		if c.SuspendedAt.Valid == suspend {
+			return nil
+		}
+		event := models.ComptusUnsuspendedEvent
+		c.SuspendedAt = sql.NullTime{}
+		if suspend {
+			event = models.ComptusSuspendedEvent
+			c.SuspendedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			if err := s.repo.SetComptusSessionsTombstonedByComptusID(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.SetComptusSuspendedAtByID(ctx, id, c.SuspendedAt); err != nil {
+			return err
+		}
+
+		return s.publishEvent(ctx, event, id, struct{}{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func suspensionDriver(suspendedAt interface{}) *pgxtest.Driver {
+	return &pgxtest.Driver{
+		OnQueryRow: func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+			switch {
+			case strings.Contains(query, "from compti"):
+				return comptusRow(4, "user@example.com", suspendedAt), nil
+			case strings.Contains(query, "insert into outbox_events"):
+				return pgxtest.Row(int64(1)), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+	}
+}
+
+func TestSuspendComptus(t *testing.T) {
+	driver := suspensionDriver(nil)
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	c, err := s.SuspendComptus(context.Background(), 4)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.True(t, c.SuspendedAt.Valid)
+
+	queries := driver.Committed()
+	sessions := queriesOf(queries, "update comptus_sessions set tombstoned = true")
+	require.Len(t, sessions, 1)
+	assert.Equal(t, int64(4), sessions[0].Args[0])
+	events := queriesOf(queries, "insert into outbox_events")
+	require.Len(t, events, 1)
+	assert.Equal(t, models.ComptusSuspendedEvent, events[0].Args[1])
+	assert.Equal(t, sessions[0].Tx, events[0].Tx, "event is not published in transaction of suspension")
+}
+
+func TestSuspendComptusIsNoOpIfAlreadySuspended(t *testing.T) {
+	driver := suspensionDriver(time.Now())
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	c, err := s.SuspendComptus(context.Background(), 4)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Empty(t, queriesOf(driver.Queries(), "insert into outbox_events"))
+}
+
+func TestUnsuspendComptus(t *testing.T) {
+	driver := suspensionDriver(time.Now())
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	c, err := s.UnsuspendComptus(context.Background(), 4)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.False(t, c.SuspendedAt.Valid)
+
+	queries := driver.Committed()
+	assert.Empty(t, queriesOf(queries, "update comptus_sessions"))
+	events := queriesOf(queries, "insert into outbox_events")
+	require.Len(t, events, 1)
+	assert.Equal(t, models.ComptusUnsuspendedEvent, events[0].Args[1])
+}
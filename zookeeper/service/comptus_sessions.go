@@ -2,8 +2,8 @@ package service
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
 )
 
@@ -13,11 +13,11 @@ func (s *Service) CreateComptusSession(ctx context.Context, comptusID int64) (*m
 		return nil, err
 	}
 	if c == nil {
-		return nil, fmt.Errorf("failed create session for not existing comptus (id = %v)", comptusID)
+		return nil, apierrors.Newf(apierrors.ComptusNotFound, "failed create session for not existing comptus (id = %v)", comptusID)
 	}
 
-	tokExp, refTokExp := s.auth.GetExpiredAt()
-	token, refreshToken, err := s.auth.CreateTokens(ctx, comptusID, tokExp, refTokExp)
+	tokExp, refTokExp := s.comptusAuth.GetExpiredAt()
+	token, refreshToken, err := s.comptusAuth.CreateTokens(ctx, comptusID, tokExp, refTokExp)
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +35,8 @@ func (s *Service) RefreshComptusSession(ctx context.Context, refreshToken string
 		return nil, err
 	}
 
-	tokExp, refTokExp := s.auth.GetExpiredAt()
-	token, refreshToken, err := s.auth.CreateTokens(ctx, comptusID, tokExp, refTokExp)
+	tokExp, refTokExp := s.comptusAuth.GetExpiredAt()
+	token, refreshToken, err := s.comptusAuth.CreateTokens(ctx, comptusID, tokExp, refTokExp)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"go.uber.org/zap"
+)
+
+// Mailer sends emails to compti.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+func newMailer(cfg *config.Config, logger *zap.Logger) Mailer {
+	if cfg.SMTPAddr == "" {
+		return &logMailer{logger: logger}
+	}
+	m := &smtpMailer{addr: cfg.SMTPAddr, from: cfg.EmailFrom}
+	if cfg.SMTPUsername != "" {
+		host, _, _ := net.SplitHostPort(cfg.SMTPAddr)
+		m.auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, host)
+	}
+
+	return m
+}
+
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func (m *smtpMailer) Send(_ context.Context, to, subject, body string) error {
+	msg := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg))
+}
+
+// logMailer only logs emails, it is used if SMTP is not configured.
+type logMailer struct {
+	logger *zap.Logger
+}
+
+func (m *logMailer) Send(ctx context.Context, to, subject, _ string) error {
+	fxlogger.FromContext(ctx, m.logger).Info("email is not sent, SMTP is not configured",
+		zap.String("to", to), zap.String("subject", subject))
+	return nil
+}
+
+// handleEmailEvent notifies comptus about changes of its launch requests and
+// account. Email may be sent again if offset of consumer is not committed.
+func (s *Service) handleEmailEvent(ctx context.Context, e *models.Event) error {
+	var (
+		comptusID     int64
+		subject, body string
+	)
+	switch e.Type {
+	case models.OrbisSociusLaunchRequestApprovedEvent:
+		var data launchRequestApprovedEventData
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return err
+		}
+		comptusID = data.ComptusID
+		subject = "Orbis socius launch request is approved"
+		body = fmt.Sprintf("Your orbis socius launch request %v is approved. Launch invite expires at %v.",
+			e.AggregateID, data.InviteExpires.Format(time.RFC1123))
+	case models.OrbisSociusLaunchRequestRejectedEvent:
+		var data launchRequestRejectedEventData
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return err
+		}
+		comptusID = data.ComptusID
+		subject = "Orbis socius launch request is rejected"
+		body = fmt.Sprintf("Your orbis socius launch request %v is rejected.", e.AggregateID)
+	case models.ComptusSuspendedEvent:
+		comptusID = e.AggregateID
+		subject = "Account is suspended"
+		body = "Your account is suspended, you are signed out of all sessions."
+	case models.ComptusUnsuspendedEvent:
+		comptusID = e.AggregateID
+		subject = "Account suspension is lifted"
+		body = "Suspension of your account is lifted, you can sign in again."
+	default:
+		return nil
+	}
+	c, err := s.repo.GetComptusByID(ctx, comptusID)
+	if err != nil || c == nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, c.Email, subject, body)
+}
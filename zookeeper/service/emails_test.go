@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sentEmail struct {
+	to, subject, body string
+}
+
+type fakeMailer struct {
+	sent []sentEmail
+}
+
+func (m *fakeMailer) Send(_ context.Context, to, subject, body string) error {
+	m.sent = append(m.sent, sentEmail{to: to, subject: subject, body: body})
+	return nil
+}
+
+// comptusRow returns row of compti table, suspendedAt is nil or time.
+func comptusRow(id int64, email string, suspendedAt interface{}) pgx.Row {
+	now := time.Now()
+	return pgxtest.Row(id, now, now, nil, false, email, "hash", "ukr", "ukr", suspendedAt)
+}
+
+func TestHandleEmailEvent(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		event     *models.Event
+		comptusID int64
+		subject   string
+	}{
+		{
+			name:      "launch request approved",
+			event:     &models.Event{ID: 1, Type: models.OrbisSociusLaunchRequestApprovedEvent, AggregateID: 10, Data: []byte(`{"comptus_id":3,"invite_expires":"2026-01-02T00:00:00Z"}`)},
+			comptusID: 3,
+			subject:   "Orbis socius launch request is approved",
+		},
+		{
+			name:      "launch request rejected",
+			event:     &models.Event{ID: 2, Type: models.OrbisSociusLaunchRequestRejectedEvent, AggregateID: 10, Data: []byte(`{"comptus_id":3}`)},
+			comptusID: 3,
+			subject:   "Orbis socius launch request is rejected",
+		},
+		{
+			name:      "comptus suspended",
+			event:     &models.Event{ID: 3, Type: models.ComptusSuspendedEvent, AggregateID: 4, Data: []byte(`{}`)},
+			comptusID: 4,
+			subject:   "Account is suspended",
+		},
+		{
+			name:      "comptus unsuspended",
+			event:     &models.Event{ID: 4, Type: models.ComptusUnsuspendedEvent, AggregateID: 4, Data: []byte(`{}`)},
+			comptusID: 4,
+			subject:   "Account suspension is lifted",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var comptusID int64
+			driver := &pgxtest.Driver{
+				OnQueryRow: func(_ context.Context, query string, args ...interface{}) (pgx.Row, error) {
+					if strings.Contains(query, "from compti") {
+						comptusID = args[0].(int64)
+						return comptusRow(comptusID, "user@example.com", nil), nil
+					}
+					return pgxtest.NoRows(), nil
+				},
+			}
+			s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+			mailer := &fakeMailer{}
+			s.mailer = mailer
+
+			require.NoError(t, s.handleEmailEvent(context.Background(), tc.event))
+			assert.Equal(t, tc.comptusID, comptusID)
+			require.Len(t, mailer.sent, 1)
+			assert.Equal(t, "user@example.com", mailer.sent[0].to)
+			assert.Equal(t, tc.subject, mailer.sent[0].subject)
+		})
+	}
+}
+
+func TestHandleEmailEventSkipsOtherEventsAndDeletedCompti(t *testing.T) {
+	driver := &pgxtest.Driver{}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+	mailer := &fakeMailer{}
+	s.mailer = mailer
+
+	require.NoError(t, s.handleEmailEvent(context.Background(), &models.Event{ID: 1, Type: models.ComptusDeletedEvent, AggregateID: 4}))
+	assert.Empty(t, driver.Queries())
+	require.NoError(t, s.handleEmailEvent(context.Background(), &models.Event{ID: 2, Type: models.ComptusSuspendedEvent, AggregateID: 4}))
+	assert.Empty(t, mailer.sent)
+}
@@ -0,0 +1,12 @@
+package service
+
+import "github.com/ecumenos/ecumenos/internal/apierrors"
+
+// Errors returned by service. They are matched by code, so errors created
+// with apierrors.New and the same code are equal to them for errors.Is.
+var (
+	ErrInvalidCredentials = apierrors.New(apierrors.AuthInvalidCredentials, "invalid email or password")
+	ErrInvalidToken       = apierrors.New(apierrors.AuthInvalidToken, "token is invalid")
+	ErrSessionNotFound    = apierrors.New(apierrors.AuthSessionNotFound, "session is not found")
+	ErrComptusSuspended   = apierrors.New(apierrors.ComptusSuspended, "comptus is suspended")
+)
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// EventHandler handles event of outbox. It is called in transaction which
+// moves offset of consumer, so changes made through repository are committed
+// once. Other side effects (e.g. HTTP calls) may be repeated, if handler or
+// commit fails event is passed again.
+type EventHandler func(ctx context.Context, e *models.Event) error
+
+// consumerStart is position in outbox from which new consumer starts.
+type consumerStart int
+
+const (
+	// startAtOldestEvent makes consumer handle all events kept in outbox.
+	startAtOldestEvent consumerStart = iota
+	// startAtNewestEvent makes consumer skip events published before its
+	// first relay.
+	startAtNewestEvent
+)
+
+type eventConsumer struct {
+	name   string
+	start  consumerStart
+	handle EventHandler
+}
+
+// subscribe registers consumer of events. Name identifies offset of consumer
+// in outbox, so it must not be changed. Start is used only on the first relay
+// of consumer.
+func (s *Service) subscribe(name string, start consumerStart, handle EventHandler) {
+	s.consumers = append(s.consumers, eventConsumer{name: name, start: start, handle: handle})
+}
+
+type launchRequestApprovedEventData struct {
+	ComptusID     int64     `json:"comptus_id"`
+	AdminID       int64     `json:"admin_id"`
+	InviteID      int64     `json:"invite_id"`
+	InviteExpires time.Time `json:"invite_expires"`
+}
+
+type launchRequestRejectedEventData struct {
+	ComptusID int64 `json:"comptus_id"`
+}
+
+// publishEvent writes event to outbox. It must be called with context of
+// repository transaction of the change.
+func (s *Service) publishEvent(ctx context.Context, typ models.EventType, aggregateID int64, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.InsertEvent(ctx, typ, aggregateID, payload)
+
+	return err
+}
+
+// RelayEvents passes new events of outbox to consumers. Consumer which fails
+// to handle event stops at it and retries it on the next relay. Event which
+// fails max attempts times is moved to dead letters and skipped.
+func (s *Service) RelayEvents(ctx context.Context) {
+	logger := fxlogger.FromContext(ctx, s.logger)
+	for _, c := range s.consumers {
+		for {
+			relayed, failed, err := s.relayNextEvent(ctx, c)
+			if err != nil {
+				s.metrics.events.WithLabelValues(c.name, "failed").Inc()
+				logger.Error("can not relay event", zap.String("consumer", c.name), zap.Error(err))
+				if failed == nil {
+					break
+				}
+				deadLettered, err := s.recordEventFailure(ctx, c, failed, err)
+				if err != nil {
+					logger.Error("can not record failure of event", zap.String("consumer", c.name), zap.Error(err))
+				}
+				if !deadLettered {
+					break
+				}
+				s.metrics.events.WithLabelValues(c.name, "dead_lettered").Inc()
+				logger.Error("event is moved to dead letters", zap.String("consumer", c.name), zap.Int64("event_id", failed.ID))
+				continue
+			}
+			if !relayed {
+				break
+			}
+			s.metrics.events.WithLabelValues(c.name, "handled").Inc()
+		}
+	}
+}
+
+// relayNextEvent returns false if consumer has no new events or its offset is
+// locked by another replica. Event is returned with error if handler fails.
+func (s *Service) relayNextEvent(ctx context.Context, c eventConsumer) (bool, *models.Event, error) {
+	var (
+		relayed bool
+		failed  *models.Event
+	)
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		offset, err := s.repo.LockConsumerOffset(ctx, c.name, c.start == startAtNewestEvent)
+		if err != nil || offset == nil {
+			return err
+		}
+		events, err := s.repo.GetEventsAfter(ctx, offset.LastEventID, 1)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		e := events[0]
+		if err := c.handle(ctx, e); err != nil {
+			failed = e
+			return fmt.Errorf("can not handle event (event_id = %v, type = %v): %w", e.ID, e.Type, err)
+		}
+		relayed = true
+
+		return s.repo.SetConsumerOffset(ctx, c.name, e.ID)
+	})
+	if err != nil {
+		return false, failed, err
+	}
+
+	return relayed, nil, nil
+}
+
+// maxEventErrorLength limits error of failed event stored in database.
+const maxEventErrorLength = 1024
+
+// recordEventFailure counts failed attempt of consumer to handle event. It
+// returns true if event reached max attempts and was moved to dead letters.
+func (s *Service) recordEventFailure(ctx context.Context, c eventConsumer, e *models.Event, handleErr error) (bool, error) {
+	lastError := handleErr.Error()
+	if len(lastError) > maxEventErrorLength {
+		lastError = lastError[:maxEventErrorLength]
+	}
+	var deadLettered bool
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		offset, err := s.repo.LockConsumerOffset(ctx, c.name, c.start == startAtNewestEvent)
+		if err != nil || offset == nil || offset.LastEventID >= e.ID {
+			return err
+		}
+		attempts := offset.Attempts + 1
+		if attempts < s.eventsMaxAttempts {
+			return s.repo.SetConsumerFailure(ctx, c.name, attempts, lastError)
+		}
+		if err := s.repo.InsertDeadLetter(ctx, c.name, e, attempts, lastError); err != nil {
+			return err
+		}
+		deadLettered = true
+
+		return s.repo.SetConsumerOffset(ctx, c.name, e.ID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return deadLettered, nil
+}
+
+// handleAuditEvent keeps all events in audit log, which outlives outbox.
+func (s *Service) handleAuditEvent(ctx context.Context, e *models.Event) error {
+	return s.repo.InsertAuditRecord(ctx, e)
+}
+
+// RunEventRelay relays events while app is running. Relay is woken up by
+// postgres notification from any replica and also polls outbox every
+// interval, so events are relayed if notification is lost. Relay is disabled
+// if interval is 0.
+func RunEventRelay(lc fx.Lifecycle, cfg *config.Config, s *Service, jobs *fxhealth.Jobs, logger *zap.Logger) {
+	if cfg.EventsPollInterval == 0 {
+		return
+	}
+	wake := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+	jobCtx := jobContext(logger, "event_relay")
+	job := jobs.Register("event_relay", cfg.EventsPollInterval)
+	listenCtx, stopListen := context.WithCancel(jobCtx)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go s.listenEvents(listenCtx, cfg.EventsPollInterval, notify)
+			go func() {
+				defer close(stopped)
+				ticker := time.NewTicker(cfg.EventsPollInterval)
+				defer ticker.Stop()
+				for {
+					s.RelayEvents(jobCtx)
+					job.Tick(cfg.EventsPollInterval)
+					select {
+					case <-done:
+						return
+					case <-ticker.C:
+					case <-wake:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			stopListen()
+			close(done)
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	if cfg.EventsRetention > 0 {
+		runPeriodically(lc, jobs, "events_cleanup", time.Hour, func() {
+			s.deleteConsumedEvents(jobContext(logger, "events_cleanup"), cfg.EventsRetention)
+		})
+	}
+}
+
+func (s *Service) listenEvents(ctx context.Context, retry time.Duration, notify func()) {
+	logger := fxlogger.FromContext(ctx, s.logger)
+	for {
+		err := s.repo.ListenEvents(ctx, func(string) { notify() })
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warn("events listener is disconnected", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+			// events inserted while listener was disconnected.
+			notify()
+		}
+	}
+}
+
+func (s *Service) deleteConsumedEvents(ctx context.Context, retention time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	logger := fxlogger.FromContext(ctx, s.logger)
+	count, err := s.repo.DeleteConsumedEvents(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logger.Error("can not delete consumed events", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		logger.Info("consumed events are deleted", zap.Int64("count", count))
+	}
+}
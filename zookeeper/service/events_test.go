@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// outboxDriver serves events of outbox after offset of consumer.
+func outboxDriver(events ...*models.Event) *pgxtest.Driver {
+	var (
+		offset   int64
+		attempts int
+	)
+	d := &pgxtest.Driver{}
+	d.OnExec = func(_ context.Context, query string, args ...interface{}) error {
+		if strings.Contains(query, "update outbox_consumer_offsets") {
+			switch v := args[2].(type) {
+			case int64:
+				offset, attempts = v, 0
+			case int:
+				attempts = v
+			}
+		}
+		return nil
+	}
+	d.OnQueryRow = func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+		if strings.Contains(query, "from outbox_consumer_offsets") {
+			return pgxtest.Row(offset, attempts), nil
+		}
+		return pgxtest.NoRows(), nil
+	}
+	d.OnQueryRows = func(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+		if !strings.Contains(query, "from outbox_events") {
+			return pgxtest.Rows(), nil
+		}
+		for _, e := range events {
+			if e.ID > offset {
+				return pgxtest.Rows([]interface{}{e.ID, e.CreatedAt, e.Type, e.AggregateID, e.Data}), nil
+			}
+		}
+		return pgxtest.Rows(), nil
+	}
+
+	return d
+}
+
+func TestRelayEventsLogsWithContextLogger(t *testing.T) {
+	driver := outboxDriver(&models.Event{ID: 1, CreatedAt: time.Now(), Type: models.ComptusDeletedEvent, AggregateID: 1, Data: []byte("{}")})
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+	s.consumers = []eventConsumer{{name: "test", handle: func(context.Context, *models.Event) error {
+		return errors.New("consumer is not available")
+	}}}
+	core, logs := observer.New(zap.InfoLevel)
+
+	s.RelayEvents(fxlogger.WithContext(context.Background(), zap.New(core).With(zap.String("job", "event_relay"))))
+	entries := logs.FilterMessage("can not relay event").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "event_relay", entries[0].ContextMap()["job"])
+}
+
+// queriesOf returns statements which contain given text.
+func queriesOf(queries []pgxtest.Query, text string) []pgxtest.Query {
+	var out []pgxtest.Query
+	for _, q := range queries {
+		if strings.Contains(q.SQL, text) {
+			out = append(out, q)
+		}
+	}
+
+	return out
+}
+
+func TestPublishEventLocksOutbox(t *testing.T) {
+	driver := &pgxtest.Driver{
+		OnQueryRow: func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+			if strings.Contains(query, "insert into outbox_events") {
+				return pgxtest.Row(int64(7)), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+	}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	err := s.repo.InTx(context.Background(), func(ctx context.Context) error {
+		return s.publishEvent(ctx, models.ComptusDeletedEvent, 1, struct{}{})
+	})
+	require.NoError(t, err)
+
+	queries := driver.Committed()
+	require.Len(t, queries, 3)
+	assert.Contains(t, queries[0].SQL, "pg_advisory_xact_lock")
+	assert.Contains(t, queries[1].SQL, "insert into outbox_events")
+	assert.Contains(t, queries[2].SQL, "pg_notify")
+	assert.Equal(t, "7", queries[2].Args[1])
+	for _, q := range queries {
+		assert.Equal(t, queries[0].Tx, q.Tx, "event is not published in transaction of change")
+	}
+}
+
+func TestRelayEventsAdvancesOffset(t *testing.T) {
+	driver := outboxDriver(
+		&models.Event{ID: 1, CreatedAt: time.Now(), Type: models.ComptusDeletedEvent, AggregateID: 1, Data: []byte("{}")},
+		&models.Event{ID: 2, CreatedAt: time.Now(), Type: models.ComptusDeletedEvent, AggregateID: 2, Data: []byte("{}")},
+	)
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+	var handled []int64
+	s.subscribe("test", startAtOldestEvent, func(_ context.Context, e *models.Event) error {
+		handled = append(handled, e.ID)
+		return nil
+	})
+
+	s.RelayEvents(context.Background())
+	assert.Equal(t, []int64{1, 2}, handled)
+	offsets := queriesOf(driver.Committed(), "update outbox_consumer_offsets")
+	require.Len(t, offsets, 2)
+	assert.Equal(t, int64(2), offsets[1].Args[2])
+
+	// handled events are not passed again.
+	s.RelayEvents(context.Background())
+	assert.Equal(t, []int64{1, 2}, handled)
+}
+
+func TestRelayEventsMovesEventToDeadLetters(t *testing.T) {
+	driver := outboxDriver(
+		&models.Event{ID: 1, CreatedAt: time.Now(), Type: models.ComptusDeletedEvent, AggregateID: 1, Data: []byte("{}")},
+		&models.Event{ID: 2, CreatedAt: time.Now(), Type: models.ComptusDeletedEvent, AggregateID: 2, Data: []byte("{}")},
+	)
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+	s.eventsMaxAttempts = 3
+	var handled []int64
+	s.subscribe("test", startAtOldestEvent, func(_ context.Context, e *models.Event) error {
+		if e.ID == 1 {
+			return errors.New("consumer is not available")
+		}
+		handled = append(handled, e.ID)
+		return nil
+	})
+
+	for i := 1; i < s.eventsMaxAttempts; i++ {
+		s.RelayEvents(context.Background())
+		assert.Empty(t, handled)
+		assert.Empty(t, queriesOf(driver.Committed(), "insert into outbox_dead_letters"))
+	}
+	failures := queriesOf(driver.Committed(), "set updated_at=$2, attempts=$3")
+	require.Len(t, failures, 2)
+	assert.Equal(t, 2, failures[1].Args[2])
+	assert.Equal(t, "can not handle event (event_id = 1, type = comptus.deleted): consumer is not available", failures[1].Args[3])
+
+	s.RelayEvents(context.Background())
+	deadLetters := queriesOf(driver.Committed(), "insert into outbox_dead_letters")
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "test", deadLetters[0].Args[1])
+	assert.Equal(t, int64(1), deadLetters[0].Args[2])
+	assert.Equal(t, 3, deadLetters[0].Args[6])
+	assert.Equal(t, []int64{2}, handled, "consumer is blocked by dead letter")
+}
+
+func TestRelayEventsStartPosition(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		start      consumerStart
+		fromNewest bool
+	}{
+		{name: "oldest", start: startAtOldestEvent, fromNewest: false},
+		{name: "newest", start: startAtNewestEvent, fromNewest: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			driver := outboxDriver()
+			s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+			s.subscribe("test", tc.start, func(context.Context, *models.Event) error { return nil })
+
+			s.RelayEvents(context.Background())
+			inserts := queriesOf(driver.Queries(), "insert into outbox_consumer_offsets")
+			require.Len(t, inserts, 1)
+			assert.Equal(t, tc.fromNewest, inserts[0].Args[2])
+		})
+	}
+}
+
+func TestDeleteConsumedEvents(t *testing.T) {
+	driver := &pgxtest.Driver{
+		OnQueryRow: func(context.Context, string, ...interface{}) (pgx.Row, error) {
+			return pgxtest.Row(int64(3)), nil
+		},
+	}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	s.deleteConsumedEvents(context.Background(), 24*time.Hour)
+	deletes := queriesOf(driver.Queries(), "delete from outbox_events")
+	require.Len(t, deletes, 1)
+	assert.Contains(t, deletes[0].SQL, "min(last_event_id)", "events not handled by all consumers are deleted")
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), deletes[0].Args[0].(time.Time), time.Minute)
+}
+
+func TestHandleAuditEvent(t *testing.T) {
+	driver := &pgxtest.Driver{}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+	e := &models.Event{ID: 5, CreatedAt: time.Now(), Type: models.ComptusSuspendedEvent, AggregateID: 1, Data: []byte("{}")}
+
+	require.NoError(t, s.handleAuditEvent(context.Background(), e))
+	records := queriesOf(driver.Queries(), "insert into audit_log")
+	require.Len(t, records, 1)
+	assert.Contains(t, records[0].SQL, "on conflict (event_id) do nothing")
+	assert.Equal(t, int64(5), records[0].Args[1])
+	assert.Equal(t, models.ComptusSuspendedEvent, records[0].Args[3])
+}
@@ -1,10 +1,15 @@
 package service
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/ecumenos/ecumenos/internal/fxappsettings"
 	"github.com/ecumenos/ecumenos/zookeeper/config"
 	"github.com/ecumenos/ecumenos/zookeeper/repository"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 var Module = fx.Options(
@@ -12,15 +17,48 @@ var Module = fx.Options(
 )
 
 type Service struct {
-	repo     *repository.Repository
-	auth     *Authorization
-	settings fxappsettings.AppSettings
+	repo        *repository.Repository
+	comptusAuth *Authorization
+	adminAuth   *Authorization
+	settings    fxappsettings.AppSettings
+	metrics     *metrics
+	consumers   []eventConsumer
+	logger      *zap.Logger
+
+	appSettingsSource   string
+	webhookClient       *http.Client
+	webhookAllowPrivate bool
+	webhookMaxAttempts  int
+	eventsMaxAttempts   int
+	heartbeatInterval   time.Duration
+	mailer              Mailer
 }
 
-func New(repo *repository.Repository, rm fxappsettings.AppSettings, cfg *config.Config) *Service {
-	return &Service{
-		repo:     repo,
-		auth:     &Authorization{JWTSigningKey: cfg.JWTSecret},
-		settings: rm,
+func New(repo *repository.Repository, rm fxappsettings.AppSettings, cfg *config.Config, reg prometheus.Registerer, logger *zap.Logger) (*Service, error) {
+	m, err := newMetrics(reg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		repo:        repo,
+		comptusAuth: &Authorization{JWTSigningKey: cfg.AppJWTSecret},
+		adminAuth:   &Authorization{JWTSigningKey: cfg.AdminJWTSecret},
+		settings:    rm,
+		metrics:     m,
+		logger:      logger,
+
+		appSettingsSource:   cfg.AppSettingsSource,
+		webhookClient:       newWebhookClient(cfg.WebhookTimeout, cfg.WebhookAllowPrivate),
+		webhookAllowPrivate: cfg.WebhookAllowPrivate,
+		webhookMaxAttempts:  cfg.WebhookMaxAttempts,
+		eventsMaxAttempts:   cfg.EventsMaxAttempts,
+		heartbeatInterval:   cfg.HeartbeatInterval,
+		mailer:              newMailer(cfg, logger),
 	}
+	s.subscribe("webhooks", startAtNewestEvent, s.handleWebhookEvent)
+	s.subscribe("audit", startAtOldestEvent, s.handleAuditEvent)
+	s.subscribe("email", startAtNewestEvent, s.handleEmailEvent)
+
+	return s, nil
 }
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/toolkit/webhooks"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type launchInviteRedeemedEventData struct {
+	OrbisSociusID int64 `json:"orbis_socius_id"`
+	ComptusID     int64 `json:"comptus_id"`
+}
+
+type healthChangedEventData struct {
+	Alive bool `json:"alive"`
+}
+
+func (s *Service) HeartbeatInterval() time.Duration {
+	return s.heartbeatInterval
+}
+
+// RegisterOrbisSocius authenticates orbis socius instance by its API key. The
+// first registration redeems launch invite of the key and creates orbis
+// socius from launch request of the invite.
+func (s *Service) RegisterOrbisSocius(ctx context.Context, apiKey string) (*models.OrbisSocius, error) {
+	os, err := s.repo.GetOrbisSociusByAPIKey(ctx, apiKey)
+	if err != nil || os != nil {
+		return os, err
+	}
+
+	invite, err := s.repo.GetOrbisSociusLaunchInviteByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if invite == nil || invite.Used || !invite.OrbisSociusLaunchRequestID.Valid {
+		return nil, apierrors.New(apierrors.OrbisSociusInvalidAPIKey, "API key is invalid")
+	}
+	if invite.ExpiredAt.Before(time.Now()) {
+		return nil, apierrors.Newf(apierrors.OrbisSociusInviteExpired, "launch invite is expired (id = %v)", invite.ID)
+	}
+	req, err := s.getOrbisSociusLaunchRequest(ctx, invite.OrbisSociusLaunchRequestID.Int64)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		os, err = s.repo.InsertOrbisSocius(ctx, invite.ComptusID, &invite.AdminID, req.Region, req.OrbisSociusName, req.OrbisSociusDescription, req.OrbisSociusURL, apiKey)
+		if err != nil {
+			return err
+		}
+		redeemed, err := s.repo.SetOrbisSociusLaunchInviteUsedByID(ctx, invite.ID, os.ID)
+		if err != nil {
+			return err
+		}
+		if !redeemed {
+			// invite was redeemed by concurrent registration.
+			return apierrors.New(apierrors.OrbisSociusInvalidAPIKey, "API key is invalid")
+		}
+
+		return s.publishEvent(ctx, models.OrbisSociusLaunchInviteRedeemedEvent, invite.ID, launchInviteRedeemedEventData{
+			OrbisSociusID: os.ID,
+			ComptusID:     invite.ComptusID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return os, nil
+}
+
+// AuthorizeOrbisSocius verifies signature of request body made by API key of
+// orbis socius. Heartbeat signed before the last accepted one is rejected.
+func (s *Service) AuthorizeOrbisSocius(ctx context.Context, id int64, signature string, body []byte) (*models.OrbisSocius, error) {
+	os, err := s.repo.GetOrbisSociusByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if os == nil {
+		s.metrics.heartbeats.WithLabelValues("rejected").Inc()
+		return nil, apierrors.Newf(apierrors.OrbisSociusInvalidSignature, "signature is invalid (orbis_socius_id = %v)", id)
+	}
+	if err := webhooks.Verify([]byte(os.APIKey), signature, body, webhooks.DefaultTolerance, time.Now()); err != nil {
+		s.metrics.heartbeats.WithLabelValues("rejected").Inc()
+		return nil, apierrors.Wrap(apierrors.OrbisSociusInvalidSignature, err, "signature is invalid")
+	}
+	// signature which is not newer than the last accepted heartbeat is
+	// replayed within tolerance.
+	signedAt, err := webhooks.SignedAt(signature)
+	if err != nil {
+		return nil, err
+	}
+	if os.LastPingedAt.Valid && !signedAt.After(os.LastPingedAt.Time) {
+		s.metrics.heartbeats.WithLabelValues("rejected").Inc()
+		return nil, apierrors.Newf(apierrors.OrbisSociusInvalidSignature, "signature is replayed (orbis_socius_id = %v, signed_at = %v, last_pinged_at = %v)",
+			id, signedAt.Unix(), os.LastPingedAt.Time.Unix())
+	}
+
+	return os, nil
+}
+
+// RecordOrbisSociusHeartbeat marks orbis socius as alive and stores reported
+// state in its stats. Operator is notified if orbis socius was not alive or
+// if its compliance with minimal protocol version is changed.
+func (s *Service) RecordOrbisSociusHeartbeat(ctx context.Context, id int64, heartbeat *models.OrbisSociusHeartbeat) error {
+	if heartbeat.Version == "" {
+		return apierrors.Validation(apierrors.Field("version", apierrors.FieldRequired, "version is required"))
+	}
+	if heartbeat.ProtocolVersion < 0 {
+		return apierrors.Validation(apierrors.Field("protocol_version", apierrors.FieldInvalid, "protocol version can not be negative"))
+	}
+	if heartbeat.Members < 0 {
+		return apierrors.Validation(apierrors.Field("members", apierrors.FieldInvalid, "members can not be negative"))
+	}
+	if heartbeat.Capacity < 0 {
+		return apierrors.Validation(apierrors.Field("capacity", apierrors.FieldInvalid, "capacity can not be negative"))
+	}
+
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		wasAlive, err := s.repo.SetOrbisSociusPingedByID(ctx, id, time.Now())
+		if err != nil {
+			return err
+		}
+		if _, err := s.repo.InsertOrbisSociusStats(ctx, &id, true, heartbeat); err != nil {
+			return err
+		}
+		if err := s.updateOrbisSociusProtocol(ctx, id, heartbeat); err != nil {
+			return err
+		}
+		if wasAlive {
+			return nil
+		}
+
+		return s.publishEvent(ctx, models.OrbisSociusHealthChangedEvent, id, healthChangedEventData{Alive: true})
+	})
+	if err != nil {
+		return err
+	}
+	s.metrics.heartbeats.WithLabelValues("accepted").Inc()
+
+	return nil
+}
+
+// MarkStaleOrbesSociiDead marks orbes socii which have not sent heartbeat
+// during timeout as not alive and notifies their operators. It returns number
+// of marked orbes socii.
+func (s *Service) MarkStaleOrbesSociiDead(ctx context.Context, timeout time.Duration) (int, error) {
+	var (
+		ids   []int64
+		alive int
+	)
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		ids, err = s.repo.SetStaleOrbesSociiDead(ctx, time.Now().Add(-timeout))
+		if err != nil {
+			return err
+		}
+		if alive, err = s.repo.CountAliveOrbesSocii(ctx); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			id := id
+			if _, err := s.repo.InsertOrbisSociusStats(ctx, &id, false, nil); err != nil {
+				return err
+			}
+			if err := s.publishEvent(ctx, models.OrbisSociusHealthChangedEvent, id, healthChangedEventData{Alive: false}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 {
+		fxlogger.FromContext(ctx, s.logger).Info("orbes socii without heartbeats are marked as not alive", zap.Int64s("orbis_socius_ids", ids))
+	}
+	s.metrics.probes.WithLabelValues("success").Add(float64(alive))
+	s.metrics.probes.WithLabelValues("failure").Add(float64(len(ids)))
+
+	return len(ids), nil
+}
+
+// RunOrbesSociiLivenessCheck marks orbes socii without heartbeats as not
+// alive while app is running. Check is disabled if heartbeat timeout is 0.
+func RunOrbesSociiLivenessCheck(lc fx.Lifecycle, cfg *config.Config, s *Service, jobs *fxhealth.Jobs, logger *zap.Logger) {
+	if cfg.HeartbeatTimeout == 0 {
+		return
+	}
+	jobCtx := jobContext(logger, "liveness_check")
+	runPeriodically(lc, jobs, "liveness_check", cfg.HeartbeatInterval, func() {
+		ctx, cancel := context.WithTimeout(jobCtx, cfg.HeartbeatInterval)
+		defer cancel()
+		if _, err := s.MarkStaleOrbesSociiDead(ctx, cfg.HeartbeatTimeout); err != nil {
+			fxlogger.FromContext(ctx, logger).Error("can not check liveness of orbes socii", zap.Error(err))
+		}
+	})
+}
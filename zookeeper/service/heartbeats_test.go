@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	"github.com/ecumenos/ecumenos/internal/toolkit/webhooks"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkStaleOrbesSociiDead(t *testing.T) {
+	driver := &pgxtest.Driver{
+		OnQueryRows: func(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+			if strings.Contains(query, "set alive = false") {
+				return pgxtest.Rows([]interface{}{int64(1)}, []interface{}{int64(2)}), nil
+			}
+			return pgxtest.Rows(), nil
+		},
+		OnQueryRow: func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+			if strings.Contains(query, "insert into outbox_events") {
+				return pgxtest.Row(int64(1)), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+		OnCountRows: func(_ context.Context, query string, _ ...interface{}) (int, error) {
+			if strings.Contains(query, "from orbes_socii where") {
+				return 3, nil
+			}
+			return 0, nil
+		},
+	}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	count, err := s.MarkStaleOrbesSociiDead(context.Background(), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, float64(3), testutil.ToFloat64(s.metrics.probes.WithLabelValues("success")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(s.metrics.probes.WithLabelValues("failure")))
+
+	var events int
+	for _, q := range driver.Queries() {
+		assert.NotZero(t, q.Tx, "query is executed outside of transaction: %v", q.SQL)
+		if strings.Contains(q.SQL, "insert into outbox_events") && q.Args[1] == models.OrbisSociusHealthChangedEvent {
+			events++
+		}
+	}
+	assert.Equal(t, 2, events, "operators of dead orbes socii are not notified")
+}
+
+func TestMarkStaleOrbesSociiDeadWithoutStaleOrbesSocii(t *testing.T) {
+	driver := &pgxtest.Driver{}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	count, err := s.MarkStaleOrbesSociiDead(context.Background(), time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	var swept bool
+	for _, q := range driver.Queries() {
+		assert.NotContains(t, q.SQL, "insert into outbox_events")
+		if strings.Contains(q.SQL, "set alive = false") {
+			swept = true
+			assert.WithinDuration(t, time.Now().Add(-time.Minute), q.Args[0].(time.Time), time.Second, "threshold is not now minus timeout")
+		}
+	}
+	assert.True(t, swept)
+}
+
+// registrationDriver serves launch invite of API key "key" and its launch
+// request. Orbis socius with API key "registered" already exists.
+func registrationDriver(invite []interface{}, redeemed bool) *pgxtest.Driver {
+	return &pgxtest.Driver{
+		OnQueryRow: func(_ context.Context, query string, args ...interface{}) (pgx.Row, error) {
+			switch {
+			case strings.Contains(query, "from orbes_socii\n") && strings.Contains(query, "api_key=$1") && args[0] == "registered":
+				return orbisSociusRow(9, "registered"), nil
+			case strings.Contains(query, "from orbes_socii_launch_invites") && invite != nil:
+				return pgxtest.Row(invite...), nil
+			case strings.Contains(query, "from orbes_socii_launch_requests"):
+				return pgxtest.Row(int64(5), time.Now(), int64(3), "eu", "name", "description", "https://os.example.com", models.ApprovedOrbisSociusLaunchRequest), nil
+			case strings.Contains(query, "insert into outbox_events"):
+				return pgxtest.Row(int64(1)), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+		OnCountRows: func(_ context.Context, query string, _ ...interface{}) (int, error) {
+			if strings.Contains(query, "update orbes_socii_launch_invites") && redeemed {
+				return 1, nil
+			}
+			return 0, nil
+		},
+	}
+}
+
+func orbisSociusRow(id int64, apiKey string) pgx.Row {
+	now := time.Now()
+	return pgxtest.Row(id, now, now, nil, false, int64(3), int64(1), true, models.Vulnerable, now.Add(-time.Minute), "eu", "name", "description", "https://os.example.com", apiKey, "v1", int64(1), true)
+}
+
+func inviteRow(used bool, expiredAt time.Time) []interface{} {
+	return []interface{}{int64(7), time.Now(), int64(3), int64(1), nil, "code", "key", used, int64(5), expiredAt}
+}
+
+func TestRegisterOrbisSocius(t *testing.T) {
+	driver := registrationDriver(inviteRow(false, time.Now().Add(time.Hour)), true)
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	os, err := s.RegisterOrbisSocius(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, "key", os.APIKey)
+	assert.Equal(t, int64(3), os.OwnerComptusID)
+	assert.Equal(t, "https://os.example.com", os.URL)
+
+	queries := driver.Committed()
+	inserts := queriesOf(queries, "insert into orbes_socii")
+	require.Len(t, inserts, 1)
+	events := queriesOf(queries, "insert into outbox_events")
+	require.Len(t, events, 1)
+	assert.Equal(t, models.OrbisSociusLaunchInviteRedeemedEvent, events[0].Args[1])
+	assert.Equal(t, int64(7), events[0].Args[2])
+	assert.Equal(t, inserts[0].Tx, events[0].Tx, "invite is not redeemed in transaction of registration")
+}
+
+func TestRegisterOrbisSociusReturnsRegisteredOrbisSocius(t *testing.T) {
+	driver := registrationDriver(nil, false)
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	os, err := s.RegisterOrbisSocius(context.Background(), "registered")
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), os.ID)
+	assert.Empty(t, queriesOf(driver.Queries(), "insert into"))
+}
+
+func TestRegisterOrbisSociusRejectsInvalidInvites(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		invite   []interface{}
+		redeemed bool
+		code     apierrors.Code
+	}{
+		{name: "unknown API key", code: apierrors.OrbisSociusInvalidAPIKey},
+		{name: "used invite", invite: inviteRow(true, time.Now().Add(time.Hour)), code: apierrors.OrbisSociusInvalidAPIKey},
+		{name: "expired invite", invite: inviteRow(false, time.Now().Add(-time.Hour)), code: apierrors.OrbisSociusInviteExpired},
+		{name: "concurrent redemption", invite: inviteRow(false, time.Now().Add(time.Hour)), code: apierrors.OrbisSociusInvalidAPIKey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			driver := registrationDriver(tc.invite, tc.redeemed)
+			s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+			_, err := s.RegisterOrbisSocius(context.Background(), "key")
+			assert.True(t, errors.Is(err, apierrors.New(tc.code, "")), "unexpected error: %v", err)
+			assert.Empty(t, queriesOf(driver.Committed(), "insert into"))
+		})
+	}
+}
+
+func TestAuthorizeOrbisSocius(t *testing.T) {
+	body := []byte(`{"version":"v1","members":1,"capacity":10}`)
+	for _, tc := range []struct {
+		name      string
+		id        int64
+		signature string
+		ok        bool
+	}{
+		{name: "valid signature", id: 9, signature: webhooks.Sign([]byte("key"), time.Now(), body), ok: true},
+		{name: "signed by another key", id: 9, signature: webhooks.Sign([]byte("another"), time.Now(), body)},
+		{name: "stale signature", id: 9, signature: webhooks.Sign([]byte("key"), time.Now().Add(-time.Hour), body)},
+		{name: "replayed signature", id: 9, signature: webhooks.Sign([]byte("key"), time.Now().Add(-2*time.Minute), body)},
+		{name: "unknown orbis socius", id: 10, signature: webhooks.Sign([]byte("key"), time.Now(), body)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			driver := &pgxtest.Driver{
+				OnQueryRow: func(_ context.Context, query string, args ...interface{}) (pgx.Row, error) {
+					if strings.Contains(query, "from orbes_socii") && args[0] == int64(9) {
+						return orbisSociusRow(9, "key"), nil
+					}
+					return pgxtest.NoRows(), nil
+				},
+			}
+			s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+			os, err := s.AuthorizeOrbisSocius(context.Background(), tc.id, tc.signature, body)
+			if tc.ok {
+				require.NoError(t, err)
+				assert.Equal(t, int64(9), os.ID)
+				return
+			}
+			assert.True(t, errors.Is(err, apierrors.New(apierrors.OrbisSociusInvalidSignature, "")), "unexpected error: %v", err)
+			assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.heartbeats.WithLabelValues("rejected")))
+		})
+	}
+}
+
+func TestAuthorizeOrbisSociusRejectsTamperedBody(t *testing.T) {
+	driver := &pgxtest.Driver{
+		OnQueryRow: func(context.Context, string, ...interface{}) (pgx.Row, error) {
+			return orbisSociusRow(9, "key"), nil
+		},
+	}
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+	signature := webhooks.Sign([]byte("key"), time.Now(), []byte(`{"members":1}`))
+
+	_, err := s.AuthorizeOrbisSocius(context.Background(), 9, signature, []byte(`{"members":2}`))
+	assert.True(t, errors.Is(err, apierrors.New(apierrors.OrbisSociusInvalidSignature, "")), "unexpected error: %v", err)
+}
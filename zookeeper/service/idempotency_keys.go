@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/ecumenos/ecumenos/zookeeper/repository"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// RunIdempotencyKeysCleanup deletes expired idempotency keys periodically
+// while app is running. Cleanup is disabled if interval is 0.
+func RunIdempotencyKeysCleanup(lc fx.Lifecycle, cfg *config.Config, repo *repository.Repository, jobs *fxhealth.Jobs, logger *zap.Logger) {
+	if cfg.IdempotencyCleanup == 0 {
+		return
+	}
+	logger = logger.With(zap.String("job", "idempotency_keys_cleanup"))
+	runPeriodically(lc, jobs, "idempotency_keys_cleanup", cfg.IdempotencyCleanup, func() {
+		deleteExpiredIdempotencyKeys(repo, logger, cfg.IdempotencyCleanup)
+	})
+}
+
+func deleteExpiredIdempotencyKeys(repo *repository.Repository, logger *zap.Logger, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	count, err := repo.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		logger.Error("can not delete expired idempotency keys", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		logger.Info("expired idempotency keys are deleted", zap.Int64("count", count))
+	}
+}
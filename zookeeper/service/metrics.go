@@ -0,0 +1,88 @@
+package service
+
+import (
+	"github.com/ecumenos/ecumenos/internal/fxmetrics"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var launchRequestStatusLabels = map[models.OrbisSociusLaunchRequestStatus]string{
+	models.PendingOrbisSociusLaunchRequest:  "pending",
+	models.ViewedOrbisSociusLaunchRequest:   "viewed",
+	models.ApprovedOrbisSociusLaunchRequest: "approved",
+	models.RejectedOrbisSociusLaunchRequest: "rejected",
+}
+
+type metrics struct {
+	signUps           prometheus.Counter
+	launchRequests    *prometheus.CounterVec
+	webhookDeliveries *prometheus.CounterVec
+	events            *prometheus.CounterVec
+	heartbeats        *prometheus.CounterVec
+	probes            *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		signUps: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "zookeeper",
+			Name:      "sign_ups_total",
+			Help:      "Number of created compti.",
+		}),
+		launchRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "zookeeper",
+			Name:      "orbis_socius_launch_requests_total",
+			Help:      "Number of orbis socius launch requests moved to status, pending means created.",
+		}, []string{"status"}),
+		webhookDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "zookeeper",
+			Name:      "webhook_delivery_attempts_total",
+			Help:      "Number of webhook delivery attempts by result: delivered, retried or failed after the last attempt.",
+		}, []string{"result"}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "zookeeper",
+			Name:      "outbox_events_total",
+			Help:      "Number of outbox events passed to consumer by result: handled or failed.",
+		}, []string{"consumer", "result"}),
+		heartbeats: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "zookeeper",
+			Name:      "orbis_socius_heartbeats_total",
+			Help:      "Number of orbis socius heartbeats by result: accepted or rejected.",
+		}, []string{"result"}),
+		probes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: fxmetrics.Namespace,
+			Subsystem: "zookeeper",
+			Name:      "orbis_socius_probes_total",
+			Help:      "Number of orbes socii checked by liveness prober by result: success if orbis socius sent heartbeat during timeout, failure if it is marked as not alive.",
+		}, []string{"result"}),
+	}
+	if err := reg.Register(m.signUps); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.launchRequests); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.webhookDeliveries); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.events); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.heartbeats); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.probes); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *metrics) launchRequest(status models.OrbisSociusLaunchRequestStatus) {
+	m.launchRequests.WithLabelValues(launchRequestStatusLabels[status]).Inc()
+}
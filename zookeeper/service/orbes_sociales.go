@@ -2,26 +2,291 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/localenames"
+	"github.com/ecumenos/ecumenos/internal/toolkit/random"
 	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"golang.org/x/text/language"
 )
 
-func (s *Service) GetOrbisSociusCountries() []string {
-	return s.settings.GetCountries(true)
+const (
+	orbisSociusLaunchInviteTTL  = 7 * 24 * time.Hour
+	orbisSociusHealthStatsLimit = 50
+)
+
+// LocalePreferences returns languages in which names should be displayed for
+// comptus. Accept-Language header has priority over comptus lingua.
+func (s *Service) LocalePreferences(ctx context.Context, comptusID int64, acceptLanguage string) ([]language.Tag, error) {
+	c, err := s.repo.GetComptusByID(ctx, comptusID)
+	if err != nil {
+		return nil, err
+	}
+	var lingua string
+	if c != nil {
+		lingua = c.Lingua
+	}
+
+	return localenames.Preferences(acceptLanguage, lingua), nil
 }
 
-func (s *Service) GetOrbisSociusRegions(countryCode string) []string {
-	return s.settings.GetRegionsByCountryCode(countryCode)
+func (s *Service) GetOrbisSociusCountries(prefs []language.Tag) []localenames.Name {
+	codes := s.settings.GetCountries(true)
+	countries := make([]localenames.Name, 0, len(codes))
+	for _, code := range codes {
+		countries = append(countries, localenames.Country(code, prefs))
+	}
+
+	return countries
+}
+
+func (s *Service) GetOrbisSociusRegions(countryCode string, prefs []language.Tag) []localenames.Name {
+	codes := s.settings.GetRegionsByCountryCode(countryCode)
+	regions := make([]localenames.Name, 0, len(codes))
+	for _, code := range codes {
+		var displayName string
+		if r := s.settings.GetRegion(code); r != nil {
+			displayName = r.DisplayName
+		}
+		regions = append(regions, localenames.Region(code, displayName, prefs))
+	}
+
+	return regions
 }
 
 func (s *Service) MakeCreateOrbisSociusLaunchRequest(ctx context.Context, ownerID int64, region, name, desc, url string) (*models.OrbisSociusLaunchRequest, error) {
 	if err := s.settings.ValidateRegionCode(region); err != nil {
+		return nil, apierrors.Validation(apierrors.Field("region", apierrors.FieldInvalid, err.Error()))
+	}
+
+	req, err := s.repo.InsertOrbisSociusLaunchRequest(ctx, ownerID, region, name, desc, url, models.PendingOrbisSociusLaunchRequest)
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.launchRequest(req.Status)
+
+	return req, nil
+}
+
+func (s *Service) GetOrbisSociusLanguages(prefs []language.Tag) []localenames.Name {
+	codes := s.settings.GetLanguages(true)
+	languages := make([]localenames.Name, 0, len(codes))
+	for _, code := range codes {
+		languages = append(languages, localenames.Language(code, prefs))
+	}
+
+	return languages
+}
+
+func (s *Service) CreateOrbisSocius(ctx context.Context, ownerID int64, approverID *int64, region, name, desc, url, apiKey string) (*models.OrbisSocius, error) {
+	if err := s.settings.ValidateRegionCode(region); err != nil {
+		return nil, apierrors.Validation(apierrors.Field("region", apierrors.FieldInvalid, err.Error()))
+	}
+
+	return s.repo.InsertOrbisSocius(ctx, ownerID, approverID, region, name, desc, url, apiKey)
+}
+
+// UpdateOrbisSocius sets attributes of orbis socius. API key is changed only
+// if it is not empty.
+func (s *Service) UpdateOrbisSocius(ctx context.Context, id, ownerID int64, approverID *int64, region, name, desc, apiKey string) error {
+	if err := s.settings.ValidateRegionCode(region); err != nil {
+		return apierrors.Validation(apierrors.Field("region", apierrors.FieldInvalid, err.Error()))
+	}
+
+	return s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateOrbisSociusByID(ctx, id, ownerID, approverID, region, name, desc); err != nil {
+			return err
+		}
+		if apiKey == "" {
+			return nil
+		}
+
+		return s.repo.SetOrbisSociusAPIKeyByID(ctx, id, apiKey)
+	})
+}
+
+func (s *Service) GetOrbisSociusByURL(ctx context.Context, url string) (*models.OrbisSocius, error) {
+	return s.repo.GetOrbisSociusByURL(ctx, url)
+}
+
+func (s *Service) ListOrbesSocii(ctx context.Context, region string) ([]*models.OrbisSocius, error) {
+	if region != "" {
+		return s.repo.GetOrbesSociiByRegion(ctx, region)
+	}
+
+	return s.repo.GetOrbesSocii(ctx)
+}
+
+// GetOrbisSociusHealth returns orbis socius with its latest stats, the newest
+// first.
+func (s *Service) GetOrbisSociusHealth(ctx context.Context, id int64) (*models.OrbisSocius, []*models.OrbisSociusStat, error) {
+	os, err := s.repo.GetOrbisSociusByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if os == nil {
+		return nil, nil, apierrors.Newf(apierrors.OrbisSociusNotFound, "orbis socius is not found (id = %v)", id)
+	}
+	stats, err := s.repo.GetOrbisSociusStatsByOrbisSociusID(ctx, id, orbisSociusHealthStatsLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return os, stats, nil
+}
+
+// RotateOrbisSociusAPIKey generates new API key and notifies operator of
+// orbis socius. Key itself is never sent in webhook.
+func (s *Service) RotateOrbisSociusAPIKey(ctx context.Context, id int64) (string, error) {
+	if _, err := s.getOrbisSocius(ctx, id); err != nil {
+		return "", err
+	}
+	apiKey, err := random.GenNanoString(64)
+	if err != nil {
+		return "", err
+	}
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetOrbisSociusAPIKeyByID(ctx, id, apiKey); err != nil {
+			return err
+		}
+
+		return s.publishEvent(ctx, models.OrbisSociusAPIKeyRotatedEvent, id, struct{}{})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return apiKey, nil
+}
+
+func (s *Service) ListOrbisSociusLaunchRequests(ctx context.Context, status *models.OrbisSociusLaunchRequestStatus) ([]*models.OrbisSociusLaunchRequest, error) {
+	return s.repo.GetOrbisSociusLaunchRequests(ctx, status)
+}
+
+// ViewOrbisSociusLaunchRequest returns launch request and marks pending
+// launch request as viewed.
+func (s *Service) ViewOrbisSociusLaunchRequest(ctx context.Context, id int64) (*models.OrbisSociusLaunchRequest, error) {
+	req, err := s.getOrbisSociusLaunchRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != models.PendingOrbisSociusLaunchRequest {
+		return req, nil
+	}
+	if err := s.repo.SetOrbisSociusLaunchRequestStatusByID(ctx, id, models.ViewedOrbisSociusLaunchRequest); err != nil {
+		return nil, err
+	}
+	req.Status = models.ViewedOrbisSociusLaunchRequest
+	s.metrics.launchRequest(req.Status)
+
+	return req, nil
+}
+
+// ApproveOrbisSociusLaunchRequest approves launch request and creates launch
+// invite with API key for comptus of the request.
+func (s *Service) ApproveOrbisSociusLaunchRequest(ctx context.Context, id, adminID int64) (*models.OrbisSociusLaunchRequest, *models.OrbisSociusLaunchInvite, error) {
+	code, err := random.GenNanoString(16)
+	if err != nil {
+		return nil, nil, err
+	}
+	apiKey, err := random.GenNanoString(64)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		req    *models.OrbisSociusLaunchRequest
+		invite *models.OrbisSociusLaunchInvite
+	)
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if req, err = s.getReviewableOrbisSociusLaunchRequest(ctx, id); err != nil {
+			return err
+		}
+		invite, err = s.repo.InsertOrbisSociusLaunchInvite(ctx, req.ComptusID, adminID, nil, code, apiKey, &req.ID, time.Now().Add(orbisSociusLaunchInviteTTL))
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetOrbisSociusLaunchRequestStatusByID(ctx, id, models.ApprovedOrbisSociusLaunchRequest); err != nil {
+			return err
+		}
+
+		return s.publishEvent(ctx, models.OrbisSociusLaunchRequestApprovedEvent, id, launchRequestApprovedEventData{
+			ComptusID:     req.ComptusID,
+			AdminID:       adminID,
+			InviteID:      invite.ID,
+			InviteExpires: invite.ExpiredAt,
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Status = models.ApprovedOrbisSociusLaunchRequest
+	s.metrics.launchRequest(req.Status)
+
+	return req, invite, nil
+}
+
+func (s *Service) RejectOrbisSociusLaunchRequest(ctx context.Context, id int64) (*models.OrbisSociusLaunchRequest, error) {
+	var req *models.OrbisSociusLaunchRequest
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if req, err = s.getReviewableOrbisSociusLaunchRequest(ctx, id); err != nil {
+			return err
+		}
+		if err := s.repo.SetOrbisSociusLaunchRequestStatusByID(ctx, id, models.RejectedOrbisSociusLaunchRequest); err != nil {
+			return err
+		}
+
+		return s.publishEvent(ctx, models.OrbisSociusLaunchRequestRejectedEvent, id, launchRequestRejectedEventData{ComptusID: req.ComptusID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Status = models.RejectedOrbisSociusLaunchRequest
+	s.metrics.launchRequest(req.Status)
+
+	return req, nil
+}
+
+func (s *Service) getOrbisSocius(ctx context.Context, id int64) (*models.OrbisSocius, error) {
+	os, err := s.repo.GetOrbisSociusByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if os == nil {
+		return nil, apierrors.Newf(apierrors.OrbisSociusNotFound, "orbis socius is not found (id = %v)", id)
+	}
+
+	return os, nil
+}
+
+func (s *Service) getOrbisSociusLaunchRequest(ctx context.Context, id int64) (*models.OrbisSociusLaunchRequest, error) {
+	req, err := s.repo.GetOrbisSociusLaunchRequestByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
+	if req == nil {
+		return nil, apierrors.Newf(apierrors.OrbisSociusLaunchRequestNotFound, "launch request is not found (id = %v)", id)
+	}
 
-	return s.repo.InsertOrbisSociusLaunchRequest(ctx, ownerID, region, name, desc, url, models.PendingOrbisSociusLaunchRequest)
+	return req, nil
 }
 
-func (s *Service) GetOrbisSociusLanguages() []string {
-	return s.settings.GetLanguages(true)
+// getReviewableOrbisSociusLaunchRequest locks pending launch request. It must
+// be called in transaction, so concurrent reviews of the same request are
+// serialized and only the first one succeeds.
+func (s *Service) getReviewableOrbisSociusLaunchRequest(ctx context.Context, id int64) (*models.OrbisSociusLaunchRequest, error) {
+	req, err := s.repo.LockOrbisSociusLaunchRequestByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, apierrors.Newf(apierrors.OrbisSociusLaunchRequestNotFound, "launch request is not found (id = %v)", id)
+	}
+	if req.Status == models.ApprovedOrbisSociusLaunchRequest || req.Status == models.RejectedOrbisSociusLaunchRequest {
+		return nil, apierrors.Newf(apierrors.OrbisSociusLaunchRequestReviewed, "launch request is already reviewed (id = %v)", id)
+	}
+
+	return req, nil
 }
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// launchRequestDriver serves launch request with status.
+func launchRequestDriver(status models.OrbisSociusLaunchRequestStatus) *pgxtest.Driver {
+	return &pgxtest.Driver{
+		OnQueryRow: func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+			switch {
+			case strings.Contains(query, "from orbes_socii_launch_requests"):
+				return pgxtest.Row(int64(1), time.Now(), int64(2), "eu_east", "name", "desc", "https://os.example.com", status), nil
+			case strings.Contains(query, "insert into outbox_events"):
+				return pgxtest.Row(int64(1)), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+	}
+}
+
+func TestApproveOrbisSociusLaunchRequest(t *testing.T) {
+	driver := launchRequestDriver(models.ViewedOrbisSociusLaunchRequest)
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+	req, invite, err := s.ApproveOrbisSociusLaunchRequest(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, models.ApprovedOrbisSociusLaunchRequest, req.Status)
+	assert.Equal(t, int64(2), invite.ComptusID)
+	assert.NotEmpty(t, invite.APIKey)
+
+	var locked, inserted, updated bool
+	for _, q := range driver.Queries() {
+		assert.NotZero(t, q.Tx, "query is executed outside of transaction: %v", q.SQL)
+		switch {
+		case strings.Contains(q.SQL, "for update") && strings.Contains(q.SQL, "orbes_socii_launch_requests"):
+			locked = true
+		case strings.Contains(q.SQL, "insert into orbes_socii_launch_invites"):
+			inserted = true
+		case strings.Contains(q.SQL, "update orbes_socii_launch_requests set status"):
+			updated = true
+		}
+	}
+	assert.True(t, locked, "launch request is not locked")
+	assert.True(t, inserted, "invite is not inserted")
+	assert.True(t, updated, "status is not updated")
+}
+
+func TestReviewedOrbisSociusLaunchRequestIsNotApprovedAgain(t *testing.T) {
+	for _, status := range []models.OrbisSociusLaunchRequestStatus{models.ApprovedOrbisSociusLaunchRequest, models.RejectedOrbisSociusLaunchRequest} {
+		driver := launchRequestDriver(status)
+		s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+
+		_, _, err := s.ApproveOrbisSociusLaunchRequest(context.Background(), 1, 3)
+		require.ErrorIs(t, err, apierrors.New(apierrors.OrbisSociusLaunchRequestReviewed, ""))
+		_, err = s.RejectOrbisSociusLaunchRequest(context.Background(), 1)
+		require.ErrorIs(t, err, apierrors.New(apierrors.OrbisSociusLaunchRequestReviewed, ""))
+
+		for _, q := range driver.Queries() {
+			assert.NotContains(t, q.SQL, "insert into", "reviewed request is approved again")
+			assert.NotContains(t, q.SQL, "set status", "reviewed request is reviewed again")
+		}
+	}
+}
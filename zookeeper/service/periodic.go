@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// runPeriodically calls fn every interval while app is running and records
+// ticks of job, so stuck job is reported by readiness check. Stop waits for
+// the current call to return.
+func runPeriodically(lc fx.Lifecycle, jobs *fxhealth.Jobs, job string, interval time.Duration, fn func()) {
+	tracked := jobs.Register(job, interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(stopped)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-done:
+						return
+					case <-ticker.C:
+						fn()
+						tracked.Tick(interval)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(done)
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+// jobContext returns context of background job. Services and repositories
+// log via fxlogger.FromContext, so their logs are marked by job.
+func jobContext(logger *zap.Logger, job string) context.Context {
+	return fxlogger.WithContext(context.Background(), logger.With(zap.String("job", job)))
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxtypes"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+)
+
+type complianceChangedEventData struct {
+	Compliant          bool   `json:"compliant"`
+	ProtocolVersion    *int64 `json:"protocol_version"`
+	MinProtocolVersion int    `json:"min_protocol_version"`
+}
+
+func (s *Service) GetOrbesSociiMinProtocolVersion(ctx context.Context) (int, error) {
+	return s.repo.GetOrbesSociiMinProtocolVersion(ctx)
+}
+
+func (s *Service) CountNonCompliantOrbesSocii(ctx context.Context) (int, error) {
+	return s.repo.CountNonCompliantOrbesSocii(ctx)
+}
+
+// SetOrbesSociiMinProtocolVersion sets minimal protocol version of orbes
+// socii and checks their compliance. Operators of orbes socii which
+// compliance is changed are notified.
+func (s *Service) SetOrbesSociiMinProtocolVersion(ctx context.Context, adminID int64, minProtocolVersion int) error {
+	if minProtocolVersion < 0 || minProtocolVersion > int(fxtypes.CurrentProtocolVersion) {
+		msg := fmt.Sprintf("min protocol version must be between 0 and %v", fxtypes.CurrentProtocolVersion)
+		return apierrors.Validation(apierrors.Field("min_protocol_version", apierrors.FieldInvalid, msg))
+	}
+
+	return s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetOrbesSociiMinProtocolVersion(ctx, minProtocolVersion, adminID); err != nil {
+			return err
+		}
+		changed, err := s.repo.UpdateOrbesSociiCompliance(ctx, minProtocolVersion)
+		if err != nil {
+			return err
+		}
+		for _, c := range changed {
+			if err := s.publishComplianceChanged(ctx, c, minProtocolVersion); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// updateOrbisSociusProtocol stores versions reported by orbis socius and
+// notifies operator if its compliance is changed. It must be called in
+// transaction.
+func (s *Service) updateOrbisSociusProtocol(ctx context.Context, id int64, heartbeat *models.OrbisSociusHeartbeat) error {
+	wasCompliant, compliant, err := s.repo.SetOrbisSociusProtocolByID(ctx, id, heartbeat.Version, heartbeat.ProtocolVersion)
+	if err != nil || wasCompliant == compliant {
+		return err
+	}
+	minProtocolVersion, err := s.repo.GetOrbesSociiMinProtocolVersion(ctx)
+	if err != nil {
+		return err
+	}
+	c := &models.OrbisSociusCompliance{OrbisSociusID: id, Compliant: compliant}
+	if heartbeat.ProtocolVersion > 0 {
+		c.ProtocolVersion.Int64 = int64(heartbeat.ProtocolVersion)
+		c.ProtocolVersion.Valid = true
+	}
+
+	return s.publishComplianceChanged(ctx, c, minProtocolVersion)
+}
+
+func (s *Service) publishComplianceChanged(ctx context.Context, c *models.OrbisSociusCompliance, minProtocolVersion int) error {
+	data := complianceChangedEventData{
+		Compliant:          c.Compliant,
+		MinProtocolVersion: minProtocolVersion,
+	}
+	if c.ProtocolVersion.Valid {
+		data.ProtocolVersion = &c.ProtocolVersion.Int64
+	}
+
+	return s.publishEvent(ctx, models.OrbisSociusComplianceChangedEvent, c.OrbisSociusID, data)
+}
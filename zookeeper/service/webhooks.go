@@ -0,0 +1,366 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/apierrors"
+	"github.com/ecumenos/ecumenos/internal/fxhealth"
+	"github.com/ecumenos/ecumenos/internal/fxlogger"
+	"github.com/ecumenos/ecumenos/internal/toolkit/netutils"
+	"github.com/ecumenos/ecumenos/internal/toolkit/random"
+	"github.com/ecumenos/ecumenos/internal/toolkit/webhooks"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookDeliveriesLimit = 100
+	webhookBatchSize       = 20
+	webhookMinBackoff      = 30 * time.Second
+	webhookMaxBackoff      = 6 * time.Hour
+	webhookMaxErrorLength  = 512
+)
+
+// newWebhookClient returns client which refuses to connect to internal
+// network, unless private addresses are allowed. Address is checked after
+// resolution, so host names resolved to internal addresses are refused too.
+func newWebhookClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = netutils.DenyPrivateAddress
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		// proxy would connect to receiver instead of dialer.
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// redirect is treated as failed attempt, receiver must respond
+		// by itself.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// SetOrbisSociusWebhook sets webhook URL with new signing secret and sends
+// ping event to it. Secret is not rotated if ping can not be scheduled.
+func (s *Service) SetOrbisSociusWebhook(ctx context.Context, orbisSociusID int64, webhookURL string) (*models.OrbisSociusWebhook, error) {
+	if err := validateWebhookURL(webhookURL, s.webhookAllowPrivate); err != nil {
+		return nil, apierrors.Validation(apierrors.Field("url", apierrors.FieldInvalid, err.Error()))
+	}
+	if _, err := s.getOrbisSocius(ctx, orbisSociusID); err != nil {
+		return nil, err
+	}
+	secret, err := random.GenNanoString(48)
+	if err != nil {
+		return nil, err
+	}
+	var webhook *models.OrbisSociusWebhook
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		webhook, err = s.repo.UpsertOrbisSociusWebhook(ctx, orbisSociusID, webhookURL, secret)
+		if err != nil {
+			return err
+		}
+		return s.enqueueWebhook(ctx, orbisSociusID, models.PingWebhookEvent, struct{}{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// validateWebhookURL rejects URLs of internal network which are known
+// before resolution of host. The rest is refused by webhook client.
+func validateWebhookURL(v string, allowPrivate bool) error {
+	u, err := url.Parse(v)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.New("url must be absolute http or https URL")
+	}
+	if allowPrivate {
+		return nil
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.New("url must not point to localhost")
+	}
+	if ip := net.ParseIP(host); ip != nil && !netutils.IsPublicIP(ip) {
+		return errors.New("url must not point to loopback, link-local or private address")
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteOrbisSociusWebhook(ctx context.Context, orbisSociusID int64) error {
+	if _, err := s.getOrbisSocius(ctx, orbisSociusID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteOrbisSociusWebhook(ctx, orbisSociusID)
+}
+
+// DelistOrbisSocius tombstones orbis socius, so it disappears from listings,
+// and notifies its operator.
+func (s *Service) DelistOrbisSocius(ctx context.Context, id int64) (*models.OrbisSocius, error) {
+	os, err := s.getOrbisSocius(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetOrbisSociusTombstonedByID(ctx, id); err != nil {
+			return err
+		}
+
+		return s.publishEvent(ctx, models.OrbisSociusDelistedEvent, id, struct{}{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	os.Tombstoned = true
+
+	return os, nil
+}
+
+func (s *Service) ListWebhookDeliveries(ctx context.Context, orbisSociusID int64, status *models.WebhookDeliveryStatus) ([]*models.WebhookDelivery, error) {
+	return s.repo.GetWebhookDeliveries(ctx, orbisSociusID, status, webhookDeliveriesLimit)
+}
+
+// RedeliverWebhook schedules delivery to be sent again. Payload and ID of
+// delivery are kept, so receiver can skip it if it was already processed.
+func (s *Service) RedeliverWebhook(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	d, err := s.repo.GetWebhookDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, apierrors.Newf(apierrors.WebhookDeliveryNotFound, "webhook delivery is not found (id = %v)", id)
+	}
+	now := time.Now()
+	if err := s.repo.ScheduleWebhookDelivery(ctx, id, now); err != nil {
+		return nil, err
+	}
+	d.Status = models.ScheduledWebhookDelivery
+	d.Attempts = 0
+	d.NextAttemptAt = sql.NullTime{Time: now, Valid: true}
+
+	return d, nil
+}
+
+var webhookEvents = map[models.EventType]models.WebhookEvent{
+	models.OrbisSociusAPIKeyRotatedEvent:     models.OrbisSociusAPIKeyRotatedWebhookEvent,
+	models.OrbisSociusDelistedEvent:          models.OrbisSociusDelistedWebhookEvent,
+	models.OrbisSociusHealthChangedEvent:     models.OrbisSociusHealthChangedWebhookEvent,
+	models.OrbisSociusComplianceChangedEvent: models.OrbisSociusComplianceChangedWebhookEvent,
+}
+
+// handleWebhookEvent enqueues webhook for events of orbis socius. Delivery is
+// inserted in transaction of consumer offset, so it is enqueued once.
+func (s *Service) handleWebhookEvent(ctx context.Context, e *models.Event) error {
+	event, ok := webhookEvents[e.Type]
+	if !ok {
+		return nil
+	}
+
+	return s.enqueueWebhook(ctx, e.AggregateID, event, json.RawMessage(e.Data))
+}
+
+// enqueueWebhook schedules delivery of event if orbis socius has webhook.
+func (s *Service) enqueueWebhook(ctx context.Context, orbisSociusID int64, event models.WebhookEvent, data interface{}) error {
+	webhook, err := s.repo.GetOrbisSociusWebhook(ctx, orbisSociusID)
+	if err != nil || webhook == nil {
+		return err
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.InsertWebhookDelivery(ctx, orbisSociusID, event, payload)
+
+	return err
+}
+
+// DeliverDueWebhooks sends batch of deliveries whose attempt is due. It
+// returns number of sent deliveries.
+func (s *Service) DeliverDueWebhooks(ctx context.Context) (int, error) {
+	logger := fxlogger.FromContext(ctx, s.logger)
+	now := time.Now()
+	// lease outlives attempts of batch, expired lease makes deliveries of
+	// crashed replica due again.
+	lease := now.Add(webhookBatchSize * (s.webhookClient.Timeout + time.Second))
+	deliveries, err := s.repo.ClaimDueWebhookDeliveries(ctx, now, lease, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, d := range deliveries {
+		if err := s.deliverWebhook(ctx, d); err != nil {
+			logger.Error("can not store webhook delivery attempt", zap.Int64("webhook_delivery_id", d.ID), zap.Error(err))
+			continue
+		}
+		if d.Status != models.DeliveredWebhookDelivery {
+			logger.Warn("webhook delivery attempt failed",
+				zap.Int64("webhook_delivery_id", d.ID), zap.Int64("orbis_socius_id", d.OrbisSociusID),
+				zap.Int("attempts", d.Attempts), zap.String("error", d.LastError.String))
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (s *Service) deliverWebhook(ctx context.Context, d *models.WebhookDelivery) error {
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = sql.NullTime{Time: now, Valid: true}
+	d.LastStatusCode = sql.NullInt32{}
+	d.LastError = sql.NullString{}
+
+	statusCode, err := s.sendWebhook(ctx, d)
+	if statusCode != 0 {
+		d.LastStatusCode = sql.NullInt32{Int32: int32(statusCode), Valid: true}
+	}
+	switch {
+	case err == nil:
+		d.Status = models.DeliveredWebhookDelivery
+		d.NextAttemptAt = sql.NullTime{}
+		d.DeliveredAt = sql.NullTime{Time: now, Valid: true}
+		s.metrics.webhookDeliveries.WithLabelValues("delivered").Inc()
+	case d.Attempts >= s.webhookMaxAttempts || errors.Is(err, errWebhookNotConfigured) || errors.Is(err, netutils.ErrPrivateAddress):
+		d.Status = models.FailedWebhookDelivery
+		d.NextAttemptAt = sql.NullTime{}
+		s.metrics.webhookDeliveries.WithLabelValues("failed").Inc()
+	default:
+		d.NextAttemptAt = sql.NullTime{Time: now.Add(webhookBackoff(d.Attempts)), Valid: true}
+		s.metrics.webhookDeliveries.WithLabelValues("retried").Inc()
+	}
+	if err != nil {
+		msg := err.Error()
+		if len(msg) > webhookMaxErrorLength {
+			msg = msg[:webhookMaxErrorLength]
+		}
+		d.LastError = sql.NullString{String: msg, Valid: true}
+	}
+
+	return s.repo.UpdateWebhookDeliveryAttempt(ctx, d)
+}
+
+var errWebhookNotConfigured = errors.New("webhook is not configured")
+
+func (s *Service) sendWebhook(ctx context.Context, d *models.WebhookDelivery) (int, error) {
+	webhook, err := s.repo.GetOrbisSociusWebhook(ctx, d.OrbisSociusID)
+	if err != nil {
+		return 0, err
+	}
+	if webhook == nil {
+		return 0, errWebhookNotConfigured
+	}
+	body, err := json.Marshal(webhooks.Event{
+		ID:            d.ID,
+		Type:          string(d.Event),
+		OrbisSociusID: d.OrbisSociusID,
+		CreatedAt:     d.CreatedAt,
+		Data:          d.Data,
+	})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("%v/%v", config.ServiceName, config.ServiceVersion))
+	req.Header.Set(webhooks.EventHeader, string(d.Event))
+	req.Header.Set(webhooks.DeliveryHeader, fmt.Sprint(d.ID))
+	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign([]byte(webhook.Secret), time.Now(), body))
+	resp, err := s.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status code (status code = %v)", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// webhookBackoff doubles delay after every failed attempt.
+func webhookBackoff(attempts int) time.Duration {
+	delay := webhookMinBackoff
+	for i := 1; i < attempts && delay < webhookMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+
+	return delay
+}
+
+// RunWebhookDispatcher delivers scheduled webhooks while app is running.
+// Dispatcher is disabled if poll interval is 0.
+func RunWebhookDispatcher(lc fx.Lifecycle, cfg *config.Config, s *Service, jobs *fxhealth.Jobs, logger *zap.Logger) {
+	if cfg.WebhookPollInterval == 0 {
+		return
+	}
+	ctx := jobContext(logger, "webhook_dispatcher")
+	runPeriodically(lc, jobs, "webhook_dispatcher", cfg.WebhookPollInterval, func() {
+		for {
+			n, err := s.DeliverDueWebhooks(ctx)
+			if err != nil {
+				logger.Error("can not deliver webhooks", zap.Error(err))
+				return
+			}
+			// full batch means more deliveries may be due.
+			if n < webhookBatchSize {
+				return
+			}
+		}
+	})
+}
+
+// RunWebhookDeliveriesCleanup deletes finished deliveries after retention
+// period while app is running. Cleanup is disabled if retention is 0.
+func RunWebhookDeliveriesCleanup(lc fx.Lifecycle, cfg *config.Config, s *Service, jobs *fxhealth.Jobs, logger *zap.Logger) {
+	if cfg.WebhookRetention == 0 {
+		return
+	}
+	ctx := jobContext(logger, "webhook_deliveries_cleanup")
+	runPeriodically(lc, jobs, "webhook_deliveries_cleanup", time.Hour, func() {
+		s.deleteFinishedWebhookDeliveries(ctx, cfg.WebhookRetention)
+	})
+}
+
+func (s *Service) deleteFinishedWebhookDeliveries(ctx context.Context, retention time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	logger := fxlogger.FromContext(ctx, s.logger)
+	count, err := s.repo.DeleteFinishedWebhookDeliveries(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logger.Error("can not delete finished webhook deliveries", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		logger.Info("finished webhook deliveries are deleted", zap.Int64("count", count))
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecumenos/ecumenos/internal/fxpostgres/pgxtest"
+	"github.com/ecumenos/ecumenos/internal/toolkit/webhooks"
+	models "github.com/ecumenos/ecumenos/models/zookeeper"
+	"github.com/ecumenos/ecumenos/zookeeper/config"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "webhooksecret"
+
+// webhookDriver serves due delivery with given number of attempts and
+// webhook of its orbis socius.
+func webhookDriver(url string, attempts int) *pgxtest.Driver {
+	d := &pgxtest.Driver{}
+	d.OnQueryRows = func(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+		if !strings.Contains(query, "update webhook_deliveries d") {
+			return pgxtest.Rows(), nil
+		}
+		return pgxtest.Rows([]interface{}{
+			int64(1), time.Now(), int64(2), models.OrbisSociusDelistedWebhookEvent, []byte("{}"),
+			models.ScheduledWebhookDelivery, attempts, time.Now(), nil, nil, nil, nil,
+		}), nil
+	}
+	d.OnQueryRow = func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+		if strings.Contains(query, "from orbes_socii_webhooks") {
+			return pgxtest.Row(int64(2), time.Now(), time.Now(), url, testWebhookSecret), nil
+		}
+		return pgxtest.NoRows(), nil
+	}
+
+	return d
+}
+
+// storedAttempt returns stored status, attempts and next attempt of delivery.
+func storedAttempt(t *testing.T, driver *pgxtest.Driver) (models.WebhookDeliveryStatus, int, sql.NullTime) {
+	for _, q := range driver.Queries() {
+		if strings.Contains(q.SQL, "set status=$2, attempts=$3") {
+			return q.Args[1].(models.WebhookDeliveryStatus), q.Args[2].(int), q.Args[3].(sql.NullTime)
+		}
+	}
+	t.Fatal("delivery attempt is not stored")
+
+	return 0, 0, sql.NullTime{}
+}
+
+func newWebhookTestService(t *testing.T, driver *pgxtest.Driver) *Service {
+	s := newTestService(t, driver, &fakeSettings{}, config.FileAppSettingsSource)
+	s.webhookClient = newWebhookClient(time.Second, true)
+	s.webhookMaxAttempts = 3
+
+	return s
+}
+
+func TestDeliverDueWebhooksSendsSignedEvent(t *testing.T) {
+	var event *webhooks.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var err error
+		event, err = webhooks.VerifyRequest(r, []byte(testWebhookSecret), time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", r.Header.Get(webhooks.DeliveryHeader))
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	driver := webhookDriver(srv.URL, 0)
+	s := newWebhookTestService(t, driver)
+
+	n, err := s.DeliverDueWebhooks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.NotNil(t, event)
+	assert.Equal(t, string(models.OrbisSociusDelistedWebhookEvent), event.Type)
+	assert.Equal(t, int64(2), event.OrbisSociusID)
+	status, attempts, next := storedAttempt(t, driver)
+	assert.Equal(t, models.DeliveredWebhookDelivery, status)
+	assert.Equal(t, 1, attempts)
+	assert.False(t, next.Valid)
+}
+
+func TestDeliverDueWebhooksRetriesWithBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	driver := webhookDriver(srv.URL, 1)
+	s := newWebhookTestService(t, driver)
+
+	start := time.Now()
+	_, err := s.DeliverDueWebhooks(context.Background())
+	require.NoError(t, err)
+	status, attempts, next := storedAttempt(t, driver)
+	assert.Equal(t, models.ScheduledWebhookDelivery, status)
+	assert.Equal(t, 2, attempts)
+	require.True(t, next.Valid)
+	assert.WithinDuration(t, start.Add(2*webhookMinBackoff), next.Time, time.Second)
+}
+
+func TestDeliverDueWebhooksFailsAfterMaxAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	driver := webhookDriver(srv.URL, 2)
+	s := newWebhookTestService(t, driver)
+
+	_, err := s.DeliverDueWebhooks(context.Background())
+	require.NoError(t, err)
+	status, attempts, next := storedAttempt(t, driver)
+	assert.Equal(t, models.FailedWebhookDelivery, status)
+	assert.Equal(t, 3, attempts)
+	assert.False(t, next.Valid)
+}
+
+func TestDeliverDueWebhooksRefusesPrivateAddress(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+	// name of receiver is resolved to loopback address.
+	url := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+	driver := webhookDriver(url, 0)
+	s := newWebhookTestService(t, driver)
+	s.webhookClient = newWebhookClient(time.Second, false)
+
+	_, err := s.DeliverDueWebhooks(context.Background())
+	require.NoError(t, err)
+	assert.False(t, called)
+	status, _, _ := storedAttempt(t, driver)
+	assert.Equal(t, models.FailedWebhookDelivery, status, "refused address must not be retried")
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, webhookMinBackoff, webhookBackoff(1))
+	assert.Equal(t, 2*webhookMinBackoff, webhookBackoff(2))
+	assert.Equal(t, 8*webhookMinBackoff, webhookBackoff(4))
+	assert.Equal(t, webhookMaxBackoff, webhookBackoff(100))
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, v := range []string{
+		"ftp://example.com",
+		"/webhooks",
+		"http://localhost:8080/webhooks",
+		"http://127.0.0.1/webhooks",
+		"http://[::1]/webhooks",
+		"http://10.0.0.1/webhooks",
+		"http://169.254.169.254/latest/meta-data",
+	} {
+		assert.Error(t, validateWebhookURL(v, false), v)
+	}
+	assert.NoError(t, validateWebhookURL("https://example.com/webhooks", false))
+	assert.NoError(t, validateWebhookURL("http://localhost:8080/webhooks", true))
+}
+
+func TestSetOrbisSociusWebhookDoesNotRotateSecretWithoutPing(t *testing.T) {
+	driver := &pgxtest.Driver{
+		OnQueryRow: func(_ context.Context, query string, _ ...interface{}) (pgx.Row, error) {
+			switch {
+			case strings.Contains(query, "orbes_socii_webhooks"):
+				return pgxtest.Row(int64(2), time.Now(), time.Now(), "https://os.example.com/webhooks", testWebhookSecret), nil
+			case strings.Contains(query, "from orbes_socii"):
+				return orbisSociusRow(2, "key"), nil
+			}
+			return pgxtest.NoRows(), nil
+		},
+		OnExec: func(_ context.Context, query string, _ ...interface{}) error {
+			if strings.Contains(query, "insert into webhook_deliveries") {
+				return errors.New("connection reset")
+			}
+			return nil
+		},
+	}
+	s := newWebhookTestService(t, driver)
+
+	_, err := s.SetOrbisSociusWebhook(context.Background(), 2, "https://os.example.com/webhooks")
+	require.Error(t, err)
+	require.Len(t, queriesOf(driver.Queries(), "insert into orbes_socii_webhooks"), 1)
+	assert.Empty(t, queriesOf(driver.Committed(), "insert into orbes_socii_webhooks"), "secret is rotated without ping")
+}